@@ -0,0 +1,439 @@
+package main
+
+import (
+	"bufio"
+	"cloud-storage/biz/audit"
+	"cloud-storage/biz/blob"
+	"cloud-storage/biz/config"
+	"cloud-storage/biz/dal"
+	"cloud-storage/biz/dal/query"
+	"cloud-storage/biz/dal/repository"
+	"cloud-storage/biz/mail"
+	"cloud-storage/biz/mw"
+	"cloud-storage/biz/service"
+	"cloud-storage/biz/vfs"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const adminUsage = `usage: %s admin [-config path] <command> [arguments]
+
+commands:
+  user create [-email address] [-admin] <name>
+                          create a user with the password read from stdin
+  user passwd <name>      reset the password of a user to one read from stdin
+  user disable <name>     keep a user from logging in or using their keys
+  user enable <name>      let a disabled user back in
+  user quota <name> <bytes>
+                          set the storage quota of a user, 0 for the default
+                          and -1 for none
+  user admin <name> true|false
+                          grant or revoke administrator rights
+  user reset-2fa <name>   turn off the two-factor authentication of a user
+                          who lost their authenticator and recovery codes
+  tree <name> [path]      list the files of a user
+  gc [-grace duration]    remove the content no file or share refers to
+  check [-verify]         look for missing or damaged content; -verify reads
+                          every blob back and compares it with its hash
+  import <name> <dir> [path]
+                          copy a local directory into the files of a user
+  export <name> <dir> [path]
+                          copy the files of a user into a local directory
+  audit [-user name] [-action action] [-since time] [-until time]
+                          write the audit log as JSON lines, oldest first;
+                          times are in RFC 3339
+`
+
+// errUsage makes runAdmin print the usage and exit with code 2.
+var errUsage = errors.New("usage")
+
+// runAdmin runs the admin subcommand with its arguments and returns the
+// exit code.
+func runAdmin(args []string) int {
+	fs := flag.NewFlagSet("admin", flag.ExitOnError)
+	path := fs.String("config", "", "path of the YAML or TOML configuration file (default $"+config.PathEnv+")")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), adminUsage, os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	commands := map[string]func(ctx context.Context, args []string) error{
+		"user":   adminUser,
+		"tree":   adminTree,
+		"gc":     adminGC,
+		"check":  adminCheck,
+		"import": adminImport,
+		"export": adminExport,
+		"audit":  adminAudit,
+	}
+	command, ok := commands[fs.Arg(0)]
+	if !ok {
+		fs.Usage()
+		return 2
+	}
+
+	cfg, err := config.Load(*path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load configuration: %v\n", err)
+		return 1
+	}
+	blob.Dir = cfg.Storage.BlobDir()
+	dal.Init(&cfg.Database)
+	service.Init(repository.New(query.Q), service.Options{
+		Tokens:     service.Tokens{Access: mw.GenerateToken, Challenge: mw.GenerateChallenge},
+		UserQuota:  cfg.Quota.UserBytes,
+		Lockout:    service.Lockout{Threshold: cfg.Lockout.Threshold, Duration: cfg.Lockout.Duration},
+		TOTPIssuer: cfg.TwoFactor.Issuer,
+		Mailer:     mail.New(&cfg.Mailer),
+		PasswordReset: service.PasswordReset{
+			URL: cfg.PasswordReset.URL,
+			TTL: cfg.PasswordReset.TTL,
+		},
+	})
+
+	// Actions of the command are audited with no actor
+	ctx := audit.WithClient(context.Background(), "", "cloud-storage admin")
+	err = command(ctx, fs.Args()[1:])
+	if errors.Is(err, errUsage) {
+		fs.Usage()
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+func adminUser(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	if args[0] == "create" {
+		return adminUserCreate(ctx, args[1:])
+	}
+	if len(args) < 2 {
+		return errUsage
+	}
+	u, err := service.Users.Find(ctx, args[1])
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case args[0] == "passwd" && len(args) == 2:
+		password, err := readPassword()
+		if err != nil {
+			return err
+		}
+		return service.Users.ResetPassword(ctx, u.Identity, password)
+	case args[0] == "disable" && len(args) == 2:
+		return service.Users.SetDisabled(ctx, u.Identity, true)
+	case args[0] == "enable" && len(args) == 2:
+		return service.Users.SetDisabled(ctx, u.Identity, false)
+	case args[0] == "quota" && len(args) == 3:
+		quota, err := strconv.ParseInt(args[2], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid quota %q", args[2])
+		}
+		return service.Users.SetQuota(ctx, u.Identity, quota)
+	case args[0] == "admin" && len(args) == 3:
+		admin, err := strconv.ParseBool(args[2])
+		if err != nil {
+			return fmt.Errorf("invalid value %q, expected true or false", args[2])
+		}
+		return service.Users.SetAdmin(ctx, u.Identity, admin)
+	case args[0] == "reset-2fa" && len(args) == 2:
+		return service.Users.ResetTwoFactor(ctx, u.Identity)
+	}
+	return errUsage
+}
+
+func adminUserCreate(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("user create", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	email := fs.String("email", "", "")
+	admin := fs.Bool("admin", false, "")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 1 {
+		return errUsage
+	}
+	password, err := readPassword()
+	if err != nil {
+		return err
+	}
+	u, err := service.Users.Create(ctx, &service.UserCreateRequest{
+		Name:     fs.Arg(0),
+		Password: password,
+		Email:    *email,
+		Admin:    *admin,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(u.Identity)
+	return nil
+}
+
+// readPassword reads a password from the first line of stdin, prompting for
+// it on stderr.
+func readPassword() (string, error) {
+	fmt.Fprint(os.Stderr, "Password: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", errors.New("no password given")
+	}
+	return password, nil
+}
+
+// userFS returns the files of the user with the given name.
+func userFS(ctx context.Context, name string) (*vfs.FS, error) {
+	u, err := service.Users.Find(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return vfs.New(ctx, u.Identity), nil
+}
+
+func adminTree(ctx context.Context, args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return errUsage
+	}
+	f, err := userFS(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	root := "/"
+	if len(args) == 2 {
+		root = args[1]
+	}
+	dir, err := f.Stat(root)
+	if err != nil {
+		return fmt.Errorf("%s: %w", root, err)
+	}
+
+	return f.Walk(dir, func(name string, e *vfs.Entry) error {
+		if e.IsDir() {
+			name += "/"
+		}
+		_, err := fmt.Printf("%12d  %s\n", e.Size(), name)
+		return err
+	})
+}
+
+func adminGC(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("gc", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	grace := fs.Duration("grace", blob.GCGrace, "")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 0 {
+		return errUsage
+	}
+	res, err := blob.GC(ctx, *grace)
+	if err != nil {
+		return err
+	}
+	audit.Record(ctx, &audit.Event{
+		Action: audit.BlobGC,
+		After:  map[string]interface{}{"blobs": res.Blobs, "files": res.Files, "bytes": res.Bytes, "grace": grace.String()},
+	})
+	fmt.Printf("removed %d blobs and %d stray files, %d bytes\n", res.Blobs, res.Files, res.Bytes)
+	return nil
+}
+
+func adminCheck(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	verify := fs.Bool("verify", false, "")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 0 {
+		return errUsage
+	}
+	var problems int
+	err := blob.Check(ctx, *verify, func(p *blob.Problem) {
+		problems++
+		fmt.Println(p)
+	})
+	if err != nil {
+		return err
+	}
+	if problems > 0 {
+		return fmt.Errorf("found %d problems", problems)
+	}
+	return nil
+}
+
+func adminImport(ctx context.Context, args []string) error {
+	if len(args) < 2 || len(args) > 3 {
+		return errUsage
+	}
+	f, err := userFS(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	src, dest := args[1], "/"
+	if len(args) == 3 {
+		dest = args[2]
+	}
+	if _, err := f.MkdirAll(dest); err != nil {
+		return fmt.Errorf("%s: %w", dest, err)
+	}
+
+	var files int
+	err = filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		name := path.Join(dest, filepath.ToSlash(rel))
+		switch {
+		case d.IsDir():
+			_, err = f.MkdirAll(name)
+		case d.Type().IsRegular():
+			err = importFile(f, name, p)
+			files++
+		default:
+			fmt.Fprintf(os.Stderr, "skipping %s, not a regular file\n", p)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("imported %d files\n", files)
+	return nil
+}
+
+func importFile(f *vfs.FS, name, src string) error {
+	file, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = f.Put(name, file)
+	return err
+}
+
+func adminExport(ctx context.Context, args []string) error {
+	if len(args) < 2 || len(args) > 3 {
+		return errUsage
+	}
+	f, err := userFS(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	dest, src := args[1], "/"
+	if len(args) == 3 {
+		src = args[2]
+	}
+	e, err := f.Stat(src)
+	if err != nil {
+		return fmt.Errorf("%s: %w", src, err)
+	}
+	if !e.IsDir() {
+		if err := os.MkdirAll(dest, 0o755); err != nil {
+			return err
+		}
+		return exportFile(ctx, e, filepath.Join(dest, e.Name))
+	}
+
+	if err := os.MkdirAll(dest, 0o755); err != nil {
+		return err
+	}
+	var files int
+	err = f.Walk(e, func(name string, e *vfs.Entry) error {
+		p := filepath.Join(dest, filepath.FromSlash(name))
+		if e.IsDir() {
+			return os.MkdirAll(p, 0o755)
+		}
+		files++
+		if err := exportFile(ctx, e, p); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("exported %d files\n", files)
+	return nil
+}
+
+func exportFile(ctx context.Context, e *vfs.Entry, p string) error {
+	if e.Pool == nil {
+		return errors.New("content is not in the pool")
+	}
+	src, err := blob.Open(ctx, e.Pool)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.Create(p)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+func adminAudit(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("audit", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	user := fs.String("user", "", "")
+	action := fs.String("action", "", "")
+	since := fs.String("since", "", "")
+	until := fs.String("until", "", "")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 0 {
+		return errUsage
+	}
+	f := &audit.Filter{Action: *action}
+	if *user != "" {
+		u, err := service.Users.Find(ctx, *user)
+		if err != nil {
+			return err
+		}
+		f.User = u.Identity
+	}
+	var err error
+	if *since != "" {
+		if f.Since, err = time.Parse(time.RFC3339, *since); err != nil {
+			return fmt.Errorf("invalid -since: %w", err)
+		}
+	}
+	if *until != "" {
+		if f.Until, err = time.Parse(time.RFC3339, *until); err != nil {
+			return fmt.Errorf("invalid -until: %w", err)
+		}
+	}
+
+	audit.Record(ctx, &audit.Event{
+		Action: audit.AuditExport,
+		After:  map[string]string{"user": f.User, "action": *action, "since": *since, "until": *until},
+	})
+	w := bufio.NewWriter(os.Stdout)
+	if _, err := audit.Export(ctx, f, w); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -0,0 +1,131 @@
+// Package audit keeps the trail of security-relevant and destructive
+// actions: logins, credential and account changes, deletes and moves of
+// files, shares and the actions of administrators.
+//
+// Every action is a row of the audit_log table recording who did what to
+// which target, the values changed and the client it came from. The trail
+// is append-only: nothing in the server updates or deletes its rows, which
+// GormPlugin enforces for every query made through gorm.
+package audit
+
+import (
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/dal/query"
+	"cloud-storage/biz/logging"
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// The actions recorded.
+const (
+	UserLogin                = "user.login"
+	UserLoginFailed          = "user.login_failed"
+	UserTokenRefresh         = "user.token_refresh"
+	UserCreate               = "user.create"
+	UserPassword             = "user.password"
+	UserPasswordResetRequest = "user.password_reset_request"
+	UserPasswordReset        = "user.password_reset"
+	UserAdmin                = "user.admin"
+	UserDisabled             = "user.disabled"
+	UserLocked               = "user.locked"
+	UserQuota                = "user.quota"
+	UserTwoFactorEnable      = "user.2fa_enable"
+	UserTwoFactorDisable     = "user.2fa_disable"
+	UserRecoveryCodes        = "user.recovery_codes"
+	AccessKeyCreate          = "access_key.create"
+	AccessKeyDelete          = "access_key.delete"
+	APITokenCreate           = "api_token.create"
+	APITokenRevoke           = "api_token.revoke"
+	SessionRevoke            = "session.revoke"
+	SessionRevokeOthers      = "session.revoke_others"
+	SSHKeyCreate             = "ssh_key.create"
+	SSHKeyDelete             = "ssh_key.delete"
+	FileDelete               = "file.delete"
+	FileMove                 = "file.move"
+	FileRename               = "file.rename"
+	ShareCreate              = "share.create"
+	ShareRevoke              = "share.revoke"
+	JobRetry                 = "job.retry"
+	BlobGC                   = "blob.gc"
+	AuditExport              = "audit.export"
+)
+
+var log = logging.Logger("audit")
+
+// Event is an action to record.
+type Event struct {
+	Action string
+	// Actor is the identity of the user acting, empty for anonymous
+	// requests and the admin command
+	Actor string
+	// Target is the identity of the user, file, share or other object acted
+	// on, if any
+	Target string
+	// Before and After are the values changed by the action, stored as
+	// JSON; nil for none
+	Before interface{}
+	After  interface{}
+}
+
+// Record appends e to the trail, with the client of ctx, see WithClient.
+// It is called once the action is carried out, so a failure to record it
+// is logged rather than returned.
+func Record(ctx context.Context, e *Event) {
+	ip, userAgent := Client(ctx)
+	row := &entity.AuditLog{
+		Action:    e.Action,
+		Actor:     e.Actor,
+		Target:    e.Target,
+		OldValue:  marshal(e.Before),
+		NewValue:  marshal(e.After),
+		IP:        ip,
+		UserAgent: truncate(userAgent, 255),
+		CreatedAt: time.Now(),
+	}
+	if err := query.AuditLog.WithContext(ctx).Create(row); err != nil {
+		log.ErrorContext(ctx, "failed to record action", "action", e.Action, "actor", e.Actor, "target", e.Target, "error", err)
+	}
+}
+
+func marshal(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+type clientKey struct{}
+
+// clientInfo is the client a request comes from.
+type clientInfo struct {
+	ip        string
+	userAgent string
+}
+
+// WithClient returns a copy of ctx whose actions are recorded as made from
+// ip with userAgent, the name and version of the client program.
+func WithClient(ctx context.Context, ip, userAgent string) context.Context {
+	return context.WithValue(ctx, clientKey{}, &clientInfo{ip: ip, userAgent: userAgent})
+}
+
+// Client returns the address and user agent of the client of ctx, empty if
+// it has none.
+func Client(ctx context.Context) (ip, userAgent string) {
+	c, ok := ctx.Value(clientKey{}).(*clientInfo)
+	if !ok {
+		return "", ""
+	}
+	return c.ip, c.userAgent
+}
@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"cloud-storage/biz/dal/entity"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrAppendOnly is the error of queries updating or deleting records of
+// the trail.
+var ErrAppendOnly = errors.New("the audit log is append-only")
+
+// GormPlugin returns the gorm plugin failing every update and delete of
+// the audit_log table made through a database.
+func GormPlugin() gorm.Plugin {
+	return gormPlugin{}
+}
+
+type gormPlugin struct{}
+
+func (gormPlugin) Name() string {
+	return "cloud-storage:audit"
+}
+
+func (gormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Update().Before("gorm:update").Register("cloud-storage:audit_append_only", appendOnly),
+		cb.Delete().Before("gorm:delete").Register("cloud-storage:audit_append_only", appendOnly),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func appendOnly(db *gorm.DB) {
+	if db.Statement.Table == entity.TableNameAuditLog {
+		_ = db.AddError(ErrAppendOnly)
+	}
+}
@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/dal/query"
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+// exportBatchSize is how many records Export reads at once.
+const exportBatchSize = 500
+
+// Filter narrows the records listed or exported. Zero fields do not filter.
+type Filter struct {
+	// User matches the records the user is the actor or the target of
+	User   string
+	Action string
+	// Since and Until bound the time of the records, Until excluded
+	Since time.Time
+	Until time.Time
+}
+
+func (f *Filter) conds() []gen.Condition {
+	alQ := query.AuditLog
+	var conds []gen.Condition
+	if f.User != "" {
+		conds = append(conds, field.Or(alQ.Actor.Eq(f.User), alQ.Target.Eq(f.User)))
+	}
+	if f.Action != "" {
+		conds = append(conds, alQ.Action.Eq(f.Action))
+	}
+	if !f.Since.IsZero() {
+		conds = append(conds, alQ.CreatedAt.Gte(f.Since))
+	}
+	if !f.Until.IsZero() {
+		conds = append(conds, alQ.CreatedAt.Lt(f.Until))
+	}
+	return conds
+}
+
+// Entry is a record of the trail as listed and exported.
+type Entry struct {
+	ID        uint64          `json:"id"`
+	Time      time.Time       `json:"time"`
+	Action    string          `json:"action"`
+	Actor     string          `json:"actor,omitempty"`
+	Target    string          `json:"target,omitempty"`
+	Before    json.RawMessage `json:"before,omitempty"`
+	After     json.RawMessage `json:"after,omitempty"`
+	IP        string          `json:"ip,omitempty"`
+	UserAgent string          `json:"user_agent,omitempty"`
+}
+
+func newEntry(al *entity.AuditLog) *Entry {
+	e := &Entry{
+		ID:        al.ID,
+		Time:      al.CreatedAt,
+		Action:    al.Action,
+		Actor:     al.Actor,
+		Target:    al.Target,
+		IP:        al.IP,
+		UserAgent: al.UserAgent,
+	}
+	if json.Valid([]byte(al.OldValue)) {
+		e.Before = json.RawMessage(al.OldValue)
+	}
+	if json.Valid([]byte(al.NewValue)) {
+		e.After = json.RawMessage(al.NewValue)
+	}
+	return e
+}
+
+// List returns a page of the records matching f, newest first, and the
+// total number of matches.
+func List(ctx context.Context, f *Filter, offset, limit int) ([]*Entry, int64, error) {
+	alQ := query.AuditLog
+	rows, count, err := alQ.WithContext(ctx).Where(f.conds()...).Order(alQ.ID.Desc()).FindByPage(offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	entries := make([]*Entry, len(rows))
+	for i, al := range rows {
+		entries[i] = newEntry(al)
+	}
+	return entries, count, nil
+}
+
+// Export writes the records matching f to w as JSON lines, oldest first,
+// and returns how many it wrote.
+func Export(ctx context.Context, f *Filter, w io.Writer) (int, error) {
+	alQ := query.AuditLog
+	enc := json.NewEncoder(w)
+	conds := f.conds()
+	var n int
+	var afterID uint64
+	for {
+		batch, err := alQ.WithContext(ctx).Where(conds...).Where(alQ.ID.Gt(afterID)).
+			Order(alQ.ID).Limit(exportBatchSize).Find()
+		if err != nil {
+			return n, err
+		}
+		for _, al := range batch {
+			if err := enc.Encode(newEntry(al)); err != nil {
+				return n, err
+			}
+			afterID = al.ID
+			n++
+		}
+		if len(batch) < exportBatchSize {
+			return n, nil
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package blob
+
+import (
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/dal/query"
+	"cloud-storage/biz/fulltext"
+	"cloud-storage/biz/jobs"
+	"cloud-storage/biz/logging"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/duke-git/lancet/v2/random"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// Dir is where the content of the repository pool is kept, one file per
+// hash. It is set from the configuration at startup.
+var Dir string
+
+var (
+	log    = logging.Logger("blob")
+	tracer = otel.Tracer("cloud-storage/biz/blob")
+)
+
+// Put stores the content read from r in the repository pool. If a blob with
+// the same hash already exists it is reused and the new content is discarded.
+func Put(ctx context.Context, name string, r io.Reader) (rp *entity.RepositoryPool, err error) {
+	ctx, span := tracer.Start(ctx, "blob.put", trace.WithAttributes(attribute.String("blob.name", name)))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err := os.MkdirAll(Dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	tmp, err := os.CreateTemp(Dir, ".upload-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+
+	h := md5.New()
+	size, err := io.Copy(io.MultiWriter(tmp, h), r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	hash := hex.EncodeToString(h.Sum(nil))
+	uploadedBytes.Add(float64(size))
+	span.SetAttributes(attribute.String("blob.hash", hash), attribute.Int64("blob.size", size))
+
+	// Look on the primary, as the record of content stored a moment ago may
+	// not have reached the replicas yet
+	rp, err = find(ctx, hash)
+	if err == nil {
+		DedupLookups.WithLabelValues("hit").Inc()
+		span.SetAttributes(attribute.Bool("blob.dedup", true))
+		return rp, Touch(ctx, rp.Identity)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	DedupLookups.WithLabelValues("miss").Inc()
+	span.SetAttributes(attribute.Bool("blob.dedup", false))
+
+	uuid, err := random.UUIdV4()
+	if err != nil {
+		return nil, err
+	}
+	name = filepath.Base(name)
+	joinedPath := filepath.Join(Dir, hash)
+	rp = &entity.RepositoryPool{
+		Identity: uuid,
+		Hash:     hash,
+		Name:     name,
+		Ext:      filepath.Ext(name),
+		Size:     size,
+		Path:     joinedPath,
+	}
+	// The record only commits once the content is in place, so a failed
+	// rename leaves no record without content
+	err = query.Q.Transaction(func(tx *query.Query) error {
+		if err := tx.RepositoryPool.WithContext(ctx).Create(rp); err != nil {
+			return err
+		}
+		if err := fulltext.Enqueue(ctx, rp, jobs.In(tx)); err != nil {
+			return err
+		}
+		return os.Rename(tmp.Name(), joinedPath)
+	})
+	if err != nil {
+		// A concurrent upload of the same content may have won the race for
+		// the unique hash, in which case its record and content are kept
+		if existing, findErr := find(ctx, hash); findErr == nil {
+			return existing, nil
+		}
+		os.Remove(joinedPath)
+		return nil, err
+	}
+	return rp, nil
+}
+
+// find returns the record of the content with the given hash.
+func find(ctx context.Context, hash string) (*entity.RepositoryPool, error) {
+	rpQ := query.RepositoryPool
+	return rpQ.WithContext(ctx).WriteDB().Where(rpQ.Hash.Eq(hash)).First()
+}
+
+// File is the content of a blob opened for reading. What is read from it
+// counts as downloaded, and its span lasts until it is closed.
+type File struct {
+	*os.File
+	span trace.Span
+	read atomic.Int64
+}
+
+// Open opens the content of a blob for reading.
+func Open(ctx context.Context, rp *entity.RepositoryPool) (*File, error) {
+	_, span := tracer.Start(ctx, "blob.read", trace.WithAttributes(
+		attribute.String("blob.hash", rp.Hash),
+		attribute.Int64("blob.size", rp.Size),
+	))
+	f, err := os.Open(rp.Path)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.End()
+		return nil, err
+	}
+	return &File{File: f, span: span}, nil
+}
+
+func (f *File) Read(p []byte) (int, error) {
+	n, err := f.File.Read(p)
+	f.count(n)
+	return n, err
+}
+
+func (f *File) ReadAt(p []byte, off int64) (int, error) {
+	n, err := f.File.ReadAt(p, off)
+	f.count(n)
+	return n, err
+}
+
+// WriteTo keeps io.Copy from going around Read, which it would through the
+// WriteTo of the embedded file.
+func (f *File) WriteTo(w io.Writer) (int64, error) {
+	n, err := f.File.WriteTo(w)
+	f.count(int(n))
+	return n, err
+}
+
+func (f *File) count(n int) {
+	f.read.Add(int64(n))
+	downloadedBytes.Add(float64(n))
+}
+
+func (f *File) Close() error {
+	f.span.SetAttributes(attribute.Int64("blob.read_bytes", f.read.Load()))
+	f.span.End()
+	return f.File.Close()
+}
@@ -0,0 +1,137 @@
+package blob
+
+import (
+	"cloud-storage/biz/dal/query"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// Problem is an inconsistency between the database and the content in Dir.
+type Problem struct {
+	// Table and Identity name the record the problem was found with
+	Table    string
+	Identity string
+	// Reason describes what is wrong
+	Reason string
+}
+
+func (p *Problem) String() string {
+	return fmt.Sprintf("%s %s: %s", p.Table, p.Identity, p.Reason)
+}
+
+// Check looks for pool records whose content is missing or has the wrong
+// size, and for files and shares referring to content not in the pool, and
+// calls report for every problem found. With verify, the content of every
+// blob is also read back and compared with its hash, which takes as long as
+// reading all of Dir.
+func Check(ctx context.Context, verify bool, report func(*Problem)) error {
+	if err := checkRecords(ctx, verify, report); err != nil {
+		return err
+	}
+	return checkReferences(ctx, report)
+}
+
+func checkRecords(ctx context.Context, verify bool, report func(*Problem)) error {
+	rpQ := query.RepositoryPool
+	var afterID uint32
+	for {
+		batch, err := rpQ.WithContext(ctx).WriteDB().Where(rpQ.ID.Gt(afterID)).Order(rpQ.ID).Limit(gcBatchSize).Find()
+		if err != nil {
+			return err
+		}
+		for _, rp := range batch {
+			afterID = rp.ID
+			info, err := os.Stat(rp.Path)
+			switch {
+			case errors.Is(err, fs.ErrNotExist):
+				report(&Problem{Table: "repository_pool", Identity: rp.Identity, Reason: "content is missing from " + rp.Path})
+				continue
+			case err != nil:
+				report(&Problem{Table: "repository_pool", Identity: rp.Identity, Reason: err.Error()})
+				continue
+			case info.Size() != rp.Size:
+				report(&Problem{Table: "repository_pool", Identity: rp.Identity,
+					Reason: fmt.Sprintf("content is %d bytes, expected %d", info.Size(), rp.Size)})
+				continue
+			}
+			if !verify {
+				continue
+			}
+			hash, err := hashFile(rp.Path)
+			if err != nil {
+				report(&Problem{Table: "repository_pool", Identity: rp.Identity, Reason: err.Error()})
+			} else if hash != rp.Hash {
+				report(&Problem{Table: "repository_pool", Identity: rp.Identity,
+					Reason: fmt.Sprintf("content hashes to %s, expected %s", hash, rp.Hash)})
+			}
+		}
+		if len(batch) < gcBatchSize {
+			return nil
+		}
+	}
+}
+
+// checkReferences reports the files and shares whose content has no pool
+// record.
+func checkReferences(ctx context.Context, report func(*Problem)) error {
+	rpQ, urQ, sbQ := query.RepositoryPool, query.UserRepository, query.ShareBasic
+	pooled := rpQ.WithContext(ctx).Select(rpQ.Identity)
+
+	urs, err := urQ.WithContext(ctx).WriteDB().
+		Where(urQ.RepositoryIdentity.Neq(""), urQ.WithContext(ctx).Columns(urQ.RepositoryIdentity).NotIn(pooled)).
+		Find()
+	if err != nil {
+		return err
+	}
+	for _, ur := range urs {
+		report(&Problem{Table: "user_repository", Identity: ur.Identity, Reason: "content " + ur.RepositoryIdentity + " is not in the pool"})
+	}
+
+	sbs, err := sbQ.WithContext(ctx).WriteDB().
+		Where(sbQ.RepositoryIdentity.Neq(""), sbQ.WithContext(ctx).Columns(sbQ.RepositoryIdentity).NotIn(pooled)).
+		Find()
+	if err != nil {
+		return err
+	}
+	for _, sb := range sbs {
+		report(&Problem{Table: "share_basic", Identity: sb.Identity, Reason: "content " + sb.RepositoryIdentity + " is not in the pool"})
+	}
+	return nil
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := md5.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Writable reports whether content can be stored in Dir, by writing and
+// removing a temporary file the way Put does.
+func Writable() error {
+	if err := os.MkdirAll(Dir, os.ModePerm); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(Dir, ".probe-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write([]byte("ok"))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
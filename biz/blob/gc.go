@@ -0,0 +1,205 @@
+package blob
+
+import (
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/dal/query"
+	"cloud-storage/biz/jobs"
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gorm.io/gen"
+)
+
+// GCGrace is how long content stays in the pool after it was last stored
+// or reused, whether or not anything refers to it. Uploads hand out the
+// identity of a blob before the file referring to it is saved, which has
+// to happen within this time.
+const GCGrace = 24 * time.Hour
+
+// gcBatchSize is how many records or files GC checks per query.
+const gcBatchSize = 500
+
+// GCResult counts what GC removed.
+type GCResult struct {
+	// Blobs is the number of pool records removed with their content
+	Blobs int
+	// Files is the number of files removed from Dir that had no record
+	Files int
+	// Bytes is the size of the removed content
+	Bytes int64
+}
+
+var gcJob = jobs.Define("blob.gc", jobs.Options{Timeout: time.Hour}, func(ctx context.Context, _ struct{}) error {
+	res, err := GC(ctx, GCGrace)
+	if err != nil {
+		return err
+	}
+	log.InfoContext(ctx, "GC done", "blobs", res.Blobs, "files", res.Files, "bytes", res.Bytes)
+	return nil
+})
+
+func init() {
+	jobs.Schedule("blob.gc", "@daily", gcJob, struct{}{})
+}
+
+// Touch marks the blob as used now, keeping GC off it for GCGrace.
+func Touch(ctx context.Context, identity string) error {
+	rpQ := query.RepositoryPool
+	_, err := rpQ.WithContext(ctx).Where(rpQ.Identity.Eq(identity)).Update(rpQ.UpdatedAt, time.Now())
+	return err
+}
+
+// GC removes the content of the pool that no file or share refers to and
+// that was not used for grace, then the files of Dir that belong to no
+// record, such as uploads cut short by a crash.
+func GC(ctx context.Context, grace time.Duration) (*GCResult, error) {
+	res := &GCResult{}
+	cutoff := time.Now().Add(-grace)
+	if err := gcRecords(ctx, cutoff, res); err != nil {
+		return res, err
+	}
+	if err := gcFiles(ctx, cutoff, res); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// unreferenced returns the conditions matching the pool records that are
+// unused since cutoff and that no file or share refers to.
+func unreferenced(ctx context.Context, q *query.Query, cutoff time.Time) []gen.Condition {
+	rpQ, urQ, sbQ := q.RepositoryPool, q.UserRepository, q.ShareBasic
+	return []gen.Condition{
+		rpQ.UpdatedAt.Lt(cutoff),
+		rpQ.WithContext(ctx).Columns(rpQ.Identity).NotIn(
+			urQ.WithContext(ctx).Select(urQ.RepositoryIdentity).Where(urQ.RepositoryIdentity.IsNotNull()),
+		),
+		rpQ.WithContext(ctx).Columns(rpQ.Identity).NotIn(
+			sbQ.WithContext(ctx).Select(sbQ.RepositoryIdentity).Where(sbQ.RepositoryIdentity.IsNotNull()),
+		),
+	}
+}
+
+func gcRecords(ctx context.Context, cutoff time.Time, res *GCResult) error {
+	rpQ := query.RepositoryPool
+	var afterID uint32
+	for {
+		batch, err := rpQ.WithContext(ctx).WriteDB().Unscoped().
+			Where(unreferenced(ctx, query.Q, cutoff)...).
+			Where(rpQ.ID.Gt(afterID)).
+			Order(rpQ.ID).
+			Limit(gcBatchSize).
+			Find()
+		if err != nil {
+			return err
+		}
+		for _, rp := range batch {
+			afterID = rp.ID
+			ok, err := removeRecord(ctx, rp, cutoff)
+			if err != nil {
+				return err
+			}
+			if ok {
+				res.Blobs++
+				res.Bytes += rp.Size
+			}
+		}
+		if len(batch) < gcBatchSize {
+			return nil
+		}
+	}
+}
+
+// removeRecord deletes the record of rp and then its content, unless a
+// file or share came to refer to it in the meantime.
+func removeRecord(ctx context.Context, rp *entity.RepositoryPool, cutoff time.Time) (bool, error) {
+	var removed bool
+	// The record goes first: content without a record is removed by the
+	// next run, while a record without content would break downloads
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		rpQ := tx.RepositoryPool
+		info, err := rpQ.WithContext(ctx).Unscoped().
+			Where(rpQ.ID.Eq(rp.ID)).
+			Where(unreferenced(ctx, tx, cutoff)...).
+			Delete()
+		if err != nil {
+			return err
+		}
+		removed = info.RowsAffected == 1
+		return nil
+	})
+	if err != nil || !removed {
+		return false, err
+	}
+	if err := os.Remove(rp.Path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.WarnContext(ctx, "failed to remove blob", "path", rp.Path, "error", err)
+	}
+	return true, nil
+}
+
+func gcFiles(ctx context.Context, cutoff time.Time, res *GCResult) error {
+	entries, err := os.ReadDir(Dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var stale []fs.FileInfo
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		// Content being stored right now is written to a temporary file
+		// and renamed before its record commits
+		if info.ModTime().Before(cutoff) {
+			stale = append(stale, info)
+		}
+	}
+
+	rpQ := query.RepositoryPool
+	for len(stale) > 0 {
+		n := min(len(stale), gcBatchSize)
+		batch := stale[:n]
+		stale = stale[n:]
+
+		hashes := make([]string, 0, len(batch))
+		for _, info := range batch {
+			if !strings.HasPrefix(info.Name(), ".") {
+				hashes = append(hashes, info.Name())
+			}
+		}
+		var known []string
+		if len(hashes) > 0 {
+			err := rpQ.WithContext(ctx).WriteDB().Unscoped().Where(rpQ.Hash.In(hashes...)).Pluck(rpQ.Hash, &known)
+			if err != nil {
+				return err
+			}
+		}
+		exists := make(map[string]bool, len(known))
+		for _, hash := range known {
+			exists[hash] = true
+		}
+		for _, info := range batch {
+			if exists[info.Name()] {
+				continue
+			}
+			if err := os.Remove(filepath.Join(Dir, info.Name())); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				log.WarnContext(ctx, "failed to remove stray file", "name", info.Name(), "error", err)
+				continue
+			}
+			res.Files++
+			res.Bytes += info.Size()
+		}
+	}
+	return nil
+}
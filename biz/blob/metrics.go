@@ -0,0 +1,74 @@
+package blob
+
+import (
+	"cloud-storage/biz/dal/query"
+	"cloud-storage/biz/metrics"
+	"context"
+	"database/sql"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	uploadedBytes = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "blob",
+		Name:      "uploaded_bytes_total",
+		Help:      "Bytes of content received, including content already in the pool.",
+	})
+	downloadedBytes = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "blob",
+		Name:      "downloaded_bytes_total",
+		Help:      "Bytes of content read from the pool by clients.",
+	})
+	// DedupLookups counts the lookups of content by hash, which find it in
+	// the pool or not; the rate of hits is how much storage dedup saves.
+	DedupLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "blob",
+		Name:      "dedup_lookups_total",
+		Help:      "Lookups of content by hash in the pool by result, hit or miss.",
+	}, []string{"result"})
+)
+
+// storedBytesTTL is how long the size of the pool is cached between scrapes.
+const storedBytesTTL = time.Minute
+
+func init() {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "blob",
+		Name:      "stored_bytes",
+		Help:      "Size of the content in the pool.",
+	}, cachedStoredBytes())
+}
+
+func cachedStoredBytes() func() float64 {
+	var (
+		mu        sync.Mutex
+		value     = math.NaN()
+		queriedAt time.Time
+	)
+	return func() float64 {
+		mu.Lock()
+		defer mu.Unlock()
+		if time.Since(queriedAt) < storedBytesTTL {
+			return value
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		var sum struct{ Total sql.NullInt64 }
+		rpQ := query.RepositoryPool
+		if err := rpQ.WithContext(ctx).Select(rpQ.Size.Sum().As("total")).Scan(&sum); err != nil {
+			log.WarnContext(ctx, "failed to sum the size of the pool", "error", err)
+			return math.NaN()
+		}
+		value, queriedAt = float64(sum.Total.Int64), time.Now()
+		return value
+	}
+}
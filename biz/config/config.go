@@ -0,0 +1,629 @@
+// Package config loads the configuration of the server from a YAML or TOML
+// file, environment variables and secret files.
+//
+// Every setting can be overridden by the environment variable named in its
+// env tag, prefixed with CLOUD_STORAGE_, e.g. CLOUD_STORAGE_DATABASE_DSN.
+// Secrets can also be read from files, e.g. with database.dsn_file or
+// CLOUD_STORAGE_DATABASE_DSN_FILE, which is how container orchestrators
+// usually provide them.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix prefixes the names of the environment variables.
+const EnvPrefix = "CLOUD_STORAGE_"
+
+// PathEnv names the configuration file when no path is given to Load.
+const PathEnv = EnvPrefix + "CONFIG"
+
+type Config struct {
+	Server        Server        `yaml:"server" toml:"server"`
+	Database      Database      `yaml:"database" toml:"database"`
+	Storage       Storage       `yaml:"storage" toml:"storage"`
+	JWT           JWT           `yaml:"jwt" toml:"jwt"`
+	Mailer        Mailer        `yaml:"mailer" toml:"mailer"`
+	Quota         Quota         `yaml:"quota" toml:"quota"`
+	Limits        Limits        `yaml:"limits" toml:"limits"`
+	RateLimit     RateLimit     `yaml:"rate_limit" toml:"rate_limit"`
+	Lockout       Lockout       `yaml:"lockout" toml:"lockout"`
+	TwoFactor     TwoFactor     `yaml:"two_factor" toml:"two_factor"`
+	PasswordReset PasswordReset `yaml:"password_reset" toml:"password_reset"`
+	Jobs          Jobs          `yaml:"jobs" toml:"jobs"`
+	Tracing       Tracing       `yaml:"tracing" toml:"tracing"`
+	Log           Log           `yaml:"log" toml:"log"`
+}
+
+type Server struct {
+	// Addr is the listen address of the HTTP server
+	Addr string `yaml:"addr" toml:"addr" env:"SERVER_ADDR"`
+	// GRPCAddr is the listen address of the gRPC server, disabled if empty
+	GRPCAddr string `yaml:"grpc_addr" toml:"grpc_addr" env:"SERVER_GRPC_ADDR"`
+	// SFTPAddr is the listen address of the SFTP server, disabled if empty
+	SFTPAddr string `yaml:"sftp_addr" toml:"sftp_addr" env:"SERVER_SFTP_ADDR"`
+	// MetricsAddr is the listen address of the Prometheus metrics endpoint,
+	// kept apart from Addr so that it need not be public; disabled if empty
+	MetricsAddr string `yaml:"metrics_addr" toml:"metrics_addr" env:"SERVER_METRICS_ADDR"`
+	// ShutdownDelay is how long the server keeps serving on SIGTERM while
+	// /readyz fails, so that the orchestrator stops sending it requests
+	// before it stops listening
+	ShutdownDelay time.Duration `yaml:"shutdown_delay" toml:"shutdown_delay" env:"SERVER_SHUTDOWN_DELAY"`
+	// ShutdownTimeout is how long the server then waits for the requests,
+	// uploads and SFTP sessions in progress before it exits; keep both
+	// below the grace period of the orchestrator
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT"`
+	// TrustedProxies are the addresses or CIDR ranges of the reverse
+	// proxies whose X-Forwarded-For and X-Real-IP headers are believed.
+	// Clients are known by their socket address if empty. The environment
+	// variable separates them with commas.
+	TrustedProxies []string `yaml:"trusted_proxies" toml:"trusted_proxies" env:"SERVER_TRUSTED_PROXIES"`
+}
+
+// TrustedProxyNets parses TrustedProxies, bare addresses standing for
+// themselves alone.
+func (s *Server) TrustedProxyNets() ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(s.TrustedProxies))
+	for _, proxy := range s.TrustedProxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid address %q", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+type Database struct {
+	// Driver is one of mysql, sqlite and postgres
+	Driver  string `yaml:"driver" toml:"driver" env:"DATABASE_DRIVER"`
+	DSN     string `yaml:"dsn" toml:"dsn" env:"DATABASE_DSN"`
+	DSNFile string `yaml:"dsn_file" toml:"dsn_file" env:"DATABASE_DSN_FILE"`
+	// Replicas are the DSNs of read replicas of the database, which serve
+	// the queries outside transactions. The environment variable separates
+	// them with commas.
+	Replicas []string `yaml:"replicas" toml:"replicas" env:"DATABASE_REPLICAS"`
+	// StickyWindow is how long after a write the reads of the writing user
+	// still go to the primary, so that they see their own changes; only
+	// the writes made through the same instance count
+	StickyWindow time.Duration `yaml:"sticky_window" toml:"sticky_window" env:"DATABASE_STICKY_WINDOW"`
+	// AutoMigrate applies pending schema migrations at startup. Without it
+	// the server refuses to start until they are applied with migrate up.
+	AutoMigrate bool `yaml:"auto_migrate" toml:"auto_migrate" env:"DATABASE_AUTO_MIGRATE"`
+	// SlowQuery is how long a query runs before it is logged as slow,
+	// never if 0
+	SlowQuery time.Duration `yaml:"slow_query" toml:"slow_query" env:"DATABASE_SLOW_QUERY"`
+}
+
+type Storage struct {
+	// Backend is where file content is kept; only "local" is supported
+	Backend string `yaml:"backend" toml:"backend" env:"STORAGE_BACKEND"`
+	// Dir holds the content, the search index, uploads in progress and the
+	// SSH host key of the local backend
+	Dir string `yaml:"dir" toml:"dir" env:"STORAGE_DIR"`
+}
+
+type JWT struct {
+	Key     string `yaml:"key" toml:"key" env:"JWT_KEY"`
+	KeyFile string `yaml:"key_file" toml:"key_file" env:"JWT_KEY_FILE"`
+	// TTL is how long a token is valid
+	TTL time.Duration `yaml:"ttl" toml:"ttl" env:"JWT_TTL"`
+	// MaxRefresh is how long after its issue a token can be refreshed
+	MaxRefresh time.Duration `yaml:"max_refresh" toml:"max_refresh" env:"JWT_MAX_REFRESH"`
+}
+
+// Mailer is the SMTP server sending mail to users. Mail is disabled if Host
+// is empty.
+type Mailer struct {
+	Host         string `yaml:"host" toml:"host" env:"MAILER_HOST"`
+	Port         int    `yaml:"port" toml:"port" env:"MAILER_PORT"`
+	Username     string `yaml:"username" toml:"username" env:"MAILER_USERNAME"`
+	Password     string `yaml:"password" toml:"password" env:"MAILER_PASSWORD"`
+	PasswordFile string `yaml:"password_file" toml:"password_file" env:"MAILER_PASSWORD_FILE"`
+	From         string `yaml:"from" toml:"from" env:"MAILER_FROM"`
+}
+
+type Quota struct {
+	// UserBytes is the total size of the files of a user, unlimited if 0
+	UserBytes int64 `yaml:"user_bytes" toml:"user_bytes" env:"QUOTA_USER_BYTES"`
+}
+
+type Limits struct {
+	// MaxRequestBodySize is the largest request body the HTTP server reads
+	// into memory
+	MaxRequestBodySize int `yaml:"max_request_body_size" toml:"max_request_body_size" env:"LIMITS_MAX_REQUEST_BODY_SIZE"`
+}
+
+// RateLimit throttles the endpoints open to anonymous clients. Each limit
+// is a rate such as 20/1m, 20 requests a minute with bursts of up to 20,
+// kept per client IP address or per user, email address or share; a limit
+// is disabled if empty.
+type RateLimit struct {
+	// LoginIP limits the logins from an IP address; over WebDAV and SFTP,
+	// only the failed ones count
+	LoginIP string `yaml:"login_ip" toml:"login_ip" env:"RATE_LIMIT_LOGIN_IP"`
+	// LoginUser limits the logins to an account, from any address; like
+	// LoginIP, only failed ones over WebDAV and SFTP
+	LoginUser string `yaml:"login_user" toml:"login_user" env:"RATE_LIMIT_LOGIN_USER"`
+	// MailCodeIP limits the registration codes requested from an IP address
+	MailCodeIP string `yaml:"mail_code_ip" toml:"mail_code_ip" env:"RATE_LIMIT_MAIL_CODE_IP"`
+	// MailCodeEmail limits the registration codes sent to an email address
+	MailCodeEmail string `yaml:"mail_code_email" toml:"mail_code_email" env:"RATE_LIMIT_MAIL_CODE_EMAIL"`
+	// PasswordResetIP limits the password resets requested and completed
+	// from an IP address
+	PasswordResetIP string `yaml:"password_reset_ip" toml:"password_reset_ip" env:"RATE_LIMIT_PASSWORD_RESET_IP"`
+	// PasswordResetEmail limits the password reset links sent to an email
+	// address
+	PasswordResetEmail string `yaml:"password_reset_email" toml:"password_reset_email" env:"RATE_LIMIT_PASSWORD_RESET_EMAIL"`
+	// ShareIP limits the shares looked up from an IP address
+	ShareIP string `yaml:"share_ip" toml:"share_ip" env:"RATE_LIMIT_SHARE_IP"`
+	// Share limits the lookups of a share, from any address
+	Share string `yaml:"share" toml:"share" env:"RATE_LIMIT_SHARE"`
+}
+
+// Lockout refuses password logins to an account for a while after too many
+// failed in a row. It is disabled if Threshold is 0.
+type Lockout struct {
+	// Threshold is the number of failed logins in a row locking an account
+	Threshold int `yaml:"threshold" toml:"threshold" env:"LOCKOUT_THRESHOLD"`
+	// Duration is how long an account stays locked
+	Duration time.Duration `yaml:"duration" toml:"duration" env:"LOCKOUT_DURATION"`
+}
+
+// TwoFactor is the TOTP second factor users may turn on for their logins.
+type TwoFactor struct {
+	// Issuer names the server in authenticator apps
+	Issuer string `yaml:"issuer" toml:"issuer" env:"TWO_FACTOR_ISSUER"`
+	// ChallengeTTL is how long after the password the code can be given
+	ChallengeTTL time.Duration `yaml:"challenge_ttl" toml:"challenge_ttl" env:"TWO_FACTOR_CHALLENGE_TTL"`
+	// RequireAdmin refuses the administration endpoints to administrators
+	// who did not log in with a second factor
+	RequireAdmin bool `yaml:"require_admin" toml:"require_admin" env:"TWO_FACTOR_REQUIRE_ADMIN"`
+}
+
+// PasswordReset is the recovery of accounts by a link mailed to their email
+// address, which sets a new password.
+type PasswordReset struct {
+	// URL is the page of the web client setting the new password, which
+	// gets the token in its token query parameter; the mail carries the bare
+	// token if empty
+	URL string `yaml:"url" toml:"url" env:"PASSWORD_RESET_URL"`
+	// TTL is how long a link works
+	TTL time.Duration `yaml:"ttl" toml:"ttl" env:"PASSWORD_RESET_TTL"`
+}
+
+// Jobs is the background job queue, which every server process works on.
+type Jobs struct {
+	// PollInterval is how often idle workers look for due jobs
+	PollInterval time.Duration `yaml:"poll_interval" toml:"poll_interval" env:"JOBS_POLL_INTERVAL"`
+	// Lease is how long a running job stays claimed by a process that
+	// stopped renewing it, e.g. because it crashed
+	Lease time.Duration `yaml:"lease" toml:"lease" env:"JOBS_LEASE"`
+	// Retention is how long finished jobs are kept, forever if 0
+	Retention time.Duration `yaml:"retention" toml:"retention" env:"JOBS_RETENTION"`
+}
+
+// Tracing exports OpenTelemetry traces. It is disabled if Exporter is empty.
+type Tracing struct {
+	// Exporter is otlp, sending spans over gRPC to Endpoint, or stdout,
+	// writing them as JSON to File for debugging without a collector
+	Exporter string `yaml:"exporter" toml:"exporter" env:"TRACING_EXPORTER"`
+	// Endpoint is the host:port of the OTLP collector; if empty, the
+	// exporter reads OTEL_EXPORTER_OTLP_ENDPOINT or uses localhost:4317
+	Endpoint string `yaml:"endpoint" toml:"endpoint" env:"TRACING_ENDPOINT"`
+	// Insecure sends spans to the collector without TLS
+	Insecure bool `yaml:"insecure" toml:"insecure" env:"TRACING_INSECURE"`
+	// File is where the stdout exporter writes, standard output if empty
+	File string `yaml:"file" toml:"file" env:"TRACING_FILE"`
+	// SampleRatio is the fraction of the traces starting here that are
+	// recorded; traces started by callers follow their decision
+	SampleRatio float64 `yaml:"sample_ratio" toml:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+}
+
+// Log is written to standard error.
+type Log struct {
+	// Format is json or text
+	Format string `yaml:"format" toml:"format" env:"LOG_FORMAT"`
+	// Level is the lowest level logged, one of debug, info, warn and error
+	Level string `yaml:"level" toml:"level" env:"LOG_LEVEL"`
+	// Levels overrides Level for components such as db, http or jobs. The
+	// environment variable lists them as component=level, separated by
+	// commas.
+	Levels map[string]string `yaml:"levels" toml:"levels" env:"LOG_LEVELS"`
+}
+
+// Default returns the configuration used for settings missing from the
+// file and the environment.
+func Default() *Config {
+	return &Config{
+		Server: Server{
+			Addr:            ":8888",
+			ShutdownDelay:   5 * time.Second,
+			ShutdownTimeout: 20 * time.Second,
+		},
+		Database: Database{
+			Driver:       "mysql",
+			AutoMigrate:  true,
+			StickyWindow: 5 * time.Second,
+			SlowQuery:    200 * time.Millisecond,
+		},
+		Storage: Storage{
+			Backend: "local",
+			Dir:     "data",
+		},
+		JWT: JWT{
+			TTL:        time.Hour,
+			MaxRefresh: time.Hour,
+		},
+		Mailer: Mailer{
+			Port: 587,
+		},
+		Limits: Limits{
+			MaxRequestBodySize: 4 << 20,
+		},
+		RateLimit: RateLimit{
+			LoginIP:            "20/1m",
+			LoginUser:          "10/1m",
+			MailCodeIP:         "5/1m",
+			MailCodeEmail:      "1/1m",
+			PasswordResetIP:    "5/1m",
+			PasswordResetEmail: "3/1h",
+			ShareIP:            "60/1m",
+			Share:              "120/1m",
+		},
+		Lockout: Lockout{
+			Threshold: 10,
+			Duration:  15 * time.Minute,
+		},
+		TwoFactor: TwoFactor{
+			Issuer:       "cloud-storage",
+			ChallengeTTL: 5 * time.Minute,
+			RequireAdmin: true,
+		},
+		PasswordReset: PasswordReset{
+			TTL: time.Hour,
+		},
+		Jobs: Jobs{
+			PollInterval: time.Second,
+			Lease:        time.Minute,
+			Retention:    7 * 24 * time.Hour,
+		},
+		Tracing: Tracing{
+			SampleRatio: 1,
+		},
+		Log: Log{
+			Format: "json",
+			Level:  "info",
+		},
+	}
+}
+
+// Load reads the configuration file at path, or at $CLOUD_STORAGE_CONFIG if
+// path is empty, applies the environment and validates the result. Without
+// a file the configuration comes from the environment alone.
+func Load(path string) (*Config, error) {
+	if path == "" {
+		path = os.Getenv(PathEnv)
+	}
+	c := Default()
+	if path != "" {
+		if err := c.readFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if err := applyEnv(reflect.ValueOf(c).Elem()); err != nil {
+		return nil, err
+	}
+	if err := c.readSecrets(); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return c, nil
+}
+
+func (c *Config) readFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, c)
+	case ".toml":
+		err = toml.Unmarshal(data, c)
+	default:
+		return fmt.Errorf("config file %s: unknown format %q", path, ext)
+	}
+	if err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyEnv sets the fields of v that have an env tag from the environment.
+func applyEnv(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f, sf := v.Field(i), t.Field(i)
+		if sf.Type.Kind() == reflect.Struct {
+			if err := applyEnv(f); err != nil {
+				return err
+			}
+			continue
+		}
+		name, ok := sf.Tag.Lookup("env")
+		if !ok {
+			continue
+		}
+		s, ok := os.LookupEnv(EnvPrefix + name)
+		if !ok {
+			continue
+		}
+		if err := setValue(f, s); err != nil {
+			return fmt.Errorf("%s%s: %w", EnvPrefix, name, err)
+		}
+	}
+	return nil
+}
+
+func setValue(f reflect.Value, s string) error {
+	if f.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		f.SetInt(int64(d))
+		return nil
+	}
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(s)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		f.SetInt(n)
+	case reflect.Float64:
+		x, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		f.SetFloat(x)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		f.SetBool(b)
+	case reflect.Map:
+		if f.Type().Key().Kind() != reflect.String || f.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", f.Type())
+		}
+		m := make(map[string]string)
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			k, v, ok := strings.Cut(item, "=")
+			if !ok {
+				return fmt.Errorf("%q is not a key=value pair", item)
+			}
+			m[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+		f.Set(reflect.ValueOf(m))
+	case reflect.Slice:
+		if f.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", f.Type())
+		}
+		var items []string
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		f.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", f.Type())
+	}
+	return nil
+}
+
+// readSecrets replaces secrets with the content of their files, if set.
+func (c *Config) readSecrets() error {
+	for _, s := range []struct {
+		value *string
+		file  string
+	}{
+		{&c.Database.DSN, c.Database.DSNFile},
+		{&c.JWT.Key, c.JWT.KeyFile},
+		{&c.Mailer.Password, c.Mailer.PasswordFile},
+	} {
+		if s.file == "" {
+			continue
+		}
+		data, err := os.ReadFile(s.file)
+		if err != nil {
+			return err
+		}
+		*s.value = strings.TrimRight(string(data), "\r\n")
+	}
+	return nil
+}
+
+// Validate reports the first setting that is missing or out of range.
+func (c *Config) Validate() error {
+	switch {
+	case c.Server.Addr == "":
+		return errors.New("server.addr is required")
+	case c.Server.ShutdownDelay < 0:
+		return errors.New("server.shutdown_delay must not be negative")
+	case c.Server.ShutdownTimeout <= 0:
+		return errors.New("server.shutdown_timeout must be positive")
+	case c.Database.Driver != "mysql" && c.Database.Driver != "sqlite" && c.Database.Driver != "postgres":
+		return fmt.Errorf("database.driver %q is not supported", c.Database.Driver)
+	case c.Database.DSN == "":
+		return errors.New("database.dsn is required")
+	case slices.Contains(c.Database.Replicas, ""):
+		return errors.New("database.replicas must not contain an empty dsn")
+	case c.Database.StickyWindow < 0:
+		return errors.New("database.sticky_window must not be negative")
+	case c.Database.SlowQuery < 0:
+		return errors.New("database.slow_query must not be negative")
+	case c.Storage.Backend != "local":
+		return fmt.Errorf("storage.backend %q is not supported", c.Storage.Backend)
+	case c.Storage.Dir == "":
+		return errors.New("storage.dir is required")
+	case c.JWT.Key == "":
+		return errors.New("jwt.key is required")
+	case len(c.JWT.Key) < 16:
+		return errors.New("jwt.key must be at least 16 bytes")
+	case c.JWT.TTL <= 0:
+		return errors.New("jwt.ttl must be positive")
+	case c.JWT.MaxRefresh < 0:
+		return errors.New("jwt.max_refresh must not be negative")
+	case c.Mailer.Host != "" && (c.Mailer.Port <= 0 || c.Mailer.Port > 65535):
+		return errors.New("mailer.port is out of range")
+	case c.Mailer.Host != "" && c.Mailer.From == "":
+		return errors.New("mailer.from is required with mailer.host")
+	case c.Quota.UserBytes < 0:
+		return errors.New("quota.user_bytes must not be negative")
+	case c.Limits.MaxRequestBodySize <= 0:
+		return errors.New("limits.max_request_body_size must be positive")
+	case c.Lockout.Threshold < 0:
+		return errors.New("lockout.threshold must not be negative")
+	case c.Lockout.Threshold > 0 && c.Lockout.Duration <= 0:
+		return errors.New("lockout.duration must be positive")
+	case c.TwoFactor.Issuer == "":
+		return errors.New("two_factor.issuer is required")
+	case c.TwoFactor.ChallengeTTL <= 0:
+		return errors.New("two_factor.challenge_ttl must be positive")
+	case c.PasswordReset.URL != "" && !absoluteURL(c.PasswordReset.URL):
+		return errors.New("password_reset.url must be an absolute URL")
+	case c.PasswordReset.TTL <= 0:
+		return errors.New("password_reset.ttl must be positive")
+	case c.Jobs.PollInterval <= 0:
+		return errors.New("jobs.poll_interval must be positive")
+	case c.Jobs.Lease < 3*time.Second:
+		return errors.New("jobs.lease must be at least 3s")
+	case c.Jobs.Retention < 0:
+		return errors.New("jobs.retention must not be negative")
+	case c.Tracing.Exporter != "" && c.Tracing.Exporter != "otlp" && c.Tracing.Exporter != "stdout":
+		return fmt.Errorf("tracing.exporter %q is not supported", c.Tracing.Exporter)
+	case c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1:
+		return errors.New("tracing.sample_ratio must be between 0 and 1")
+	case c.Log.Format != "json" && c.Log.Format != "text":
+		return fmt.Errorf("log.format %q is not supported", c.Log.Format)
+	case !validLevel(c.Log.Level):
+		return fmt.Errorf("log.level %q is not one of debug, info, warn and error", c.Log.Level)
+	}
+	if _, err := c.Server.TrustedProxyNets(); err != nil {
+		return fmt.Errorf("server.trusted_proxies: %w", err)
+	}
+	for name, rate := range c.RateLimit.rates() {
+		if _, _, err := ParseRate(rate); err != nil {
+			return fmt.Errorf("rate_limit.%s: %w", name, err)
+		}
+	}
+	for component, level := range c.Log.Levels {
+		if !validLevel(level) {
+			return fmt.Errorf("log.levels.%s %q is not one of debug, info, warn and error", component, level)
+		}
+	}
+	return nil
+}
+
+func validLevel(level string) bool {
+	switch strings.ToLower(level) {
+	case "debug", "info", "warn", "error":
+		return true
+	}
+	return false
+}
+
+func absoluteURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+// rates returns the limits by their names in the configuration file.
+func (r *RateLimit) rates() map[string]string {
+	return map[string]string{
+		"login_ip":             r.LoginIP,
+		"login_user":           r.LoginUser,
+		"mail_code_ip":         r.MailCodeIP,
+		"mail_code_email":      r.MailCodeEmail,
+		"password_reset_ip":    r.PasswordResetIP,
+		"password_reset_email": r.PasswordResetEmail,
+		"share_ip":             r.ShareIP,
+		"share":                r.Share,
+	}
+}
+
+// ParseRate parses a rate limit of n requests per period, written n/period
+// as in 20/1m. An empty limit is returned as 0 requests.
+func ParseRate(s string) (int, time.Duration, error) {
+	if s == "" {
+		return 0, 0, nil
+	}
+	count, period, ok := strings.Cut(s, "/")
+	if !ok {
+		return 0, 0, fmt.Errorf("%q is not of the form n/period", s)
+	}
+	n, err := strconv.Atoi(count)
+	if err != nil || n <= 0 {
+		return 0, 0, fmt.Errorf("%q: count must be a positive integer", s)
+	}
+	d, err := time.ParseDuration(period)
+	if err != nil || d <= 0 {
+		return 0, 0, fmt.Errorf("%q: period must be a positive duration", s)
+	}
+	return n, d, nil
+}
+
+// BlobDir is where the local backend keeps file content.
+func (s *Storage) BlobDir() string {
+	return filepath.Join(s.Dir, "blobs")
+}
+
+// IndexDir is where the full-text search index is kept.
+func (s *Storage) IndexDir() string {
+	return filepath.Join(s.Dir, "index")
+}
+
+// MultipartDir is where multipart uploads in progress are kept.
+func (s *Storage) MultipartDir() string {
+	return filepath.Join(s.Dir, "multipart")
+}
+
+// HostKeyPath is where the SSH host key of the SFTP server is kept.
+func (s *Storage) HostKeyPath() string {
+	return filepath.Join(s.Dir, "ssh_host_key")
+}
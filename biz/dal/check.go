@@ -0,0 +1,33 @@
+package dal
+
+import (
+	"cloud-storage/biz/dal/migrate"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// primary and migrator are the database set up by Init and its migrations.
+var (
+	primary  *gorm.DB
+	migrator *migrate.Migrator
+)
+
+// Ping reports whether the primary database answers.
+func Ping(ctx context.Context) error {
+	if primary == nil {
+		return errors.New("database is not set up")
+	}
+	return primary.WithContext(ctx).Exec("SELECT 1").Error
+}
+
+// CheckSchema reports whether the schema of the database is still at the
+// version the server requires, which another deployment migrating the
+// database up or down would break.
+func CheckSchema() error {
+	if migrator == nil {
+		return errors.New("database is not set up")
+	}
+	return schemaCurrent(migrator)
+}
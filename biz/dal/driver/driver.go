@@ -0,0 +1,31 @@
+// Package driver opens the databases the server can store its metadata in.
+package driver
+
+import (
+	"fmt"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// The supported drivers.
+const (
+	MySQL    = "mysql"
+	SQLite   = "sqlite"
+	Postgres = "postgres"
+)
+
+// Dialector returns the gorm dialector of the named driver connecting to dsn.
+func Dialector(name, dsn string) (gorm.Dialector, error) {
+	switch name {
+	case MySQL:
+		return mysql.Open(dsn), nil
+	case SQLite:
+		return sqlite.Open(dsn), nil
+	case Postgres:
+		return postgres.Open(dsn), nil
+	}
+	return nil, fmt.Errorf("unknown database driver %q", name)
+}
@@ -15,11 +15,11 @@ const TableNameRepositoryPool = "repository_pool"
 // RepositoryPool mapped from table <repository_pool>
 type RepositoryPool struct {
 	ID        uint32         `gorm:"column:id;type:int unsigned;primaryKey;autoIncrement:true" json:"id"`
-	Identity  string         `gorm:"column:identity;type:varchar(36)" json:"identity"`
-	Hash      string         `gorm:"column:hash;type:varchar(32);comment:文件的唯一标识" json:"hash"` // 文件的唯一标识
+	Identity  string         `gorm:"column:identity;type:varchar(36);uniqueIndex:uk_repository_pool_identity,priority:1" json:"identity"`
+	Hash      string         `gorm:"column:hash;type:varchar(32);uniqueIndex:uk_repository_pool_hash,priority:1;comment:文件的唯一标识" json:"hash"` // 文件的唯一标识
 	Name      string         `gorm:"column:name;type:varchar(255)" json:"name"`
 	Ext       string         `gorm:"column:ext;type:varchar(30);comment:文件扩展名" json:"ext"`   // 文件扩展名
-	Size      int32          `gorm:"column:size;type:int;comment:文件大小" json:"size"`          // 文件大小
+	Size      int64          `gorm:"column:size;type:bigint;comment:文件大小" json:"size"`       // 文件大小
 	Path      string         `gorm:"column:path;type:varchar(255);comment:文件路径" json:"path"` // 文件路径
 	CreatedAt time.Time      `gorm:"column:created_at;type:datetime" json:"created_at"`
 	UpdatedAt time.Time      `gorm:"column:updated_at;type:datetime" json:"updated_at"`
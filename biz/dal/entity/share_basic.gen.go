@@ -15,8 +15,8 @@ const TableNameShareBasic = "share_basic"
 // ShareBasic mapped from table <share_basic>
 type ShareBasic struct {
 	ID                     uint32         `gorm:"column:id;type:int unsigned;primaryKey;autoIncrement:true" json:"id"`
-	Identity               string         `gorm:"column:identity;type:varchar(36)" json:"identity"`
-	UserIdentity           string         `gorm:"column:user_identity;type:varchar(36)" json:"user_identity"`
+	Identity               string         `gorm:"column:identity;type:varchar(36);uniqueIndex:uk_share_basic_identity,priority:1" json:"identity"`
+	UserIdentity           string         `gorm:"column:user_identity;type:varchar(36);index:idx_share_basic_user_identity,priority:1" json:"user_identity"`
 	RepositoryIdentity     string         `gorm:"column:repository_identity;type:varchar(36);comment:公共池中的唯一标识" json:"repository_identity"`            // 公共池中的唯一标识
 	UserRepositoryIdentity string         `gorm:"column:user_repository_identity;type:varchar(36);comment:用户池子中的唯一标识" json:"user_repository_identity"` // 用户池子中的唯一标识
 	ExpiredTime            int32          `gorm:"column:expired_time;type:int;comment:失效时间，单位秒, 【0-永不失效】" json:"expired_time"`                         // 失效时间，单位秒, 【0-永不失效】
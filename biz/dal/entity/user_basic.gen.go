@@ -14,14 +14,23 @@ const TableNameUserBasic = "user_basic"
 
 // UserBasic mapped from table <user_basic>
 type UserBasic struct {
-	ID        uint32         `gorm:"column:id;type:int unsigned;primaryKey;autoIncrement:true" json:"id"`
-	Identity  string         `gorm:"column:identity;type:varchar(36)" json:"identity"`
-	Name      string         `gorm:"column:name;type:varchar(60)" json:"name"`
-	Password  string         `gorm:"column:password;type:varchar(32)" json:"password"`
-	Email     string         `gorm:"column:email;type:varchar(100)" json:"email"`
-	CreatedAt time.Time      `gorm:"column:created_at;type:datetime" json:"created_at"`
-	UpdatedAt time.Time      `gorm:"column:updated_at;type:datetime" json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;type:datetime" json:"deleted_at"`
+	ID                uint32         `gorm:"column:id;type:int unsigned;primaryKey;autoIncrement:true" json:"id"`
+	Identity          string         `gorm:"column:identity;type:varchar(36);uniqueIndex:uk_user_basic_identity,priority:1" json:"identity"`
+	Name              string         `gorm:"column:name;type:varchar(60);index:idx_user_basic_name,priority:1" json:"name"`
+	Password          string         `gorm:"column:password;type:varchar(32)" json:"password"`
+	Email             string         `gorm:"column:email;type:varchar(100);index:idx_user_basic_email,priority:1" json:"email"`
+	CreatedAt         time.Time      `gorm:"column:created_at;type:datetime" json:"created_at"`
+	UpdatedAt         time.Time      `gorm:"column:updated_at;type:datetime" json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"column:deleted_at;type:datetime" json:"deleted_at"`
+	Admin             bool           `gorm:"column:admin;type:tinyint(1);not null;comment:管理员" json:"admin"`                                   // 管理员
+	Disabled          bool           `gorm:"column:disabled;type:tinyint(1);not null;comment:禁用后无法登录" json:"disabled"`                         // 禁用后无法登录
+	Quota             int64          `gorm:"column:quota;type:bigint;not null;comment:存储配额，单位字节，【0-默认配额，-1-不限】" json:"quota"`                  // 存储配额，单位字节，【0-默认配额，-1-不限】
+	FailedLogins      int32          `gorm:"column:failed_logins;type:int;not null;comment:连续登录失败次数" json:"failed_logins"`                     // 连续登录失败次数
+	LockedUntil       time.Time      `gorm:"column:locked_until;type:datetime;comment:锁定截止时间，之前无法用密码登录" json:"locked_until"`                   // 锁定截止时间，之前无法用密码登录
+	TotpSecret        string         `gorm:"column:totp_secret;type:varchar(64);not null;comment:TOTP 密钥，base32 编码，空表示未设置" json:"totp_secret"` // TOTP 密钥，base32 编码，空表示未设置
+	TotpEnabled       bool           `gorm:"column:totp_enabled;type:tinyint(1);not null;comment:两步验证已启用，登录需要验证码" json:"totp_enabled"`         // 两步验证已启用，登录需要验证码
+	TotpLastStep      int64          `gorm:"column:totp_last_step;type:bigint;not null;comment:最后使用的 TOTP 时间步，防止验证码重放" json:"totp_last_step"`  // 最后使用的 TOTP 时间步，防止验证码重放
+	PasswordChangedAt time.Time      `gorm:"column:password_changed_at;type:datetime;comment:修改密码时间，之前签发的登录令牌失效" json:"password_changed_at"`   // 修改密码时间，之前签发的登录令牌失效
 }
 
 // TableName UserBasic's table name
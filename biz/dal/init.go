@@ -1,23 +1,96 @@
 package dal
 
 import (
+	"cloud-storage/biz/audit"
+	"cloud-storage/biz/config"
+	"cloud-storage/biz/dal/driver"
+	"cloud-storage/biz/dal/migrate"
 	"cloud-storage/biz/dal/query"
-	"gorm.io/driver/mysql"
+	"cloud-storage/biz/dal/resolver"
+	"cloud-storage/biz/logging"
+	"cloud-storage/biz/metrics"
+	"cloud-storage/biz/tracing"
+	"fmt"
+
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
-var dsn = "root:123456@(127.0.0.1:3306)/cloud_storage?charset=utf8mb4&parseTime=True&loc=Local"
-
-func Init() {
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
+// Open connects to the database described by c.
+func Open(c *config.Database) (*gorm.DB, error) {
+	dialector, err := driver.Dialector(c.Driver, c.DSN)
+	if err != nil {
+		return nil, err
+	}
+	return gorm.Open(dialector, &gorm.Config{
 		SkipDefaultTransaction: true,
 		PrepareStmt:            true,
-		Logger:                 logger.Default.LogMode(logger.Info),
+		// Report unique constraint violations as gorm.ErrDuplicatedKey
+		TranslateError: true,
+		Logger:         logging.Gorm(c.SlowQuery),
 	})
+}
+
+func Init(c *config.Database) {
+	db, err := Open(c)
+	if err != nil {
+		panic(err)
+	}
+	if err := db.Use(metrics.GormPlugin()); err != nil {
+		panic(err)
+	}
+	if err := db.Use(tracing.GormPlugin()); err != nil {
+		panic(err)
+	}
+	if err := db.Use(audit.GormPlugin()); err != nil {
+		panic(err)
+	}
+	m, err := migrate.New(db, c.Driver)
 	if err != nil {
 		panic(err)
 	}
+	if err := checkSchema(m, c); err != nil {
+		panic(err)
+	}
+	if len(c.Replicas) > 0 {
+		if err := useReplicas(db, c); err != nil {
+			panic(err)
+		}
+	}
 
 	query.SetDefault(db)
+	primary, migrator = db, m
+}
+
+// useReplicas sends the reads of db to the replicas of c. Migrations run
+// before, so that they only ever touch the primary.
+func useReplicas(db *gorm.DB, c *config.Database) error {
+	replicas := make([]gorm.Dialector, len(c.Replicas))
+	for i, dsn := range c.Replicas {
+		dialector, err := driver.Dialector(c.Driver, dsn)
+		if err != nil {
+			return err
+		}
+		replicas[i] = dialector
+	}
+	return resolver.Register(db, replicas, c.StickyWindow)
+}
+
+// checkSchema applies pending migrations if c allows it, and otherwise
+// makes sure there are none.
+func checkSchema(m *migrate.Migrator, c *config.Database) error {
+	if c.AutoMigrate {
+		return m.Up()
+	}
+	return schemaCurrent(m)
+}
+
+func schemaCurrent(m *migrate.Migrator) error {
+	version, err := m.Version()
+	if err != nil {
+		return err
+	}
+	if version != m.Latest() {
+		return fmt.Errorf("database schema is at version %d but %d is required, run migrate up", version, m.Latest())
+	}
+	return nil
 }
@@ -0,0 +1,263 @@
+// Package migrate applies the versioned schema migrations embedded in the
+// binary.
+//
+// Every migration is a pair of scripts under migrations/<driver>, named
+// <version>_<name>.up.sql and <version>_<name>.down.sql. The versions
+// applied to a database are recorded in its schema_migrations table.
+package migrate
+
+import (
+	"cloud-storage/biz/logging"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations
+var migrations embed.FS
+
+var log = logging.Logger("migrate")
+
+// Migration is a versioned change of the schema.
+type Migration struct {
+	Version int64
+	Name    string
+	up      string
+	down    string
+}
+
+// Status is a migration and when it was applied, nil if it is pending.
+type Status struct {
+	*Migration
+	AppliedAt *time.Time
+}
+
+// Migrator migrates a database.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []*Migration
+}
+
+// schemaMigration is a row of the schema_migrations table.
+type schemaMigration struct {
+	Version   int64
+	Name      string
+	AppliedAt time.Time
+}
+
+// New returns a migrator for db, which is opened with the named driver.
+func New(db *gorm.DB, driver string) (*Migrator, error) {
+	ms, err := load(driver)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Exec("CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, name varchar(255) NOT NULL, applied_at timestamp NOT NULL)").Error
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: ms}, nil
+}
+
+// load reads the migrations of the named driver, sorted by version.
+func load(driver string) ([]*Migration, error) {
+	dir := path.Join("migrations", driver)
+	entries, err := fs.ReadDir(migrations, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for database driver %q", driver)
+	}
+	byVersion := make(map[int64]*Migration)
+	for _, e := range entries {
+		base, direction, ok := cutDirection(e.Name())
+		if !ok {
+			return nil, fmt.Errorf("migration %s: name must end with .up.sql or .down.sql", e.Name())
+		}
+		v, name, _ := strings.Cut(base, "_")
+		version, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: name must start with a positive version", e.Name())
+		}
+		data, err := fs.ReadFile(migrations, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if direction == "up" {
+			m.up = string(data)
+		} else {
+			m.down = string(data)
+		}
+	}
+	ms := make([]*Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.up == "" || m.down == "" {
+			return nil, fmt.Errorf("migration %d: both up and down scripts are required", m.Version)
+		}
+		ms = append(ms, m)
+	}
+	sort.Slice(ms, func(i, j int) bool { return ms[i].Version < ms[j].Version })
+	return ms, nil
+}
+
+func cutDirection(file string) (string, string, bool) {
+	if base, ok := strings.CutSuffix(file, ".up.sql"); ok {
+		return base, "up", true
+	}
+	if base, ok := strings.CutSuffix(file, ".down.sql"); ok {
+		return base, "down", true
+	}
+	return "", "", false
+}
+
+// Latest returns the version of the last migration, 0 if there is none.
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version returns the version of the last applied migration, 0 if none
+// was applied.
+func (m *Migrator) Version() (int64, error) {
+	var version int64
+	err := m.db.Raw("SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version).Error
+	return version, err
+}
+
+// Status returns every migration with when it was applied.
+func (m *Migrator) Status() ([]*Status, error) {
+	var rows []*schemaMigration
+	if err := m.db.Table("schema_migrations").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[int64]time.Time, len(rows))
+	for _, r := range rows {
+		applied[r.Version] = r.AppliedAt
+	}
+	statuses := make([]*Status, len(m.migrations))
+	for i, mg := range m.migrations {
+		statuses[i] = &Status{Migration: mg}
+		if t, ok := applied[mg.Version]; ok {
+			statuses[i].AppliedAt = &t
+		}
+	}
+	return statuses, nil
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up() error {
+	return m.To(m.Latest())
+}
+
+// Down reverts the last applied migration.
+func (m *Migrator) Down() error {
+	version, err := m.Version()
+	if err != nil {
+		return err
+	}
+	if version == 0 {
+		return nil
+	}
+	var target int64
+	for _, mg := range m.migrations {
+		if mg.Version < version {
+			target = mg.Version
+		}
+	}
+	return m.To(target)
+}
+
+// To applies or reverts migrations until the schema is at version, 0 to
+// revert all of them.
+func (m *Migrator) To(version int64) error {
+	if version != 0 && m.find(version) == nil {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+	current, err := m.Version()
+	if err != nil {
+		return err
+	}
+	if current > m.Latest() {
+		return fmt.Errorf("database is at version %d, newer than this binary knows (%d)", current, m.Latest())
+	}
+	for _, mg := range m.migrations {
+		if mg.Version > current && mg.Version <= version {
+			if err := m.apply(mg, true); err != nil {
+				return err
+			}
+		}
+	}
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		mg := m.migrations[i]
+		if mg.Version <= current && mg.Version > version {
+			if err := m.apply(mg, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) find(version int64) *Migration {
+	for _, mg := range m.migrations {
+		if mg.Version == version {
+			return mg
+		}
+	}
+	return nil
+}
+
+// apply runs the up or down script of mg and records the result. Databases
+// with transactional DDL roll back a failed script as a whole; on the others
+// it has to be cleaned up by hand.
+func (m *Migrator) apply(mg *Migration, up bool) error {
+	script, direction := mg.up, "up"
+	if !up {
+		script, direction = mg.down, "down"
+	}
+	log.Info("migrate "+direction, "version", mg.Version, "name", mg.Name)
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		for _, stmt := range statements(script) {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+		if up {
+			return tx.Table("schema_migrations").Create(&schemaMigration{Version: mg.Version, Name: mg.Name, AppliedAt: time.Now()}).Error
+		}
+		return tx.Exec("DELETE FROM schema_migrations WHERE version = ?", mg.Version).Error
+	})
+	if err != nil {
+		return fmt.Errorf("migration %d_%s %s: %w", mg.Version, mg.Name, direction, err)
+	}
+	return nil
+}
+
+// statements splits a script into statements, dropping comment lines, as
+// not every driver runs several statements at once.
+func statements(script string) []string {
+	var lines []string
+	for _, line := range strings.Split(script, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "--") {
+			lines = append(lines, line)
+		}
+	}
+	var stmts []string
+	for _, stmt := range strings.Split(strings.Join(lines, "\n"), ";\n") {
+		if stmt = strings.TrimSuffix(strings.TrimSpace(stmt), ";"); stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+	}
+	return stmts
+}
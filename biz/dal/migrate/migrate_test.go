@@ -0,0 +1,186 @@
+package migrate
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestStatements(t *testing.T) {
+	tests := []struct {
+		name, script string
+		want         []string
+	}{
+		{"empty", "", nil},
+		{"comments only", "-- nothing to do on this database\n  -- indented\n", nil},
+		{"one", "CREATE TABLE a (id int);\n", []string{"CREATE TABLE a (id int)"}},
+		{"no trailing semicolon", "DROP TABLE a", []string{"DROP TABLE a"}},
+		{
+			"several",
+			"-- 用户\nCREATE TABLE a (\n  id int\n);\n\nCREATE INDEX i ON a (id);\n",
+			[]string{"CREATE TABLE a (\n  id int\n)", "CREATE INDEX i ON a (id)"},
+		},
+		{
+			"semicolon inside a line",
+			"INSERT INTO a VALUES (';');\nDROP TABLE b;",
+			[]string{"INSERT INTO a VALUES (';')", "DROP TABLE b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := statements(tt.script); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("statements = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCutDirection(t *testing.T) {
+	tests := []struct {
+		file, base, direction string
+		ok                    bool
+	}{
+		{"0001_init.up.sql", "0001_init", "up", true},
+		{"0001_init.down.sql", "0001_init", "down", true},
+		{"0001_init.sql", "", "", false},
+		{"0001_init.up.sql.bak", "", "", false},
+	}
+	for _, tt := range tests {
+		base, direction, ok := cutDirection(tt.file)
+		if base != tt.base || direction != tt.direction || ok != tt.ok {
+			t.Errorf("cutDirection(%q) = %q, %q, %v, want %q, %q, %v",
+				tt.file, base, direction, ok, tt.base, tt.direction, tt.ok)
+		}
+	}
+}
+
+// TestLoad checks that every driver has the same migrations, each with
+// both scripts.
+func TestLoad(t *testing.T) {
+	want, err := load("sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(want) == 0 {
+		t.Fatal("no sqlite migrations")
+	}
+	for i, m := range want {
+		if m.Version != int64(i+1) {
+			t.Errorf("migration %d_%s is number %d", m.Version, m.Name, i+1)
+		}
+	}
+	for _, driver := range []string{"mysql", "postgres"} {
+		ms, err := load(driver)
+		if err != nil {
+			t.Fatalf("%s: %v", driver, err)
+		}
+		if len(ms) != len(want) {
+			t.Fatalf("%s has %d migrations, sqlite %d", driver, len(ms), len(want))
+		}
+		for i, m := range ms {
+			if m.Version != want[i].Version || m.Name != want[i].Name {
+				t.Errorf("%s migration %d_%s, sqlite %d_%s", driver, m.Version, m.Name, want[i].Version, want[i].Name)
+			}
+		}
+	}
+	if _, err := load("oracle"); err == nil {
+		t.Error("load accepted a driver without migrations")
+	}
+}
+
+func newTestMigrator(t *testing.T) *Migrator {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := New(db, "sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func version(t *testing.T, m *Migrator) int64 {
+	t.Helper()
+	v, err := m.Version()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestMigrator(t *testing.T) {
+	m := newTestMigrator(t)
+	latest := m.Latest()
+
+	steps := []struct {
+		name string
+		run  func() error
+		want int64
+	}{
+		{"fresh", func() error { return nil }, 0},
+		{"up", m.Up, latest},
+		{"up again", m.Up, latest},
+		{"down", m.Down, latest - 1},
+		{"to first", func() error { return m.To(1) }, 1},
+		{"to latest", func() error { return m.To(latest) }, latest},
+		{"to zero", func() error { return m.To(0) }, 0},
+		{"down at zero", m.Down, 0},
+		{"up from zero", m.Up, latest},
+	}
+	for _, s := range steps {
+		if err := s.run(); err != nil {
+			t.Fatalf("%s: %v", s.name, err)
+		}
+		if got := version(t, m); got != s.want {
+			t.Fatalf("%s: version %d, want %d", s.name, got, s.want)
+		}
+	}
+
+	if err := m.To(latest + 1); err == nil {
+		t.Error("To accepted an unknown version")
+	}
+
+	statuses, err := m.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != int(latest) {
+		t.Fatalf("Status returned %d migrations, want %d", len(statuses), latest)
+	}
+	for _, s := range statuses {
+		if s.AppliedAt == nil {
+			t.Errorf("migration %d_%s is not applied", s.Version, s.Name)
+		}
+	}
+}
+
+func TestMigratorNewerDatabase(t *testing.T) {
+	m := newTestMigrator(t)
+	if err := m.Up(); err != nil {
+		t.Fatal(err)
+	}
+	newer := m.Latest() + 1
+	if err := m.db.Exec("INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, 'future', CURRENT_TIMESTAMP)", newer).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Up(); err == nil {
+		t.Error("Up ran on a database newer than the binary")
+	}
+	statuses, err := m.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range statuses {
+		if s.Version == newer {
+			t.Error("Status listed a migration the binary does not know")
+		}
+	}
+}
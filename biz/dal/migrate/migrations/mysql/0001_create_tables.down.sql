@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS `user_ssh_key`;
+DROP TABLE IF EXISTS `user_repository_property`;
+DROP TABLE IF EXISTS `user_repository`;
+DROP TABLE IF EXISTS `user_basic`;
+DROP TABLE IF EXISTS `user_access_key`;
+DROP TABLE IF EXISTS `share_basic`;
+DROP TABLE IF EXISTS `repository_pool`;
@@ -1,28 +1,4 @@
-/*
- Navicat Premium Data Transfer
-
- Source Server         : 192.168.1.8
- Source Server Type    : MySQL
- Source Server Version : 50736
- Source Host           : 192.168.1.8:3306
- Source Schema         : cloud-disk
-
- Target Server Type    : MySQL
- Target Server Version : 50736
- File Encoding         : 65001
-
- Date: 05/05/2022 21:05:15
-*/
-
-SET NAMES utf8mb4;
-SET
-FOREIGN_KEY_CHECKS = 0;
-
--- ----------------------------
--- Table structure for repository_pool
--- ----------------------------
-DROP TABLE IF EXISTS `repository_pool`;
-CREATE TABLE `repository_pool`
+CREATE TABLE IF NOT EXISTS `repository_pool`
 (
     `id`         int(11) unsigned NOT NULL AUTO_INCREMENT,
     `identity`   varchar(36)  DEFAULT NULL,
@@ -35,13 +11,9 @@ CREATE TABLE `repository_pool`
     `updated_at` datetime     DEFAULT NULL,
     `deleted_at` datetime     DEFAULT NULL,
     PRIMARY KEY (`id`)
-) ENGINE=InnoDB AUTO_INCREMENT=3 DEFAULT CHARSET=utf8;
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
 
--- ----------------------------
--- Table structure for share_basic
--- ----------------------------
-DROP TABLE IF EXISTS `share_basic`;
-CREATE TABLE `share_basic`
+CREATE TABLE IF NOT EXISTS `share_basic`
 (
     `id`                       int(11) unsigned NOT NULL AUTO_INCREMENT,
     `identity`                 varchar(36) DEFAULT NULL,
@@ -54,13 +26,22 @@ CREATE TABLE `share_basic`
     `updated_at`               datetime    DEFAULT NULL,
     `deleted_at`               datetime    DEFAULT NULL,
     PRIMARY KEY (`id`)
-) ENGINE=InnoDB AUTO_INCREMENT=3 DEFAULT CHARSET=utf8;
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE IF NOT EXISTS `user_access_key`
+(
+    `id`                int(11) unsigned NOT NULL AUTO_INCREMENT,
+    `identity`          varchar(36) DEFAULT NULL,
+    `user_identity`     varchar(36) DEFAULT NULL,
+    `access_key_id`     varchar(20) DEFAULT NULL COMMENT 'S3 访问密钥 ID',
+    `secret_access_key` varchar(40) DEFAULT NULL COMMENT 'S3 访问密钥，用于 SigV4 签名校验',
+    `created_at`        datetime    DEFAULT NULL,
+    `updated_at`        datetime    DEFAULT NULL,
+    `deleted_at`        datetime    DEFAULT NULL,
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
 
--- ----------------------------
--- Table structure for user_basic
--- ----------------------------
-DROP TABLE IF EXISTS `user_basic`;
-CREATE TABLE `user_basic`
+CREATE TABLE IF NOT EXISTS `user_basic`
 (
     `id`         int(11) unsigned NOT NULL AUTO_INCREMENT,
     `identity`   varchar(36)  DEFAULT NULL,
@@ -71,13 +52,9 @@ CREATE TABLE `user_basic`
     `updated_at` datetime     DEFAULT NULL,
     `deleted_at` datetime     DEFAULT NULL,
     PRIMARY KEY (`id`)
-) ENGINE=InnoDB AUTO_INCREMENT=5 DEFAULT CHARSET=utf8;
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
 
--- ----------------------------
--- Table structure for user_repository
--- ----------------------------
-DROP TABLE IF EXISTS `user_repository`;
-CREATE TABLE `user_repository`
+CREATE TABLE IF NOT EXISTS `user_repository`
 (
     `id`                  int(11) unsigned NOT NULL AUTO_INCREMENT,
     `identity`            varchar(36)  DEFAULT NULL,
@@ -90,7 +67,32 @@ CREATE TABLE `user_repository`
     `updated_at`          datetime     DEFAULT NULL,
     `deleted_at`          datetime     DEFAULT NULL,
     PRIMARY KEY (`id`)
-) ENGINE=InnoDB AUTO_INCREMENT=8 DEFAULT CHARSET=utf8;
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
 
-SET
-FOREIGN_KEY_CHECKS = 1;
\ No newline at end of file
+CREATE TABLE IF NOT EXISTS `user_repository_property`
+(
+    `id`                       int(11) unsigned NOT NULL AUTO_INCREMENT,
+    `user_repository_identity` varchar(36)  DEFAULT NULL,
+    `space`                    varchar(255) DEFAULT NULL COMMENT 'XML 命名空间',
+    `name`                     varchar(255) DEFAULT NULL,
+    `lang`                     varchar(35)  DEFAULT NULL,
+    `inner_xml`                text COMMENT '属性值（XML 片段）',
+    `created_at`               datetime     DEFAULT NULL,
+    `updated_at`               datetime     DEFAULT NULL,
+    `deleted_at`               datetime     DEFAULT NULL,
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE IF NOT EXISTS `user_ssh_key`
+(
+    `id`            int(11) unsigned NOT NULL AUTO_INCREMENT,
+    `identity`      varchar(36) DEFAULT NULL,
+    `user_identity` varchar(36) DEFAULT NULL,
+    `name`          varchar(60) DEFAULT NULL,
+    `public_key`    text COMMENT 'authorized_keys 格式的 SSH 公钥',
+    `fingerprint`   varchar(64) DEFAULT NULL COMMENT 'SHA256 指纹，用于 SFTP 登录时查找公钥',
+    `created_at`    datetime    DEFAULT NULL,
+    `updated_at`    datetime    DEFAULT NULL,
+    `deleted_at`    datetime    DEFAULT NULL,
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
@@ -0,0 +1,16 @@
+DROP INDEX `idx_user_ssh_key_fingerprint` ON `user_ssh_key`;
+DROP INDEX `idx_user_ssh_key_user_identity` ON `user_ssh_key`;
+DROP INDEX `uk_user_ssh_key_identity` ON `user_ssh_key`;
+DROP INDEX `idx_user_repository_property_user_repository_identity` ON `user_repository_property`;
+DROP INDEX `idx_user_repository_repository_identity` ON `user_repository`;
+DROP INDEX `idx_user_repository_user_identity_parent_id_name` ON `user_repository`;
+DROP INDEX `uk_user_repository_identity` ON `user_repository`;
+DROP INDEX `idx_user_basic_name` ON `user_basic`;
+DROP INDEX `uk_user_basic_identity` ON `user_basic`;
+DROP INDEX `idx_user_access_key_user_identity` ON `user_access_key`;
+DROP INDEX `uk_user_access_key_access_key_id` ON `user_access_key`;
+DROP INDEX `uk_user_access_key_identity` ON `user_access_key`;
+DROP INDEX `idx_share_basic_user_identity` ON `share_basic`;
+DROP INDEX `uk_share_basic_identity` ON `share_basic`;
+DROP INDEX `uk_repository_pool_hash` ON `repository_pool`;
+DROP INDEX `uk_repository_pool_identity` ON `repository_pool`;
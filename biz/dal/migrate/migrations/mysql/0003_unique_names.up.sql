@@ -0,0 +1,12 @@
+-- Names must be unique within a folder. Rename the live duplicates that
+-- slipped in before, keeping the oldest entry as is.
+UPDATE `user_repository` `ur`
+    JOIN `user_repository` `o`
+    ON `o`.`user_identity` = `ur`.`user_identity` AND `o`.`parent_id` = `ur`.`parent_id` AND
+       `o`.`name` = `ur`.`name` AND `o`.`deleted_at` IS NULL AND `o`.`id` < `ur`.`id`
+SET `ur`.`name` = CONCAT(`ur`.`name`, ' (', `ur`.`id`, ')')
+WHERE `ur`.`deleted_at` IS NULL;
+
+-- Deleted entries are kept, so only live ones take part. The expression is
+-- NULL for deleted entries, and NULLs never collide in a unique index.
+CREATE UNIQUE INDEX `uk_user_repository_user_identity_parent_id_name` ON `user_repository` (`user_identity`, `parent_id`, `name`, ((CASE WHEN `deleted_at` IS NULL THEN 1 END)));
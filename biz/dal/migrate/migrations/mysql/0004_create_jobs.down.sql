@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `job_schedule`;
+DROP TABLE IF EXISTS `job`;
@@ -0,0 +1,33 @@
+CREATE TABLE `job`
+(
+    `id`           int(11) unsigned NOT NULL AUTO_INCREMENT,
+    `type`         varchar(64)  NOT NULL,
+    `payload`      text COMMENT 'JSON 格式的任务参数',
+    `dedup_key`    varchar(255) NOT NULL DEFAULT '' COMMENT '非空时同类型只保留一个待执行的任务',
+    `state`        varchar(16)  NOT NULL COMMENT 'pending, running, succeeded 或 failed',
+    `attempts`     int          NOT NULL DEFAULT 0,
+    `max_attempts` int          NOT NULL DEFAULT 0,
+    `run_at`       datetime     NOT NULL COMMENT '最早执行时间',
+    `locked_by`    varchar(64)  NOT NULL DEFAULT '' COMMENT '执行任务的进程',
+    `locked_until` datetime     DEFAULT NULL COMMENT '租约到期后任务可被其他进程接管',
+    `last_error`   text,
+    `finished_at`  datetime     DEFAULT NULL,
+    `created_at`   datetime     DEFAULT NULL,
+    `updated_at`   datetime     DEFAULT NULL,
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE INDEX `idx_job_state_run_at` ON `job` (`state`, `run_at`);
+CREATE INDEX `idx_job_type_dedup_key` ON `job` (`type`, `dedup_key`);
+
+CREATE TABLE `job_schedule`
+(
+    `id`          int(11) unsigned NOT NULL AUTO_INCREMENT,
+    `name`        varchar(64) NOT NULL,
+    `next_run_at` datetime    NOT NULL,
+    `created_at`  datetime    DEFAULT NULL,
+    `updated_at`  datetime    DEFAULT NULL,
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE UNIQUE INDEX `uk_job_schedule_name` ON `job_schedule` (`name`);
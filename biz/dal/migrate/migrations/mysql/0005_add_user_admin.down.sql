@@ -0,0 +1 @@
+ALTER TABLE `user_basic` DROP COLUMN `admin`;
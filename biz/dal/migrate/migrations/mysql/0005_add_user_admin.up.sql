@@ -0,0 +1 @@
+ALTER TABLE `user_basic` ADD COLUMN `admin` tinyint(1) NOT NULL DEFAULT 0 COMMENT '管理员';
@@ -0,0 +1,2 @@
+ALTER TABLE `user_basic` ADD COLUMN `disabled` tinyint(1) NOT NULL DEFAULT 0 COMMENT '禁用后无法登录';
+ALTER TABLE `user_basic` ADD COLUMN `quota` bigint NOT NULL DEFAULT 0 COMMENT '存储配额，单位字节，【0-默认配额，-1-不限】';
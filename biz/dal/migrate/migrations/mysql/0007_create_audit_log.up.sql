@@ -0,0 +1,18 @@
+CREATE TABLE `audit_log`
+(
+    `id`         bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+    `action`     varchar(64)  NOT NULL COMMENT '操作，如 user.login、file.delete',
+    `actor`      varchar(36)  NOT NULL DEFAULT '' COMMENT '执行操作的用户，空表示匿名或管理命令',
+    `target`     varchar(255) NOT NULL DEFAULT '' COMMENT '被操作对象的 identity',
+    `old_value`  text COMMENT 'JSON 格式的操作前的值',
+    `new_value`  text COMMENT 'JSON 格式的操作后的值',
+    `ip`         varchar(64)  NOT NULL DEFAULT '',
+    `user_agent` varchar(255) NOT NULL DEFAULT '',
+    `created_at` datetime     NOT NULL,
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE INDEX `idx_audit_log_created_at` ON `audit_log` (`created_at`);
+CREATE INDEX `idx_audit_log_actor_created_at` ON `audit_log` (`actor`, `created_at`);
+CREATE INDEX `idx_audit_log_target_created_at` ON `audit_log` (`target`, `created_at`);
+CREATE INDEX `idx_audit_log_action_created_at` ON `audit_log` (`action`, `created_at`);
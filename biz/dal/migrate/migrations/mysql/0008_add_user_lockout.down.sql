@@ -0,0 +1,2 @@
+ALTER TABLE `user_basic` DROP COLUMN `locked_until`;
+ALTER TABLE `user_basic` DROP COLUMN `failed_logins`;
@@ -0,0 +1,2 @@
+ALTER TABLE `user_basic` ADD COLUMN `failed_logins` int NOT NULL DEFAULT 0 COMMENT '连续登录失败次数';
+ALTER TABLE `user_basic` ADD COLUMN `locked_until` datetime DEFAULT NULL COMMENT '锁定截止时间，之前无法用密码登录';
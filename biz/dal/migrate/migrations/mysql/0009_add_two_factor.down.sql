@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS `user_recovery_code`;
+ALTER TABLE `user_basic` DROP COLUMN `totp_last_step`;
+ALTER TABLE `user_basic` DROP COLUMN `totp_enabled`;
+ALTER TABLE `user_basic` DROP COLUMN `totp_secret`;
@@ -0,0 +1,15 @@
+ALTER TABLE `user_basic` ADD COLUMN `totp_secret` varchar(64) NOT NULL DEFAULT '' COMMENT 'TOTP 密钥，base32 编码，空表示未设置';
+ALTER TABLE `user_basic` ADD COLUMN `totp_enabled` tinyint(1) NOT NULL DEFAULT 0 COMMENT '两步验证已启用，登录需要验证码';
+ALTER TABLE `user_basic` ADD COLUMN `totp_last_step` bigint NOT NULL DEFAULT 0 COMMENT '最后使用的 TOTP 时间步，防止验证码重放';
+
+CREATE TABLE `user_recovery_code`
+(
+    `id`            int(11) unsigned NOT NULL AUTO_INCREMENT,
+    `user_identity` varchar(36) NOT NULL,
+    `code_hash`     varchar(64) NOT NULL COMMENT '恢复码的 SHA256，十六进制',
+    `used_at`       datetime    DEFAULT NULL COMMENT '使用时间，空表示未使用',
+    `created_at`    datetime    NOT NULL,
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE INDEX `idx_user_recovery_code_user_identity` ON `user_recovery_code` (`user_identity`);
@@ -0,0 +1,20 @@
+CREATE TABLE `user_api_token`
+(
+    `id`            int(11) unsigned NOT NULL AUTO_INCREMENT,
+    `identity`      varchar(36)  NOT NULL,
+    `user_identity` varchar(36)  NOT NULL,
+    `name`          varchar(60)  NOT NULL,
+    `token_hash`    varchar(64)  NOT NULL COMMENT '令牌的 SHA256，十六进制',
+    `token_prefix`  varchar(16)  NOT NULL COMMENT '令牌的开头，便于用户辨认',
+    `scopes`        varchar(255) NOT NULL COMMENT '权限范围，逗号分隔，如 files:read,files:write',
+    `expires_at`    datetime     DEFAULT NULL COMMENT '过期时间，空表示永不过期',
+    `last_used_at`  datetime     DEFAULT NULL COMMENT '最后使用时间，空表示未使用',
+    `last_used_ip`  varchar(45)  NOT NULL DEFAULT '' COMMENT '最后使用时的客户端 IP',
+    `revoked_at`    datetime     DEFAULT NULL COMMENT '吊销时间，空表示未吊销',
+    `created_at`    datetime     NOT NULL,
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE UNIQUE INDEX `uk_user_api_token_identity` ON `user_api_token` (`identity`);
+CREATE UNIQUE INDEX `uk_user_api_token_token_hash` ON `user_api_token` (`token_hash`);
+CREATE INDEX `idx_user_api_token_user_identity` ON `user_api_token` (`user_identity`);
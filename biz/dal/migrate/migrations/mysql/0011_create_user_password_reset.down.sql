@@ -0,0 +1,3 @@
+DROP INDEX `idx_user_basic_email` ON `user_basic`;
+DROP TABLE IF EXISTS `user_password_reset`;
+ALTER TABLE `user_basic` DROP COLUMN `password_changed_at`;
@@ -0,0 +1,16 @@
+ALTER TABLE `user_basic` ADD COLUMN `password_changed_at` datetime DEFAULT NULL COMMENT '修改密码时间，之前签发的登录令牌失效';
+
+CREATE TABLE `user_password_reset`
+(
+    `id`            int(11) unsigned NOT NULL AUTO_INCREMENT,
+    `user_identity` varchar(36) NOT NULL,
+    `token_hash`    varchar(64) NOT NULL COMMENT '重置令牌的 SHA256，十六进制',
+    `expires_at`    datetime    NOT NULL COMMENT '过期时间',
+    `used_at`       datetime    DEFAULT NULL COMMENT '使用时间，空表示未使用',
+    `created_at`    datetime    NOT NULL,
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE UNIQUE INDEX `uk_user_password_reset_token_hash` ON `user_password_reset` (`token_hash`);
+CREATE INDEX `idx_user_password_reset_user_identity` ON `user_password_reset` (`user_identity`);
+CREATE INDEX `idx_user_basic_email` ON `user_basic` (`email`);
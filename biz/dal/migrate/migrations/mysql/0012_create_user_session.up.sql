@@ -0,0 +1,16 @@
+CREATE TABLE `user_session`
+(
+    `id`            int(11) unsigned NOT NULL AUTO_INCREMENT,
+    `identity`      varchar(36)  NOT NULL,
+    `user_identity` varchar(36)  NOT NULL,
+    `device_name`   varchar(60)  NOT NULL DEFAULT '' COMMENT '设备名称，登录时由客户端提供',
+    `user_agent`    varchar(255) NOT NULL DEFAULT '' COMMENT '登录时的 User-Agent',
+    `ip`            varchar(45)  NOT NULL DEFAULT '' COMMENT '最后访问时的客户端 IP',
+    `last_seen_at`  datetime     NOT NULL COMMENT '最后访问时间',
+    `expires_at`    datetime     NOT NULL COMMENT '过期时间，与访问令牌相同',
+    `created_at`    datetime     NOT NULL,
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE UNIQUE INDEX `uk_user_session_identity` ON `user_session` (`identity`);
+CREATE INDEX `idx_user_session_user_identity` ON `user_session` (`user_identity`);
@@ -0,0 +1 @@
+ALTER TABLE `repository_pool` MODIFY COLUMN `size` int(11) DEFAULT NULL COMMENT '文件大小';
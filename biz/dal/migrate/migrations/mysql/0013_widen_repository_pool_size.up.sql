@@ -0,0 +1,2 @@
+-- Files over 2 GiB overflowed int
+ALTER TABLE `repository_pool` MODIFY COLUMN `size` bigint DEFAULT NULL COMMENT '文件大小';
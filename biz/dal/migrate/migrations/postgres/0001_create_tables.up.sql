@@ -0,0 +1,98 @@
+CREATE TABLE IF NOT EXISTS "repository_pool"
+(
+    "id"         serial NOT NULL,
+    "identity"   varchar(36)  DEFAULT NULL,
+    "hash"       varchar(32)  DEFAULT NULL,
+    "name"       varchar(255) DEFAULT NULL,
+    "ext"        varchar(30)  DEFAULT NULL,
+    "size"       integer DEFAULT NULL,
+    "path"       varchar(255) DEFAULT NULL,
+    "created_at" timestamptz  DEFAULT NULL,
+    "updated_at" timestamptz  DEFAULT NULL,
+    "deleted_at" timestamptz  DEFAULT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "share_basic"
+(
+    "id"                       serial NOT NULL,
+    "identity"                 varchar(36) DEFAULT NULL,
+    "user_identity"            varchar(36) DEFAULT NULL,
+    "repository_identity"      varchar(36) DEFAULT NULL,
+    "user_repository_identity" varchar(36) DEFAULT NULL,
+    "expired_time"             integer DEFAULT NULL,
+    "click_num"                integer DEFAULT 0,
+    "created_at"               timestamptz DEFAULT NULL,
+    "updated_at"               timestamptz DEFAULT NULL,
+    "deleted_at"               timestamptz DEFAULT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "user_access_key"
+(
+    "id"                serial NOT NULL,
+    "identity"          varchar(36) DEFAULT NULL,
+    "user_identity"     varchar(36) DEFAULT NULL,
+    "access_key_id"     varchar(20) DEFAULT NULL,
+    "secret_access_key" varchar(40) DEFAULT NULL,
+    "created_at"        timestamptz DEFAULT NULL,
+    "updated_at"        timestamptz DEFAULT NULL,
+    "deleted_at"        timestamptz DEFAULT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "user_basic"
+(
+    "id"         serial NOT NULL,
+    "identity"   varchar(36)  DEFAULT NULL,
+    "name"       varchar(60)  DEFAULT NULL,
+    "password"   varchar(32)  DEFAULT NULL,
+    "email"      varchar(100) DEFAULT NULL,
+    "created_at" timestamptz  DEFAULT NULL,
+    "updated_at" timestamptz  DEFAULT NULL,
+    "deleted_at" timestamptz  DEFAULT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "user_repository"
+(
+    "id"                  serial NOT NULL,
+    "identity"            varchar(36)  DEFAULT NULL,
+    "user_identity"       varchar(36)  DEFAULT NULL,
+    "parent_id"           integer DEFAULT NULL,
+    "repository_identity" varchar(36)  DEFAULT NULL,
+    "ext"                 varchar(255) DEFAULT NULL,
+    "name"                varchar(255) DEFAULT NULL,
+    "created_at"          timestamptz  DEFAULT NULL,
+    "updated_at"          timestamptz  DEFAULT NULL,
+    "deleted_at"          timestamptz  DEFAULT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "user_repository_property"
+(
+    "id"                       serial NOT NULL,
+    "user_repository_identity" varchar(36)  DEFAULT NULL,
+    "space"                    varchar(255) DEFAULT NULL,
+    "name"                     varchar(255) DEFAULT NULL,
+    "lang"                     varchar(35)  DEFAULT NULL,
+    "inner_xml"                text,
+    "created_at"               timestamptz  DEFAULT NULL,
+    "updated_at"               timestamptz  DEFAULT NULL,
+    "deleted_at"               timestamptz  DEFAULT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "user_ssh_key"
+(
+    "id"            serial NOT NULL,
+    "identity"      varchar(36) DEFAULT NULL,
+    "user_identity" varchar(36) DEFAULT NULL,
+    "name"          varchar(60) DEFAULT NULL,
+    "public_key"    text,
+    "fingerprint"   varchar(64) DEFAULT NULL,
+    "created_at"    timestamptz DEFAULT NULL,
+    "updated_at"    timestamptz DEFAULT NULL,
+    "deleted_at"    timestamptz DEFAULT NULL,
+    PRIMARY KEY ("id")
+);
@@ -0,0 +1,15 @@
+-- Names must be unique within a folder. Rename the live duplicates that
+-- slipped in before, keeping the oldest entry as is.
+UPDATE "user_repository"
+SET "name" = "name" || ' (' || "id" || ')'
+WHERE "deleted_at" IS NULL
+  AND EXISTS (SELECT 1
+              FROM "user_repository" "o"
+              WHERE "o"."user_identity" = "user_repository"."user_identity"
+                AND "o"."parent_id" = "user_repository"."parent_id"
+                AND "o"."name" = "user_repository"."name"
+                AND "o"."deleted_at" IS NULL
+                AND "o"."id" < "user_repository"."id");
+
+-- Deleted entries are kept, so only live ones take part
+CREATE UNIQUE INDEX "uk_user_repository_user_identity_parent_id_name" ON "user_repository" ("user_identity", "parent_id", "name") WHERE "deleted_at" IS NULL;
@@ -0,0 +1,33 @@
+CREATE TABLE "job"
+(
+    "id"           serial       NOT NULL,
+    "type"         varchar(64)  NOT NULL,
+    "payload"      text,
+    "dedup_key"    varchar(255) NOT NULL DEFAULT '',
+    "state"        varchar(16)  NOT NULL,
+    "attempts"     integer      NOT NULL DEFAULT 0,
+    "max_attempts" integer      NOT NULL DEFAULT 0,
+    "run_at"       timestamptz  NOT NULL,
+    "locked_by"    varchar(64)  NOT NULL DEFAULT '',
+    "locked_until" timestamptz  DEFAULT NULL,
+    "last_error"   text,
+    "finished_at"  timestamptz  DEFAULT NULL,
+    "created_at"   timestamptz  DEFAULT NULL,
+    "updated_at"   timestamptz  DEFAULT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX "idx_job_state_run_at" ON "job" ("state", "run_at");
+CREATE INDEX "idx_job_type_dedup_key" ON "job" ("type", "dedup_key");
+
+CREATE TABLE "job_schedule"
+(
+    "id"          serial      NOT NULL,
+    "name"        varchar(64) NOT NULL,
+    "next_run_at" timestamptz NOT NULL,
+    "created_at"  timestamptz DEFAULT NULL,
+    "updated_at"  timestamptz DEFAULT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX "uk_job_schedule_name" ON "job_schedule" ("name");
@@ -0,0 +1,2 @@
+ALTER TABLE "user_basic" DROP COLUMN "quota";
+ALTER TABLE "user_basic" DROP COLUMN "disabled";
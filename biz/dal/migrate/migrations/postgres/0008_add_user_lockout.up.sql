@@ -0,0 +1,2 @@
+ALTER TABLE "user_basic" ADD COLUMN "failed_logins" integer NOT NULL DEFAULT 0;
+ALTER TABLE "user_basic" ADD COLUMN "locked_until" timestamptz DEFAULT NULL;
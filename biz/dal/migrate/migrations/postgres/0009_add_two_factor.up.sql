@@ -0,0 +1,15 @@
+ALTER TABLE "user_basic" ADD COLUMN "totp_secret" varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE "user_basic" ADD COLUMN "totp_enabled" boolean NOT NULL DEFAULT false;
+ALTER TABLE "user_basic" ADD COLUMN "totp_last_step" bigint NOT NULL DEFAULT 0;
+
+CREATE TABLE "user_recovery_code"
+(
+    "id"            serial      NOT NULL,
+    "user_identity" varchar(36) NOT NULL,
+    "code_hash"     varchar(64) NOT NULL,
+    "used_at"       timestamptz DEFAULT NULL,
+    "created_at"    timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX "idx_user_recovery_code_user_identity" ON "user_recovery_code" ("user_identity");
@@ -0,0 +1,20 @@
+CREATE TABLE "user_api_token"
+(
+    "id"            serial       NOT NULL,
+    "identity"      varchar(36)  NOT NULL,
+    "user_identity" varchar(36)  NOT NULL,
+    "name"          varchar(60)  NOT NULL,
+    "token_hash"    varchar(64)  NOT NULL,
+    "token_prefix"  varchar(16)  NOT NULL,
+    "scopes"        varchar(255) NOT NULL,
+    "expires_at"    timestamptz  DEFAULT NULL,
+    "last_used_at"  timestamptz  DEFAULT NULL,
+    "last_used_ip"  varchar(45)  NOT NULL DEFAULT '',
+    "revoked_at"    timestamptz  DEFAULT NULL,
+    "created_at"    timestamptz  NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX "uk_user_api_token_identity" ON "user_api_token" ("identity");
+CREATE UNIQUE INDEX "uk_user_api_token_token_hash" ON "user_api_token" ("token_hash");
+CREATE INDEX "idx_user_api_token_user_identity" ON "user_api_token" ("user_identity");
@@ -0,0 +1,16 @@
+CREATE TABLE "user_session"
+(
+    "id"            serial       NOT NULL,
+    "identity"      varchar(36)  NOT NULL,
+    "user_identity" varchar(36)  NOT NULL,
+    "device_name"   varchar(60)  NOT NULL DEFAULT '',
+    "user_agent"    varchar(255) NOT NULL DEFAULT '',
+    "ip"            varchar(45)  NOT NULL DEFAULT '',
+    "last_seen_at"  timestamptz  NOT NULL,
+    "expires_at"    timestamptz  NOT NULL,
+    "created_at"    timestamptz  NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX "uk_user_session_identity" ON "user_session" ("identity");
+CREATE INDEX "idx_user_session_user_identity" ON "user_session" ("user_identity");
@@ -0,0 +1 @@
+ALTER TABLE "repository_pool" ALTER COLUMN "size" TYPE integer;
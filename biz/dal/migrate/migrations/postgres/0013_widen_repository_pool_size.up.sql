@@ -0,0 +1,2 @@
+-- Files over 2 GiB overflowed integer
+ALTER TABLE "repository_pool" ALTER COLUMN "size" TYPE bigint;
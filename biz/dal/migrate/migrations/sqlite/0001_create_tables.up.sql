@@ -0,0 +1,91 @@
+CREATE TABLE IF NOT EXISTS "repository_pool"
+(
+    "id"         integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+    "identity"   varchar(36)  DEFAULT NULL,
+    "hash"       varchar(32)  DEFAULT NULL,
+    "name"       varchar(255) DEFAULT NULL,
+    "ext"        varchar(30)  DEFAULT NULL,
+    "size"       integer DEFAULT NULL,
+    "path"       varchar(255) DEFAULT NULL,
+    "created_at" datetime     DEFAULT NULL,
+    "updated_at" datetime     DEFAULT NULL,
+    "deleted_at" datetime     DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "share_basic"
+(
+    "id"                       integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+    "identity"                 varchar(36) DEFAULT NULL,
+    "user_identity"            varchar(36) DEFAULT NULL,
+    "repository_identity"      varchar(36) DEFAULT NULL,
+    "user_repository_identity" varchar(36) DEFAULT NULL,
+    "expired_time"             integer DEFAULT NULL,
+    "click_num"                integer DEFAULT 0,
+    "created_at"               datetime    DEFAULT NULL,
+    "updated_at"               datetime    DEFAULT NULL,
+    "deleted_at"               datetime    DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "user_access_key"
+(
+    "id"                integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+    "identity"          varchar(36) DEFAULT NULL,
+    "user_identity"     varchar(36) DEFAULT NULL,
+    "access_key_id"     varchar(20) DEFAULT NULL,
+    "secret_access_key" varchar(40) DEFAULT NULL,
+    "created_at"        datetime    DEFAULT NULL,
+    "updated_at"        datetime    DEFAULT NULL,
+    "deleted_at"        datetime    DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "user_basic"
+(
+    "id"         integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+    "identity"   varchar(36)  DEFAULT NULL,
+    "name"       varchar(60)  DEFAULT NULL,
+    "password"   varchar(32)  DEFAULT NULL,
+    "email"      varchar(100) DEFAULT NULL,
+    "created_at" datetime     DEFAULT NULL,
+    "updated_at" datetime     DEFAULT NULL,
+    "deleted_at" datetime     DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "user_repository"
+(
+    "id"                  integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+    "identity"            varchar(36)  DEFAULT NULL,
+    "user_identity"       varchar(36)  DEFAULT NULL,
+    "parent_id"           integer DEFAULT NULL,
+    "repository_identity" varchar(36)  DEFAULT NULL,
+    "ext"                 varchar(255) DEFAULT NULL,
+    "name"                varchar(255) DEFAULT NULL,
+    "created_at"          datetime     DEFAULT NULL,
+    "updated_at"          datetime     DEFAULT NULL,
+    "deleted_at"          datetime     DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "user_repository_property"
+(
+    "id"                       integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+    "user_repository_identity" varchar(36)  DEFAULT NULL,
+    "space"                    varchar(255) DEFAULT NULL,
+    "name"                     varchar(255) DEFAULT NULL,
+    "lang"                     varchar(35)  DEFAULT NULL,
+    "inner_xml"                text,
+    "created_at"               datetime     DEFAULT NULL,
+    "updated_at"               datetime     DEFAULT NULL,
+    "deleted_at"               datetime     DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "user_ssh_key"
+(
+    "id"            integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+    "identity"      varchar(36) DEFAULT NULL,
+    "user_identity" varchar(36) DEFAULT NULL,
+    "name"          varchar(60) DEFAULT NULL,
+    "public_key"    text,
+    "fingerprint"   varchar(64) DEFAULT NULL,
+    "created_at"    datetime    DEFAULT NULL,
+    "updated_at"    datetime    DEFAULT NULL,
+    "deleted_at"    datetime    DEFAULT NULL
+);
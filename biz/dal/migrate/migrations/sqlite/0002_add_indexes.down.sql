@@ -0,0 +1,16 @@
+DROP INDEX "idx_user_ssh_key_fingerprint";
+DROP INDEX "idx_user_ssh_key_user_identity";
+DROP INDEX "uk_user_ssh_key_identity";
+DROP INDEX "idx_user_repository_property_user_repository_identity";
+DROP INDEX "idx_user_repository_repository_identity";
+DROP INDEX "idx_user_repository_user_identity_parent_id_name";
+DROP INDEX "uk_user_repository_identity";
+DROP INDEX "idx_user_basic_name";
+DROP INDEX "uk_user_basic_identity";
+DROP INDEX "idx_user_access_key_user_identity";
+DROP INDEX "uk_user_access_key_access_key_id";
+DROP INDEX "uk_user_access_key_identity";
+DROP INDEX "idx_share_basic_user_identity";
+DROP INDEX "uk_share_basic_identity";
+DROP INDEX "uk_repository_pool_hash";
+DROP INDEX "uk_repository_pool_identity";
@@ -0,0 +1,16 @@
+CREATE UNIQUE INDEX "uk_repository_pool_identity" ON "repository_pool" ("identity");
+CREATE UNIQUE INDEX "uk_repository_pool_hash" ON "repository_pool" ("hash");
+CREATE UNIQUE INDEX "uk_share_basic_identity" ON "share_basic" ("identity");
+CREATE INDEX "idx_share_basic_user_identity" ON "share_basic" ("user_identity");
+CREATE UNIQUE INDEX "uk_user_access_key_identity" ON "user_access_key" ("identity");
+CREATE UNIQUE INDEX "uk_user_access_key_access_key_id" ON "user_access_key" ("access_key_id");
+CREATE INDEX "idx_user_access_key_user_identity" ON "user_access_key" ("user_identity");
+CREATE UNIQUE INDEX "uk_user_basic_identity" ON "user_basic" ("identity");
+CREATE INDEX "idx_user_basic_name" ON "user_basic" ("name");
+CREATE UNIQUE INDEX "uk_user_repository_identity" ON "user_repository" ("identity");
+CREATE INDEX "idx_user_repository_user_identity_parent_id_name" ON "user_repository" ("user_identity", "parent_id", "name");
+CREATE INDEX "idx_user_repository_repository_identity" ON "user_repository" ("repository_identity");
+CREATE INDEX "idx_user_repository_property_user_repository_identity" ON "user_repository_property" ("user_repository_identity");
+CREATE UNIQUE INDEX "uk_user_ssh_key_identity" ON "user_ssh_key" ("identity");
+CREATE INDEX "idx_user_ssh_key_user_identity" ON "user_ssh_key" ("user_identity");
+CREATE INDEX "idx_user_ssh_key_fingerprint" ON "user_ssh_key" ("fingerprint");
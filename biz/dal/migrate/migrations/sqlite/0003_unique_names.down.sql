@@ -0,0 +1 @@
+DROP INDEX "uk_user_repository_user_identity_parent_id_name";
@@ -0,0 +1,31 @@
+CREATE TABLE "job"
+(
+    "id"           integer      NOT NULL PRIMARY KEY AUTOINCREMENT,
+    "type"         varchar(64)  NOT NULL,
+    "payload"      text,
+    "dedup_key"    varchar(255) NOT NULL DEFAULT '',
+    "state"        varchar(16)  NOT NULL,
+    "attempts"     integer      NOT NULL DEFAULT 0,
+    "max_attempts" integer      NOT NULL DEFAULT 0,
+    "run_at"       datetime     NOT NULL,
+    "locked_by"    varchar(64)  NOT NULL DEFAULT '',
+    "locked_until" datetime     DEFAULT NULL,
+    "last_error"   text,
+    "finished_at"  datetime     DEFAULT NULL,
+    "created_at"   datetime     DEFAULT NULL,
+    "updated_at"   datetime     DEFAULT NULL
+);
+
+CREATE INDEX "idx_job_state_run_at" ON "job" ("state", "run_at");
+CREATE INDEX "idx_job_type_dedup_key" ON "job" ("type", "dedup_key");
+
+CREATE TABLE "job_schedule"
+(
+    "id"          integer     NOT NULL PRIMARY KEY AUTOINCREMENT,
+    "name"        varchar(64) NOT NULL,
+    "next_run_at" datetime    NOT NULL,
+    "created_at"  datetime    DEFAULT NULL,
+    "updated_at"  datetime    DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX "uk_job_schedule_name" ON "job_schedule" ("name");
@@ -0,0 +1 @@
+ALTER TABLE "user_basic" ADD COLUMN "admin" boolean NOT NULL DEFAULT 0;
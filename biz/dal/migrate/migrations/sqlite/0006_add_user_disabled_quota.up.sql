@@ -0,0 +1,2 @@
+ALTER TABLE "user_basic" ADD COLUMN "disabled" boolean NOT NULL DEFAULT 0;
+ALTER TABLE "user_basic" ADD COLUMN "quota" bigint NOT NULL DEFAULT 0;
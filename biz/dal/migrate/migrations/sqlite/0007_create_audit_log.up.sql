@@ -0,0 +1,17 @@
+CREATE TABLE "audit_log"
+(
+    "id"         integer      NOT NULL PRIMARY KEY AUTOINCREMENT,
+    "action"     varchar(64)  NOT NULL,
+    "actor"      varchar(36)  NOT NULL DEFAULT '',
+    "target"     varchar(255) NOT NULL DEFAULT '',
+    "old_value"  text,
+    "new_value"  text,
+    "ip"         varchar(64)  NOT NULL DEFAULT '',
+    "user_agent" varchar(255) NOT NULL DEFAULT '',
+    "created_at" datetime     NOT NULL
+);
+
+CREATE INDEX "idx_audit_log_created_at" ON "audit_log" ("created_at");
+CREATE INDEX "idx_audit_log_actor_created_at" ON "audit_log" ("actor", "created_at");
+CREATE INDEX "idx_audit_log_target_created_at" ON "audit_log" ("target", "created_at");
+CREATE INDEX "idx_audit_log_action_created_at" ON "audit_log" ("action", "created_at");
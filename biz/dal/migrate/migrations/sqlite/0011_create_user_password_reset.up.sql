@@ -0,0 +1,15 @@
+ALTER TABLE "user_basic" ADD COLUMN "password_changed_at" datetime DEFAULT NULL;
+
+CREATE TABLE "user_password_reset"
+(
+    "id"            integer     NOT NULL PRIMARY KEY AUTOINCREMENT,
+    "user_identity" varchar(36) NOT NULL,
+    "token_hash"    varchar(64) NOT NULL,
+    "expires_at"    datetime    NOT NULL,
+    "used_at"       datetime    DEFAULT NULL,
+    "created_at"    datetime    NOT NULL
+);
+
+CREATE UNIQUE INDEX "uk_user_password_reset_token_hash" ON "user_password_reset" ("token_hash");
+CREATE INDEX "idx_user_password_reset_user_identity" ON "user_password_reset" ("user_identity");
+CREATE INDEX "idx_user_basic_email" ON "user_basic" ("email");
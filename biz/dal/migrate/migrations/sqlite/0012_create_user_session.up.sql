@@ -0,0 +1,15 @@
+CREATE TABLE "user_session"
+(
+    "id"            integer      NOT NULL PRIMARY KEY AUTOINCREMENT,
+    "identity"      varchar(36)  NOT NULL,
+    "user_identity" varchar(36)  NOT NULL,
+    "device_name"   varchar(60)  NOT NULL DEFAULT '',
+    "user_agent"    varchar(255) NOT NULL DEFAULT '',
+    "ip"            varchar(45)  NOT NULL DEFAULT '',
+    "last_seen_at"  datetime     NOT NULL,
+    "expires_at"    datetime     NOT NULL,
+    "created_at"    datetime     NOT NULL
+);
+
+CREATE UNIQUE INDEX "uk_user_session_identity" ON "user_session" ("identity");
+CREATE INDEX "idx_user_session_user_identity" ON "user_session" ("user_identity");
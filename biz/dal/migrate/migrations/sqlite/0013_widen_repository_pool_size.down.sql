@@ -0,0 +1 @@
+-- Nothing was changed, see the up script.
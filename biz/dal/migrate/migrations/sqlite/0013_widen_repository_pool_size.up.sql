@@ -0,0 +1,2 @@
+-- SQLite integers already hold 64 bits, so there is nothing to widen; the
+-- version is kept in step with the other databases.
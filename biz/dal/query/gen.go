@@ -16,49 +16,99 @@ import (
 )
 
 var (
-	Q              = new(Query)
-	RepositoryPool *repositoryPool
-	ShareBasic     *shareBasic
-	UserBasic      *userBasic
-	UserRepository *userRepository
+	Q                      = new(Query)
+	AuditLog               *auditLog
+	Job                    *job
+	JobSchedule            *jobSchedule
+	RepositoryPool         *repositoryPool
+	ShareBasic             *shareBasic
+	UserAPIToken           *userAPIToken
+	UserAccessKey          *userAccessKey
+	UserBasic              *userBasic
+	UserPasswordReset      *userPasswordReset
+	UserRecoveryCode       *userRecoveryCode
+	UserRepository         *userRepository
+	UserRepositoryProperty *userRepositoryProperty
+	UserSSHKey             *userSSHKey
+	UserSession            *userSession
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	*Q = *Use(db, opts...)
+	AuditLog = &Q.AuditLog
+	Job = &Q.Job
+	JobSchedule = &Q.JobSchedule
 	RepositoryPool = &Q.RepositoryPool
 	ShareBasic = &Q.ShareBasic
+	UserAPIToken = &Q.UserAPIToken
+	UserAccessKey = &Q.UserAccessKey
 	UserBasic = &Q.UserBasic
+	UserPasswordReset = &Q.UserPasswordReset
+	UserRecoveryCode = &Q.UserRecoveryCode
 	UserRepository = &Q.UserRepository
+	UserRepositoryProperty = &Q.UserRepositoryProperty
+	UserSSHKey = &Q.UserSSHKey
+	UserSession = &Q.UserSession
 }
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
-		db:             db,
-		RepositoryPool: newRepositoryPool(db, opts...),
-		ShareBasic:     newShareBasic(db, opts...),
-		UserBasic:      newUserBasic(db, opts...),
-		UserRepository: newUserRepository(db, opts...),
+		db:                     db,
+		AuditLog:               newAuditLog(db, opts...),
+		Job:                    newJob(db, opts...),
+		JobSchedule:            newJobSchedule(db, opts...),
+		RepositoryPool:         newRepositoryPool(db, opts...),
+		ShareBasic:             newShareBasic(db, opts...),
+		UserAPIToken:           newUserAPIToken(db, opts...),
+		UserAccessKey:          newUserAccessKey(db, opts...),
+		UserBasic:              newUserBasic(db, opts...),
+		UserPasswordReset:      newUserPasswordReset(db, opts...),
+		UserRecoveryCode:       newUserRecoveryCode(db, opts...),
+		UserRepository:         newUserRepository(db, opts...),
+		UserRepositoryProperty: newUserRepositoryProperty(db, opts...),
+		UserSSHKey:             newUserSSHKey(db, opts...),
+		UserSession:            newUserSession(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
-	RepositoryPool repositoryPool
-	ShareBasic     shareBasic
-	UserBasic      userBasic
-	UserRepository userRepository
+	AuditLog               auditLog
+	Job                    job
+	JobSchedule            jobSchedule
+	RepositoryPool         repositoryPool
+	ShareBasic             shareBasic
+	UserAPIToken           userAPIToken
+	UserAccessKey          userAccessKey
+	UserBasic              userBasic
+	UserPasswordReset      userPasswordReset
+	UserRecoveryCode       userRecoveryCode
+	UserRepository         userRepository
+	UserRepositoryProperty userRepositoryProperty
+	UserSSHKey             userSSHKey
+	UserSession            userSession
 }
 
 func (q *Query) Available() bool { return q.db != nil }
 
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
-		db:             db,
-		RepositoryPool: q.RepositoryPool.clone(db),
-		ShareBasic:     q.ShareBasic.clone(db),
-		UserBasic:      q.UserBasic.clone(db),
-		UserRepository: q.UserRepository.clone(db),
+		db:                     db,
+		AuditLog:               q.AuditLog.clone(db),
+		Job:                    q.Job.clone(db),
+		JobSchedule:            q.JobSchedule.clone(db),
+		RepositoryPool:         q.RepositoryPool.clone(db),
+		ShareBasic:             q.ShareBasic.clone(db),
+		UserAPIToken:           q.UserAPIToken.clone(db),
+		UserAccessKey:          q.UserAccessKey.clone(db),
+		UserBasic:              q.UserBasic.clone(db),
+		UserPasswordReset:      q.UserPasswordReset.clone(db),
+		UserRecoveryCode:       q.UserRecoveryCode.clone(db),
+		UserRepository:         q.UserRepository.clone(db),
+		UserRepositoryProperty: q.UserRepositoryProperty.clone(db),
+		UserSSHKey:             q.UserSSHKey.clone(db),
+		UserSession:            q.UserSession.clone(db),
 	}
 }
 
@@ -72,27 +122,57 @@ func (q *Query) WriteDB() *Query {
 
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
-		db:             db,
-		RepositoryPool: q.RepositoryPool.replaceDB(db),
-		ShareBasic:     q.ShareBasic.replaceDB(db),
-		UserBasic:      q.UserBasic.replaceDB(db),
-		UserRepository: q.UserRepository.replaceDB(db),
+		db:                     db,
+		AuditLog:               q.AuditLog.replaceDB(db),
+		Job:                    q.Job.replaceDB(db),
+		JobSchedule:            q.JobSchedule.replaceDB(db),
+		RepositoryPool:         q.RepositoryPool.replaceDB(db),
+		ShareBasic:             q.ShareBasic.replaceDB(db),
+		UserAPIToken:           q.UserAPIToken.replaceDB(db),
+		UserAccessKey:          q.UserAccessKey.replaceDB(db),
+		UserBasic:              q.UserBasic.replaceDB(db),
+		UserPasswordReset:      q.UserPasswordReset.replaceDB(db),
+		UserRecoveryCode:       q.UserRecoveryCode.replaceDB(db),
+		UserRepository:         q.UserRepository.replaceDB(db),
+		UserRepositoryProperty: q.UserRepositoryProperty.replaceDB(db),
+		UserSSHKey:             q.UserSSHKey.replaceDB(db),
+		UserSession:            q.UserSession.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
-	RepositoryPool IRepositoryPoolDo
-	ShareBasic     IShareBasicDo
-	UserBasic      IUserBasicDo
-	UserRepository IUserRepositoryDo
+	AuditLog               IAuditLogDo
+	Job                    IJobDo
+	JobSchedule            IJobScheduleDo
+	RepositoryPool         IRepositoryPoolDo
+	ShareBasic             IShareBasicDo
+	UserAPIToken           IUserAPITokenDo
+	UserAccessKey          IUserAccessKeyDo
+	UserBasic              IUserBasicDo
+	UserPasswordReset      IUserPasswordResetDo
+	UserRecoveryCode       IUserRecoveryCodeDo
+	UserRepository         IUserRepositoryDo
+	UserRepositoryProperty IUserRepositoryPropertyDo
+	UserSSHKey             IUserSSHKeyDo
+	UserSession            IUserSessionDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
-		RepositoryPool: q.RepositoryPool.WithContext(ctx),
-		ShareBasic:     q.ShareBasic.WithContext(ctx),
-		UserBasic:      q.UserBasic.WithContext(ctx),
-		UserRepository: q.UserRepository.WithContext(ctx),
+		AuditLog:               q.AuditLog.WithContext(ctx),
+		Job:                    q.Job.WithContext(ctx),
+		JobSchedule:            q.JobSchedule.WithContext(ctx),
+		RepositoryPool:         q.RepositoryPool.WithContext(ctx),
+		ShareBasic:             q.ShareBasic.WithContext(ctx),
+		UserAPIToken:           q.UserAPIToken.WithContext(ctx),
+		UserAccessKey:          q.UserAccessKey.WithContext(ctx),
+		UserBasic:              q.UserBasic.WithContext(ctx),
+		UserPasswordReset:      q.UserPasswordReset.WithContext(ctx),
+		UserRecoveryCode:       q.UserRecoveryCode.WithContext(ctx),
+		UserRepository:         q.UserRepository.WithContext(ctx),
+		UserRepositoryProperty: q.UserRepositoryProperty.WithContext(ctx),
+		UserSSHKey:             q.UserSSHKey.WithContext(ctx),
+		UserSession:            q.UserSession.WithContext(ctx),
 	}
 }
 
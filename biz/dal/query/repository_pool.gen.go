@@ -33,7 +33,7 @@ func newRepositoryPool(db *gorm.DB, opts ...gen.DOOption) repositoryPool {
 	_repositoryPool.Hash = field.NewString(tableName, "hash")
 	_repositoryPool.Name = field.NewString(tableName, "name")
 	_repositoryPool.Ext = field.NewString(tableName, "ext")
-	_repositoryPool.Size = field.NewInt32(tableName, "size")
+	_repositoryPool.Size = field.NewInt64(tableName, "size")
 	_repositoryPool.Path = field.NewString(tableName, "path")
 	_repositoryPool.CreatedAt = field.NewTime(tableName, "created_at")
 	_repositoryPool.UpdatedAt = field.NewTime(tableName, "updated_at")
@@ -53,7 +53,7 @@ type repositoryPool struct {
 	Hash      field.String // 文件的唯一标识
 	Name      field.String
 	Ext       field.String // 文件扩展名
-	Size      field.Int32  // 文件大小
+	Size      field.Int64  // 文件大小
 	Path      field.String // 文件路径
 	CreatedAt field.Time
 	UpdatedAt field.Time
@@ -79,7 +79,7 @@ func (r *repositoryPool) updateTableName(table string) *repositoryPool {
 	r.Hash = field.NewString(table, "hash")
 	r.Name = field.NewString(table, "name")
 	r.Ext = field.NewString(table, "ext")
-	r.Size = field.NewInt32(table, "size")
+	r.Size = field.NewInt64(table, "size")
 	r.Path = field.NewString(table, "path")
 	r.CreatedAt = field.NewTime(table, "created_at")
 	r.UpdatedAt = field.NewTime(table, "updated_at")
@@ -36,6 +36,15 @@ func newUserBasic(db *gorm.DB, opts ...gen.DOOption) userBasic {
 	_userBasic.CreatedAt = field.NewTime(tableName, "created_at")
 	_userBasic.UpdatedAt = field.NewTime(tableName, "updated_at")
 	_userBasic.DeletedAt = field.NewField(tableName, "deleted_at")
+	_userBasic.Admin = field.NewBool(tableName, "admin")
+	_userBasic.Disabled = field.NewBool(tableName, "disabled")
+	_userBasic.Quota = field.NewInt64(tableName, "quota")
+	_userBasic.FailedLogins = field.NewInt32(tableName, "failed_logins")
+	_userBasic.LockedUntil = field.NewTime(tableName, "locked_until")
+	_userBasic.TotpSecret = field.NewString(tableName, "totp_secret")
+	_userBasic.TotpEnabled = field.NewBool(tableName, "totp_enabled")
+	_userBasic.TotpLastStep = field.NewInt64(tableName, "totp_last_step")
+	_userBasic.PasswordChangedAt = field.NewTime(tableName, "password_changed_at")
 
 	_userBasic.fillFieldMap()
 
@@ -45,15 +54,24 @@ func newUserBasic(db *gorm.DB, opts ...gen.DOOption) userBasic {
 type userBasic struct {
 	userBasicDo
 
-	ALL       field.Asterisk
-	ID        field.Uint32
-	Identity  field.String
-	Name      field.String
-	Password  field.String
-	Email     field.String
-	CreatedAt field.Time
-	UpdatedAt field.Time
-	DeletedAt field.Field
+	ALL               field.Asterisk
+	ID                field.Uint32
+	Identity          field.String
+	Name              field.String
+	Password          field.String
+	Email             field.String
+	CreatedAt         field.Time
+	UpdatedAt         field.Time
+	DeletedAt         field.Field
+	Admin             field.Bool   // 管理员
+	Disabled          field.Bool   // 禁用后无法登录
+	Quota             field.Int64  // 存储配额，单位字节，【0-默认配额，-1-不限】
+	FailedLogins      field.Int32  // 连续登录失败次数
+	LockedUntil       field.Time   // 锁定截止时间，之前无法用密码登录
+	TotpSecret        field.String // TOTP 密钥，base32 编码，空表示未设置
+	TotpEnabled       field.Bool   // 两步验证已启用，登录需要验证码
+	TotpLastStep      field.Int64  // 最后使用的 TOTP 时间步，防止验证码重放
+	PasswordChangedAt field.Time   // 修改密码时间，之前签发的登录令牌失效
 
 	fieldMap map[string]field.Expr
 }
@@ -78,6 +96,15 @@ func (u *userBasic) updateTableName(table string) *userBasic {
 	u.CreatedAt = field.NewTime(table, "created_at")
 	u.UpdatedAt = field.NewTime(table, "updated_at")
 	u.DeletedAt = field.NewField(table, "deleted_at")
+	u.Admin = field.NewBool(table, "admin")
+	u.Disabled = field.NewBool(table, "disabled")
+	u.Quota = field.NewInt64(table, "quota")
+	u.FailedLogins = field.NewInt32(table, "failed_logins")
+	u.LockedUntil = field.NewTime(table, "locked_until")
+	u.TotpSecret = field.NewString(table, "totp_secret")
+	u.TotpEnabled = field.NewBool(table, "totp_enabled")
+	u.TotpLastStep = field.NewInt64(table, "totp_last_step")
+	u.PasswordChangedAt = field.NewTime(table, "password_changed_at")
 
 	u.fillFieldMap()
 
@@ -94,7 +121,7 @@ func (u *userBasic) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (u *userBasic) fillFieldMap() {
-	u.fieldMap = make(map[string]field.Expr, 8)
+	u.fieldMap = make(map[string]field.Expr, 17)
 	u.fieldMap["id"] = u.ID
 	u.fieldMap["identity"] = u.Identity
 	u.fieldMap["name"] = u.Name
@@ -103,6 +130,15 @@ func (u *userBasic) fillFieldMap() {
 	u.fieldMap["created_at"] = u.CreatedAt
 	u.fieldMap["updated_at"] = u.UpdatedAt
 	u.fieldMap["deleted_at"] = u.DeletedAt
+	u.fieldMap["admin"] = u.Admin
+	u.fieldMap["disabled"] = u.Disabled
+	u.fieldMap["quota"] = u.Quota
+	u.fieldMap["failed_logins"] = u.FailedLogins
+	u.fieldMap["locked_until"] = u.LockedUntil
+	u.fieldMap["totp_secret"] = u.TotpSecret
+	u.fieldMap["totp_enabled"] = u.TotpEnabled
+	u.fieldMap["totp_last_step"] = u.TotpLastStep
+	u.fieldMap["password_changed_at"] = u.PasswordChangedAt
 }
 
 func (u userBasic) clone(db *gorm.DB) userBasic {
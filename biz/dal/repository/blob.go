@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"cloud-storage/biz/blob"
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/dal/query"
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type blobRepository struct {
+	q *query.Query
+}
+
+func (r *blobRepository) FindByIdentity(ctx context.Context, identity string) (*entity.RepositoryPool, error) {
+	rpQ := r.q.RepositoryPool
+	rp, err := rpQ.WithContext(ctx).Where(rpQ.Identity.Eq(identity)).First()
+	return rp, notFound(err)
+}
+
+func (r *blobRepository) FindByIdentities(ctx context.Context, identities []string) ([]*entity.RepositoryPool, error) {
+	rpQ := r.q.RepositoryPool
+	return rpQ.WithContext(ctx).Where(rpQ.Identity.In(identities...)).Find()
+}
+
+func (r *blobRepository) FindByHash(ctx context.Context, hash string) (*entity.RepositoryPool, error) {
+	rpQ := r.q.RepositoryPool
+	// Look on the primary, as the record of content stored a moment ago may
+	// not have reached the replicas yet
+	rp, err := rpQ.WithContext(ctx).WriteDB().Where(rpQ.Hash.Eq(hash)).First()
+	switch {
+	case err == nil:
+		blob.DedupLookups.WithLabelValues("hit").Inc()
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		blob.DedupLookups.WithLabelValues("miss").Inc()
+	}
+	return rp, notFound(err)
+}
+
+func (r *blobRepository) Touch(ctx context.Context, identity string) error {
+	rpQ := r.q.RepositoryPool
+	_, err := rpQ.WithContext(ctx).Where(rpQ.Identity.Eq(identity)).Update(rpQ.UpdatedAt, time.Now())
+	return err
+}
+
+// contentStore keeps content in the blob directory.
+type contentStore struct{}
+
+func (contentStore) Put(ctx context.Context, name string, r io.Reader) (*entity.RepositoryPool, error) {
+	return blob.Put(ctx, name, r)
+}
+
+func (contentStore) Open(ctx context.Context, rp *entity.RepositoryPool) (io.ReadCloser, error) {
+	f, err := blob.Open(ctx, rp)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
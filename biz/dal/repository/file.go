@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/dal/query"
+	"cloud-storage/biz/dal/resolver"
+	"cloud-storage/biz/service"
+	"context"
+	"database/sql"
+	"strings"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+type fileRepository struct {
+	q *query.Query
+}
+
+func (r *fileRepository) Create(ctx context.Context, ur *entity.UserRepository) error {
+	ctx = resolver.WithUser(ctx, ur.UserIdentity)
+	return duplicate(r.q.UserRepository.WithContext(ctx).Create(ur))
+}
+
+func (r *fileRepository) Find(ctx context.Context, userIdentity, identity string) (*entity.UserRepository, error) {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	urQ := r.q.UserRepository
+	ur, err := urQ.WithContext(ctx).Where(urQ.UserIdentity.Eq(userIdentity), urQ.Identity.Eq(identity)).First()
+	return ur, notFound(err)
+}
+
+func (r *fileRepository) FindFolder(ctx context.Context, userIdentity string, id int32) (*entity.UserRepository, error) {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	urQ := r.q.UserRepository
+	ur, err := urQ.WithContext(ctx).Where(urQ.UserIdentity.Eq(userIdentity), urQ.ID.Eq(uint32(id)), urQ.RepositoryIdentity.Eq("")).First()
+	return ur, notFound(err)
+}
+
+func (r *fileRepository) FindChild(ctx context.Context, userIdentity string, parentID int32, name string) (*entity.UserRepository, error) {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	urQ := r.q.UserRepository
+	ur, err := urQ.WithContext(ctx).Where(urQ.UserIdentity.Eq(userIdentity), urQ.ParentID.Eq(parentID), urQ.Name.Eq(name)).First()
+	return ur, notFound(err)
+}
+
+func (r *fileRepository) ListChildren(ctx context.Context, userIdentity string, parentID int32, offset, limit int) ([]*service.FileRow, int64, error) {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	urQ, rpQ := r.q.UserRepository, r.q.RepositoryPool
+	var rows []*service.FileRow
+	err := urQ.WithContext(ctx).Select(urQ.ID, urQ.Identity, urQ.RepositoryIdentity, urQ.Ext, urQ.Name, rpQ.Path, rpQ.Size).
+		Where(urQ.ParentID.Eq(parentID), urQ.UserIdentity.Eq(userIdentity)).
+		LeftJoin(rpQ, urQ.RepositoryIdentity.EqCol(rpQ.Identity)).
+		Limit(limit).Offset(offset).Scan(&rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	count, err := urQ.WithContext(ctx).Where(urQ.ParentID.Eq(parentID), urQ.UserIdentity.Eq(userIdentity)).Count()
+	if err != nil {
+		return nil, 0, err
+	}
+	return rows, count, nil
+}
+
+func (r *fileRepository) ListByParents(ctx context.Context, userIdentity string, parentIDs []int32) ([]*entity.UserRepository, error) {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	urQ := r.q.UserRepository
+	return urQ.WithContext(ctx).Where(urQ.UserIdentity.Eq(userIdentity), urQ.ParentID.In(parentIDs...)).
+		Order(urQ.Name).Find()
+}
+
+func (r *fileRepository) ListFiles(ctx context.Context, userIdentity string) ([]*service.FileRow, error) {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	urQ, rpQ := r.q.UserRepository, r.q.RepositoryPool
+	var rows []*service.FileRow
+	err := urQ.WithContext(ctx).Select(urQ.Identity, urQ.ParentID, urQ.RepositoryIdentity, urQ.Name, urQ.Ext, rpQ.Size, rpQ.Hash).
+		Join(rpQ, urQ.RepositoryIdentity.EqCol(rpQ.Identity)).
+		Where(urQ.UserIdentity.Eq(userIdentity)).
+		Scan(&rows)
+	return rows, err
+}
+
+func (r *fileRepository) Folders(ctx context.Context, userIdentity string) ([]*entity.UserRepository, error) {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	urQ := r.q.UserRepository
+	return urQ.WithContext(ctx).Select(urQ.ID, urQ.Identity, urQ.ParentID, urQ.Name).
+		Where(urQ.UserIdentity.Eq(userIdentity), urQ.RepositoryIdentity.Eq("")).
+		Find()
+}
+
+func (r *fileRepository) Search(ctx context.Context, userIdentity string, filter *service.FileFilter, offset, limit int) ([]*service.FileRow, int64, error) {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	urQ, rpQ := r.q.UserRepository, r.q.RepositoryPool
+	conds := []gen.Condition{urQ.UserIdentity.Eq(userIdentity)}
+	if filter.Keyword != "" {
+		pattern := escapeLike(filter.Keyword) + "%"
+		if !filter.Prefix {
+			pattern = "%" + pattern
+		}
+		// LIKE is case-sensitive on some databases and has no default escape
+		// character on others
+		conds = append(conds, field.NewUnsafeFieldRaw("LOWER(?) LIKE ? ESCAPE '!'", urQ.Name.RawExpr(), strings.ToLower(pattern)))
+	}
+	if len(filter.Exts) > 0 {
+		conds = append(conds, urQ.Ext.In(filter.Exts...))
+	}
+	if filter.Files {
+		conds = append(conds, urQ.RepositoryIdentity.Neq(""))
+	}
+	if filter.Folders {
+		conds = append(conds, urQ.RepositoryIdentity.Eq(""))
+	}
+	if filter.MinSize > 0 {
+		conds = append(conds, rpQ.Size.Gte(filter.MinSize))
+	}
+	if filter.MaxSize > 0 {
+		conds = append(conds, rpQ.Size.Lte(filter.MaxSize))
+	}
+	if !filter.ModifiedAfter.IsZero() {
+		conds = append(conds, urQ.UpdatedAt.Gte(filter.ModifiedAfter))
+	}
+	if !filter.ModifiedBefore.IsZero() {
+		conds = append(conds, urQ.UpdatedAt.Lte(filter.ModifiedBefore))
+	}
+	if len(filter.ParentIDs) > 0 {
+		conds = append(conds, urQ.ParentID.In(filter.ParentIDs...))
+	}
+
+	var order field.OrderExpr
+	switch filter.Sort {
+	case "size":
+		order = rpQ.Size
+	case "updated_at":
+		order = urQ.UpdatedAt
+	default:
+		order = urQ.Name
+	}
+	sortBy := field.Expr(order)
+	if filter.Desc {
+		sortBy = order.Desc()
+	}
+
+	do := urQ.WithContext(ctx).LeftJoin(rpQ, urQ.RepositoryIdentity.EqCol(rpQ.Identity)).Where(conds...)
+	count, err := do.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+	var rows []*service.FileRow
+	err = do.Select(urQ.Identity, urQ.ParentID, urQ.RepositoryIdentity, urQ.Name, urQ.Ext, urQ.UpdatedAt, rpQ.Size).
+		Order(sortBy, urQ.ID).
+		Limit(limit).Offset(offset).Scan(&rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return rows, count, nil
+}
+
+func (r *fileRepository) UpdateName(ctx context.Context, userIdentity, identity, name string) error {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	urQ := r.q.UserRepository
+	_, err := urQ.WithContext(ctx).Where(urQ.UserIdentity.Eq(userIdentity), urQ.Identity.Eq(identity)).Update(urQ.Name, name)
+	return duplicate(err)
+}
+
+func (r *fileRepository) UpdateParent(ctx context.Context, userIdentity, identity string, parentID int32) error {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	urQ := r.q.UserRepository
+	_, err := urQ.WithContext(ctx).Where(urQ.UserIdentity.Eq(userIdentity), urQ.Identity.Eq(identity)).Update(urQ.ParentID, parentID)
+	return duplicate(err)
+}
+
+func (r *fileRepository) UpdateLocation(ctx context.Context, userIdentity, identity string, parentID int32, name, ext string) error {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	urQ := r.q.UserRepository
+	_, err := urQ.WithContext(ctx).Where(urQ.UserIdentity.Eq(userIdentity), urQ.Identity.Eq(identity)).
+		UpdateSimple(urQ.ParentID.Value(parentID), urQ.Name.Value(name), urQ.Ext.Value(ext))
+	return duplicate(err)
+}
+
+func (r *fileRepository) UpdateContent(ctx context.Context, userIdentity, identity, repositoryIdentity string) error {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	urQ := r.q.UserRepository
+	_, err := urQ.WithContext(ctx).Where(urQ.UserIdentity.Eq(userIdentity), urQ.Identity.Eq(identity)).
+		Update(urQ.RepositoryIdentity, repositoryIdentity)
+	return err
+}
+
+func (r *fileRepository) Delete(ctx context.Context, userIdentity, identity string) error {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	urQ := r.q.UserRepository
+	_, err := urQ.WithContext(ctx).Where(urQ.UserIdentity.Eq(userIdentity), urQ.Identity.Eq(identity)).Delete()
+	return err
+}
+
+func (r *fileRepository) DeleteChildren(ctx context.Context, userIdentity string, parentIDs []int32) (int64, error) {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	urQ := r.q.UserRepository
+	info, err := urQ.WithContext(ctx).Where(urQ.UserIdentity.Eq(userIdentity), urQ.ParentID.In(parentIDs...)).Delete()
+	if err != nil {
+		return 0, err
+	}
+	return info.RowsAffected, nil
+}
+
+func (r *fileRepository) UsedBytes(ctx context.Context, userIdentity string) (int64, error) {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	urQ, rpQ := r.q.UserRepository, r.q.RepositoryPool
+	var used sql.NullInt64
+	err := urQ.WithContext(ctx).Select(rpQ.Size.Sum()).
+		Join(rpQ, urQ.RepositoryIdentity.EqCol(rpQ.Identity)).
+		Where(urQ.UserIdentity.Eq(userIdentity)).
+		Scan(&used)
+	return used.Int64, err
+}
+
+// escapeLike escapes the LIKE wildcards in s with '!' so that it matches
+// literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestEscapeLike(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		keyword, escaped string
+		// name is matched against keyword as a prefix
+		name  string
+		match bool
+	}{
+		{"report", "report", "report.pdf", true},
+		{"50%", "50!%", "50% off.txt", true},
+		{"50%", "50!%", "500.txt", false},
+		{"a_b", "a!_b", "a_b.txt", true},
+		{"a_b", "a!_b", "axb.txt", false},
+		{"wow!", "wow!!", "wow!.txt", true},
+		{"wow!", "wow!!", "wow.txt", false},
+		{"!%_", "!!!%!_", "!%_", true},
+	}
+	for _, tt := range tests {
+		got := escapeLike(tt.keyword)
+		if got != tt.escaped {
+			t.Errorf("escapeLike(%q) = %q, want %q", tt.keyword, got, tt.escaped)
+		}
+		var match bool
+		if err := db.Raw("SELECT ? LIKE ? ESCAPE '!'", tt.name, got+"%").Scan(&match).Error; err != nil {
+			t.Fatal(err)
+		}
+		if match != tt.match {
+			t.Errorf("%q LIKE %q = %v, want %v", tt.name, got+"%", match, tt.match)
+		}
+	}
+}
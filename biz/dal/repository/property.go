@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/dal/query"
+	"cloud-storage/biz/dal/resolver"
+	"context"
+)
+
+type propertyRepository struct {
+	q *query.Query
+}
+
+func (r *propertyRepository) ListByEntry(ctx context.Context, userIdentity, entryIdentity string) ([]*entity.UserRepositoryProperty, error) {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	urpQ, urQ := r.q.UserRepositoryProperty, r.q.UserRepository
+	return urpQ.WithContext(ctx).Select(urpQ.ALL).
+		Join(urQ, urpQ.UserRepositoryIdentity.EqCol(urQ.Identity)).
+		Where(urQ.UserIdentity.Eq(userIdentity), urpQ.UserRepositoryIdentity.Eq(entryIdentity)).
+		Find()
+}
+
+func (r *propertyRepository) Create(ctx context.Context, prop *entity.UserRepositoryProperty) error {
+	return r.q.UserRepositoryProperty.WithContext(ctx).Create(prop)
+}
+
+func (r *propertyRepository) Delete(ctx context.Context, entryIdentity, space, name string) error {
+	urpQ := r.q.UserRepositoryProperty
+	// Deleted for good, as a property set again is a new row
+	_, err := urpQ.WithContext(ctx).Unscoped().
+		Where(urpQ.UserRepositoryIdentity.Eq(entryIdentity), urpQ.Space.Eq(space), urpQ.Name.Eq(name)).
+		Delete()
+	return err
+}
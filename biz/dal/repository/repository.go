@@ -0,0 +1,58 @@
+// Package repository implements the repositories of the service layer on
+// top of the generated queries. Queries on behalf of a user carry the user
+// in their context, so that the user reads their own writes.
+package repository
+
+import (
+	"cloud-storage/biz/dal/query"
+	"cloud-storage/biz/service"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// New returns the repositories backed by q.
+func New(q *query.Query) service.Repositories {
+	return service.Repositories{
+		Users:          &userRepository{q: q},
+		RecoveryCodes:  &recoveryCodeRepository{q: q},
+		PasswordResets: &passwordResetRepository{q: q},
+		Sessions:       &sessionRepository{q: q},
+		AccessKeys:     &accessKeyRepository{q: q},
+		APITokens:      &apiTokenRepository{q: q},
+		SSHKeys:        &sshKeyRepository{q: q},
+		Files:          &fileRepository{q: q},
+		Blobs:          &blobRepository{q: q},
+		Shares:         &shareRepository{q: q},
+		Properties:     &propertyRepository{q: q},
+		Content:        contentStore{},
+		Tx:             transactor{q: q},
+	}
+}
+
+// transactor runs transactions on the database of q.
+type transactor struct {
+	q *query.Query
+}
+
+func (t transactor) Transaction(fn func(r service.Repositories) error) error {
+	return t.q.Transaction(func(tx *query.Query) error {
+		return fn(New(tx))
+	})
+}
+
+// notFound translates gorm's not found error for the service layer.
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return service.ErrRecordNotFound
+	}
+	return err
+}
+
+// duplicate translates gorm's duplicated key error for the service layer.
+func duplicate(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return service.ErrDuplicate
+	}
+	return err
+}
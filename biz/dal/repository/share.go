@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/dal/query"
+	"cloud-storage/biz/dal/resolver"
+	"cloud-storage/biz/service"
+	"context"
+)
+
+type shareRepository struct {
+	q *query.Query
+}
+
+func (r *shareRepository) Create(ctx context.Context, sb *entity.ShareBasic) error {
+	ctx = resolver.WithUser(ctx, sb.UserIdentity)
+	return r.q.ShareBasic.WithContext(ctx).Create(sb)
+}
+
+func (r *shareRepository) IncrementClickNum(ctx context.Context, identity string) error {
+	sbQ := r.q.ShareBasic
+	_, err := sbQ.WithContext(ctx).Where(sbQ.Identity.Eq(identity)).Update(sbQ.ClickNum, sbQ.ClickNum.Add(1))
+	return err
+}
+
+func (r *shareRepository) Detail(ctx context.Context, identity string) (*service.ShareDetail, error) {
+	sbQ, rpQ := r.q.ShareBasic, r.q.RepositoryPool
+	var details []*service.ShareDetail
+	err := sbQ.WithContext(ctx).Select(sbQ.CreatedAt, sbQ.ExpiredTime, sbQ.RepositoryIdentity, rpQ.Name, rpQ.Ext, rpQ.Size, rpQ.Path).
+		LeftJoin(rpQ, sbQ.RepositoryIdentity.EqCol(rpQ.Identity)).
+		Where(sbQ.Identity.Eq(identity)).
+		Limit(1).Scan(&details)
+	if err != nil {
+		return nil, err
+	}
+	if len(details) == 0 {
+		return nil, service.ErrRecordNotFound
+	}
+	return details[0], nil
+}
+
+func (r *shareRepository) Delete(ctx context.Context, userIdentity, identity string) (bool, error) {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	sbQ := r.q.ShareBasic
+	info, err := sbQ.WithContext(ctx).Where(sbQ.UserIdentity.Eq(userIdentity), sbQ.Identity.Eq(identity)).Delete()
+	if err != nil {
+		return false, err
+	}
+	return info.RowsAffected > 0, nil
+}
@@ -0,0 +1,373 @@
+package repository
+
+import (
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/dal/query"
+	"cloud-storage/biz/dal/resolver"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+)
+
+type userRepository struct {
+	q *query.Query
+}
+
+func (r *userRepository) Create(ctx context.Context, u *entity.UserBasic) error {
+	ubQ := r.q.UserBasic
+	return ubQ.WithContext(ctx).Omit(ubQ.LockedUntil, ubQ.PasswordChangedAt).Create(u)
+}
+
+func (r *userRepository) FindByIdentity(ctx context.Context, identity string) (*entity.UserBasic, error) {
+	ubQ := r.q.UserBasic
+	userBasic, err := ubQ.WithContext(ctx).Where(ubQ.Identity.Eq(identity)).First()
+	return userBasic, notFound(err)
+}
+
+func (r *userRepository) FindByName(ctx context.Context, name string) (*entity.UserBasic, error) {
+	ubQ := r.q.UserBasic
+	userBasic, err := ubQ.WithContext(ctx).Where(ubQ.Name.Eq(name)).First()
+	return userBasic, notFound(err)
+}
+
+func (r *userRepository) ListByEmail(ctx context.Context, email string) ([]*entity.UserBasic, error) {
+	ubQ := r.q.UserBasic
+	return ubQ.WithContext(ctx).Where(ubQ.Email.Eq(email)).Order(ubQ.ID).Find()
+}
+
+func (r *userRepository) UpdatePassword(ctx context.Context, identity, passwordMD5 string) error {
+	ubQ := r.q.UserBasic
+	// Tokens record when they were issued in whole seconds, and so does
+	// MySQL, which rounds: a login right after the change must not count as
+	// one before it
+	changedAt := time.Now().Truncate(time.Second)
+	_, err := ubQ.WithContext(ctx).Where(ubQ.Identity.Eq(identity)).
+		UpdateSimple(ubQ.Password.Value(passwordMD5), ubQ.PasswordChangedAt.Value(changedAt))
+	return err
+}
+
+func (r *userRepository) UpdateAdmin(ctx context.Context, identity string, admin bool) error {
+	ubQ := r.q.UserBasic
+	_, err := ubQ.WithContext(ctx).Where(ubQ.Identity.Eq(identity)).Update(ubQ.Admin, admin)
+	return err
+}
+
+func (r *userRepository) UpdateDisabled(ctx context.Context, identity string, disabled bool) error {
+	ubQ := r.q.UserBasic
+	_, err := ubQ.WithContext(ctx).Where(ubQ.Identity.Eq(identity)).Update(ubQ.Disabled, disabled)
+	return err
+}
+
+func (r *userRepository) UpdateQuota(ctx context.Context, identity string, quota int64) error {
+	ubQ := r.q.UserBasic
+	_, err := ubQ.WithContext(ctx).Where(ubQ.Identity.Eq(identity)).Update(ubQ.Quota, quota)
+	return err
+}
+
+func (r *userRepository) IncrementFailedLogins(ctx context.Context, identity string) (int32, error) {
+	ubQ := r.q.UserBasic
+	_, err := ubQ.WithContext(ctx).Where(ubQ.Identity.Eq(identity)).Update(ubQ.FailedLogins, ubQ.FailedLogins.Add(1))
+	if err != nil {
+		return 0, err
+	}
+	userBasic, err := ubQ.WithContext(ctx).Select(ubQ.FailedLogins).Where(ubQ.Identity.Eq(identity)).First()
+	if err != nil {
+		return 0, notFound(err)
+	}
+	return userBasic.FailedLogins, nil
+}
+
+func (r *userRepository) Lock(ctx context.Context, identity string, until time.Time) error {
+	ubQ := r.q.UserBasic
+	_, err := ubQ.WithContext(ctx).Where(ubQ.Identity.Eq(identity)).UpdateSimple(ubQ.LockedUntil.Value(until), ubQ.FailedLogins.Zero())
+	return err
+}
+
+func (r *userRepository) ResetFailedLogins(ctx context.Context, identity string) error {
+	ubQ := r.q.UserBasic
+	_, err := ubQ.WithContext(ctx).Where(ubQ.Identity.Eq(identity), ubQ.FailedLogins.Neq(0)).Update(ubQ.FailedLogins, 0)
+	return err
+}
+
+func (r *userRepository) UpdateTOTP(ctx context.Context, identity, secret string, enabled bool) error {
+	ubQ := r.q.UserBasic
+	_, err := ubQ.WithContext(ctx).Where(ubQ.Identity.Eq(identity)).UpdateSimple(ubQ.TotpSecret.Value(secret), ubQ.TotpEnabled.Value(enabled))
+	return err
+}
+
+func (r *userRepository) UseTOTPStep(ctx context.Context, identity string, step int64) (bool, error) {
+	ubQ := r.q.UserBasic
+	info, err := ubQ.WithContext(ctx).Where(ubQ.Identity.Eq(identity), ubQ.TotpLastStep.Lt(step)).Update(ubQ.TotpLastStep, step)
+	if err != nil {
+		return false, err
+	}
+	return info.RowsAffected > 0, nil
+}
+
+type recoveryCodeRepository struct {
+	q *query.Query
+}
+
+func (r *recoveryCodeRepository) Replace(ctx context.Context, userIdentity string, codeHashes []string) error {
+	urcQ := r.q.UserRecoveryCode
+	if _, err := urcQ.WithContext(ctx).Where(urcQ.UserIdentity.Eq(userIdentity)).Delete(); err != nil {
+		return err
+	}
+	now := time.Now()
+	codes := make([]*entity.UserRecoveryCode, len(codeHashes))
+	for i, hash := range codeHashes {
+		codes[i] = &entity.UserRecoveryCode{UserIdentity: userIdentity, CodeHash: hash, CreatedAt: now}
+	}
+	return urcQ.WithContext(ctx).Omit(urcQ.UsedAt).Create(codes...)
+}
+
+func (r *recoveryCodeRepository) Use(ctx context.Context, userIdentity, codeHash string) (bool, error) {
+	urcQ := r.q.UserRecoveryCode
+	info, err := urcQ.WithContext(ctx).
+		Where(urcQ.UserIdentity.Eq(userIdentity), urcQ.CodeHash.Eq(codeHash), urcQ.UsedAt.IsNull()).
+		Update(urcQ.UsedAt, time.Now())
+	if err != nil {
+		return false, err
+	}
+	return info.RowsAffected > 0, nil
+}
+
+func (r *recoveryCodeRepository) DeleteByUser(ctx context.Context, userIdentity string) error {
+	urcQ := r.q.UserRecoveryCode
+	_, err := urcQ.WithContext(ctx).Where(urcQ.UserIdentity.Eq(userIdentity)).Delete()
+	return err
+}
+
+type passwordResetRepository struct {
+	q *query.Query
+}
+
+func (r *passwordResetRepository) Create(ctx context.Context, reset *entity.UserPasswordReset) error {
+	uprQ := r.q.UserPasswordReset
+	return uprQ.WithContext(ctx).Omit(uprQ.UsedAt).Create(reset)
+}
+
+func (r *passwordResetRepository) FindByHash(ctx context.Context, tokenHash string) (*entity.UserPasswordReset, error) {
+	uprQ := r.q.UserPasswordReset
+	reset, err := uprQ.WithContext(ctx).Where(uprQ.TokenHash.Eq(tokenHash)).First()
+	return reset, notFound(err)
+}
+
+func (r *passwordResetRepository) Use(ctx context.Context, id uint32) (bool, error) {
+	uprQ := r.q.UserPasswordReset
+	info, err := uprQ.WithContext(ctx).Where(uprQ.ID.Eq(id), uprQ.UsedAt.IsNull()).Update(uprQ.UsedAt, time.Now())
+	if err != nil {
+		return false, err
+	}
+	return info.RowsAffected > 0, nil
+}
+
+func (r *passwordResetRepository) DeleteByUser(ctx context.Context, userIdentity string) error {
+	uprQ := r.q.UserPasswordReset
+	_, err := uprQ.WithContext(ctx).Where(uprQ.UserIdentity.Eq(userIdentity)).Delete()
+	return err
+}
+
+type sessionRepository struct {
+	q *query.Query
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *entity.UserSession) error {
+	ctx = resolver.WithUser(ctx, session.UserIdentity)
+	return r.q.UserSession.WithContext(ctx).Create(session)
+}
+
+func (r *sessionRepository) Find(ctx context.Context, userIdentity, identity string) (*entity.UserSession, error) {
+	// Every authenticated request comes here, so read from a replica unless
+	// the user just wrote, e.g. signed a session out, through this instance.
+	// A session started through another instance may not have reached the
+	// replica yet: only then look on the primary.
+	ctx = resolver.WithUser(ctx, userIdentity)
+	usQ := r.q.UserSession
+	session, err := usQ.WithContext(ctx).Where(usQ.UserIdentity.Eq(userIdentity), usQ.Identity.Eq(identity)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		session, err = usQ.WithContext(ctx).WriteDB().Where(usQ.UserIdentity.Eq(userIdentity), usQ.Identity.Eq(identity)).First()
+	}
+	return session, notFound(err)
+}
+
+func (r *sessionRepository) ListByUser(ctx context.Context, userIdentity string, now time.Time) ([]*entity.UserSession, error) {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	usQ := r.q.UserSession
+	return usQ.WithContext(ctx).Where(usQ.UserIdentity.Eq(userIdentity), usQ.ExpiresAt.Gt(now)).Order(usQ.LastSeenAt.Desc(), usQ.ID.Desc()).Find()
+}
+
+func (r *sessionRepository) Touch(ctx context.Context, identity, ip string, at time.Time) error {
+	usQ := r.q.UserSession
+	_, err := usQ.WithContext(ctx).Where(usQ.Identity.Eq(identity)).UpdateSimple(usQ.LastSeenAt.Value(at), usQ.IP.Value(ip))
+	return err
+}
+
+func (r *sessionRepository) Extend(ctx context.Context, identity string, expiresAt time.Time) error {
+	usQ := r.q.UserSession
+	_, err := usQ.WithContext(ctx).Where(usQ.Identity.Eq(identity)).Update(usQ.ExpiresAt, expiresAt)
+	return err
+}
+
+func (r *sessionRepository) Delete(ctx context.Context, userIdentity, identity string) (bool, error) {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	usQ := r.q.UserSession
+	info, err := usQ.WithContext(ctx).Where(usQ.UserIdentity.Eq(userIdentity), usQ.Identity.Eq(identity)).Delete()
+	if err != nil {
+		return false, err
+	}
+	return info.RowsAffected > 0, nil
+}
+
+func (r *sessionRepository) DeleteOthers(ctx context.Context, userIdentity, keep string) (int64, error) {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	usQ := r.q.UserSession
+	info, err := usQ.WithContext(ctx).Where(usQ.UserIdentity.Eq(userIdentity), usQ.Identity.Neq(keep)).Delete()
+	if err != nil {
+		return 0, err
+	}
+	return info.RowsAffected, nil
+}
+
+func (r *sessionRepository) DeleteByUser(ctx context.Context, userIdentity string) error {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	usQ := r.q.UserSession
+	_, err := usQ.WithContext(ctx).Where(usQ.UserIdentity.Eq(userIdentity)).Delete()
+	return err
+}
+
+func (r *sessionRepository) DeleteExpired(ctx context.Context, userIdentity string, now time.Time) error {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	usQ := r.q.UserSession
+	_, err := usQ.WithContext(ctx).Where(usQ.UserIdentity.Eq(userIdentity), usQ.ExpiresAt.Lte(now)).Delete()
+	return err
+}
+
+type accessKeyRepository struct {
+	q *query.Query
+}
+
+func (r *accessKeyRepository) Create(ctx context.Context, key *entity.UserAccessKey) error {
+	ctx = resolver.WithUser(ctx, key.UserIdentity)
+	return r.q.UserAccessKey.WithContext(ctx).Create(key)
+}
+
+func (r *accessKeyRepository) FindByID(ctx context.Context, accessKeyID string) (*entity.UserAccessKey, error) {
+	uakQ := r.q.UserAccessKey
+	key, err := uakQ.WithContext(ctx).Where(uakQ.AccessKeyID.Eq(accessKeyID)).First()
+	return key, notFound(err)
+}
+
+func (r *accessKeyRepository) ListByUser(ctx context.Context, userIdentity string) ([]*entity.UserAccessKey, error) {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	uakQ := r.q.UserAccessKey
+	return uakQ.WithContext(ctx).Where(uakQ.UserIdentity.Eq(userIdentity)).Order(uakQ.ID).Find()
+}
+
+func (r *accessKeyRepository) Delete(ctx context.Context, userIdentity, accessKeyID string) error {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	uakQ := r.q.UserAccessKey
+	_, err := uakQ.WithContext(ctx).Where(uakQ.UserIdentity.Eq(userIdentity), uakQ.AccessKeyID.Eq(accessKeyID)).Delete()
+	return err
+}
+
+func (r *accessKeyRepository) DeleteByUser(ctx context.Context, userIdentity string) error {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	uakQ := r.q.UserAccessKey
+	_, err := uakQ.WithContext(ctx).Where(uakQ.UserIdentity.Eq(userIdentity)).Delete()
+	return err
+}
+
+type apiTokenRepository struct {
+	q *query.Query
+}
+
+func (r *apiTokenRepository) Create(ctx context.Context, token *entity.UserAPIToken) error {
+	ctx = resolver.WithUser(ctx, token.UserIdentity)
+	uatQ := r.q.UserAPIToken
+	omit := []field.Expr{uatQ.LastUsedAt, uatQ.RevokedAt}
+	if token.ExpiresAt.IsZero() {
+		omit = append(omit, uatQ.ExpiresAt)
+	}
+	return uatQ.WithContext(ctx).Omit(omit...).Create(token)
+}
+
+func (r *apiTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*entity.UserAPIToken, error) {
+	uatQ := r.q.UserAPIToken
+	token, err := uatQ.WithContext(ctx).Where(uatQ.TokenHash.Eq(tokenHash)).First()
+	return token, notFound(err)
+}
+
+func (r *apiTokenRepository) ListByUser(ctx context.Context, userIdentity string) ([]*entity.UserAPIToken, error) {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	uatQ := r.q.UserAPIToken
+	return uatQ.WithContext(ctx).Where(uatQ.UserIdentity.Eq(userIdentity), uatQ.RevokedAt.IsNull()).Order(uatQ.ID).Find()
+}
+
+func (r *apiTokenRepository) Revoke(ctx context.Context, userIdentity, identity string) (bool, error) {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	uatQ := r.q.UserAPIToken
+	info, err := uatQ.WithContext(ctx).
+		Where(uatQ.UserIdentity.Eq(userIdentity), uatQ.Identity.Eq(identity), uatQ.RevokedAt.IsNull()).
+		Update(uatQ.RevokedAt, time.Now())
+	if err != nil {
+		return false, err
+	}
+	return info.RowsAffected > 0, nil
+}
+
+func (r *apiTokenRepository) RevokeByUser(ctx context.Context, userIdentity string) error {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	uatQ := r.q.UserAPIToken
+	_, err := uatQ.WithContext(ctx).Where(uatQ.UserIdentity.Eq(userIdentity), uatQ.RevokedAt.IsNull()).Update(uatQ.RevokedAt, time.Now())
+	return err
+}
+
+func (r *apiTokenRepository) Touch(ctx context.Context, identity, ip string, at time.Time) error {
+	uatQ := r.q.UserAPIToken
+	_, err := uatQ.WithContext(ctx).Where(uatQ.Identity.Eq(identity)).UpdateSimple(uatQ.LastUsedAt.Value(at), uatQ.LastUsedIP.Value(ip))
+	return err
+}
+
+type sshKeyRepository struct {
+	q *query.Query
+}
+
+func (r *sshKeyRepository) Create(ctx context.Context, key *entity.UserSSHKey) error {
+	ctx = resolver.WithUser(ctx, key.UserIdentity)
+	return r.q.UserSSHKey.WithContext(ctx).Create(key)
+}
+
+func (r *sshKeyRepository) Find(ctx context.Context, userIdentity, fingerprint string) (*entity.UserSSHKey, error) {
+	uskQ := r.q.UserSSHKey
+	key, err := uskQ.WithContext(ctx).Where(uskQ.UserIdentity.Eq(userIdentity), uskQ.Fingerprint.Eq(fingerprint)).First()
+	return key, notFound(err)
+}
+
+func (r *sshKeyRepository) CountByFingerprint(ctx context.Context, fingerprint string) (int64, error) {
+	uskQ := r.q.UserSSHKey
+	return uskQ.WithContext(ctx).Where(uskQ.Fingerprint.Eq(fingerprint)).Count()
+}
+
+func (r *sshKeyRepository) ListByUser(ctx context.Context, userIdentity string) ([]*entity.UserSSHKey, error) {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	uskQ := r.q.UserSSHKey
+	return uskQ.WithContext(ctx).Where(uskQ.UserIdentity.Eq(userIdentity)).Order(uskQ.ID).Find()
+}
+
+func (r *sshKeyRepository) Delete(ctx context.Context, userIdentity, fingerprint string) error {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	uskQ := r.q.UserSSHKey
+	_, err := uskQ.WithContext(ctx).Where(uskQ.UserIdentity.Eq(userIdentity), uskQ.Fingerprint.Eq(fingerprint)).Delete()
+	return err
+}
+
+func (r *sshKeyRepository) DeleteByUser(ctx context.Context, userIdentity string) error {
+	ctx = resolver.WithUser(ctx, userIdentity)
+	uskQ := r.q.UserSSHKey
+	_, err := uskQ.WithContext(ctx).Where(uskQ.UserIdentity.Eq(userIdentity)).Delete()
+	return err
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/dal/migrate"
+	"cloud-storage/biz/dal/query"
+	"cloud-storage/biz/dal/resolver"
+	"cloud-storage/biz/service"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func openMigrated(t *testing.T, path string) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := migrate.New(db, "sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Up(); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// TestSessionFindReplica checks where sessions are looked up with a
+// replica that has not caught up with the primary.
+func TestSessionFindReplica(t *testing.T) {
+	dir := t.TempDir()
+	primary := openMigrated(t, filepath.Join(dir, "primary.db"))
+	replica := openMigrated(t, filepath.Join(dir, "replica.db"))
+	if err := resolver.Register(primary, []gorm.Dialector{sqlite.Open(filepath.Join(dir, "replica.db"))}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	r := &sessionRepository{q: query.Use(primary)}
+	ctx := context.Background()
+
+	session := func(user, identity string) *entity.UserSession {
+		now := time.Now()
+		return &entity.UserSession{Identity: identity, UserIdentity: user, LastSeenAt: now, ExpiresAt: now.Add(time.Hour), CreatedAt: now}
+	}
+	// Only the replica has the session of user-1, which shows where the
+	// lookup went; only the primary has the one user-2 just started
+	if err := replica.Create(session("user-1", "s-1")).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := primary.Create(session("user-2", "s-2")).Error; err != nil {
+		t.Fatal(err)
+	}
+	// user-3 signed a session out through this instance, which the replica
+	// still has
+	for _, db := range []*gorm.DB{primary, replica} {
+		if err := db.Create(session("user-3", "s-3")).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := r.Delete(ctx, "user-3", "s-3"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name, user, identity string
+		found                bool
+	}{
+		{"read from the replica", "user-1", "s-1", true},
+		{"not on the replica yet", "user-2", "s-2", true},
+		{"signed out through this instance", "user-3", "s-3", false},
+		{"none", "user-1", "s-2", false},
+	}
+	for _, tt := range tests {
+		_, err := r.Find(ctx, tt.user, tt.identity)
+		if tt.found && err != nil {
+			t.Errorf("%s: Find: %v", tt.name, err)
+		}
+		if !tt.found && !errors.Is(err, service.ErrRecordNotFound) {
+			t.Errorf("%s: Find error = %v, want ErrRecordNotFound", tt.name, err)
+		}
+	}
+}
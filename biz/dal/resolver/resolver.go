@@ -0,0 +1,113 @@
+// Package resolver sends reads to the replicas of the database and writes
+// and transactions to the primary.
+//
+// Replicas lag behind the primary, so a user who just wrote would not see
+// the change on the next listing. Queries run with a context carrying the
+// user, see WithUser, therefore keep reading from the primary for a short
+// window after the user's last write. Only the writes made through this
+// process count: a user whose next request is served by another instance
+// may read from a replica that lags behind.
+package resolver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+type userKey struct{}
+
+// WithUser returns a copy of ctx for queries run on behalf of the user.
+func WithUser(ctx context.Context, userIdentity string) context.Context {
+	if userIdentity == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, userKey{}, userIdentity)
+}
+
+func userFrom(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	identity, _ := ctx.Value(userKey{}).(string)
+	return identity
+}
+
+// Register routes the queries of db to replicas, keeping the reads of users
+// on the primary for window after their last write.
+func Register(db *gorm.DB, replicas []gorm.Dialector, window time.Duration) error {
+	err := db.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: replicas,
+		Policy:   dbresolver.RandomPolicy{},
+	}))
+	if err != nil {
+		return err
+	}
+	return db.Use(&sticky{window: window, writes: make(map[string]time.Time)})
+}
+
+// sticky is the gorm plugin pinning the reads of recent writers to the
+// primary. It only knows the writes of this process.
+type sticky struct {
+	window time.Duration
+
+	mu     sync.Mutex
+	writes map[string]time.Time
+	pruned time.Time
+}
+
+func (s *sticky) Name() string {
+	return "cloud-storage:sticky"
+}
+
+func (s *sticky) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Create().After("gorm:create").Register("cloud-storage:mark_write", s.markWrite),
+		cb.Update().After("gorm:update").Register("cloud-storage:mark_write", s.markWrite),
+		cb.Delete().After("gorm:delete").Register("cloud-storage:mark_write", s.markWrite),
+		cb.Query().After("gorm:db_resolver").Before("gorm:query").Register("cloud-storage:read_your_writes", s.readYourWrites),
+		cb.Row().After("gorm:db_resolver").Before("gorm:row").Register("cloud-storage:read_your_writes", s.readYourWrites),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *sticky) markWrite(db *gorm.DB) {
+	identity := userFrom(db.Statement.Context)
+	if identity == "" || db.Error != nil {
+		return
+	}
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writes[identity] = now
+	// Forget the users whose window has passed now and then
+	if now.Sub(s.pruned) > s.window {
+		for id, t := range s.writes {
+			if now.Sub(t) > s.window {
+				delete(s.writes, id)
+			}
+		}
+		s.pruned = now
+	}
+}
+
+func (s *sticky) readYourWrites(db *gorm.DB) {
+	identity := userFrom(db.Statement.Context)
+	if identity == "" {
+		return
+	}
+	s.mu.Lock()
+	t, ok := s.writes[identity]
+	s.mu.Unlock()
+	if ok && time.Since(t) <= s.window {
+		dbresolver.Write.ModifyStatement(db.Statement)
+	}
+}
@@ -0,0 +1,95 @@
+// Package errno defines the errors reported to API clients. Every error has
+// a stable code clients can rely on; its message is for humans and may
+// change.
+package errno
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Code identifies a kind of error in API responses.
+type Code string
+
+const (
+	Internal           Code = "INTERNAL"
+	InvalidArgument    Code = "INVALID_ARGUMENT"
+	Unauthenticated    Code = "UNAUTHENTICATED"
+	PermissionDenied   Code = "PERMISSION_DENIED"
+	InvalidCredentials Code = "INVALID_CREDENTIALS"
+	FileNotFound       Code = "FILE_NOT_FOUND"
+	FolderNotFound     Code = "FOLDER_NOT_FOUND"
+	ShareNotFound      Code = "SHARE_NOT_FOUND"
+	ShareExpired       Code = "SHARE_EXPIRED"
+	NameConflict       Code = "NAME_CONFLICT"
+	SSHKeyConflict     Code = "SSH_KEY_CONFLICT"
+	QuotaExceeded      Code = "QUOTA_EXCEEDED"
+	JobNotFound        Code = "JOB_NOT_FOUND"
+	UserNotFound       Code = "USER_NOT_FOUND"
+	AccountDisabled    Code = "ACCOUNT_DISABLED"
+	JobNotFailed       Code = "JOB_NOT_FAILED"
+	RateLimited        Code = "RATE_LIMITED"
+	AccountLocked      Code = "ACCOUNT_LOCKED"
+	TwoFactorRequired  Code = "TWO_FACTOR_REQUIRED"
+	InvalidTwoFactor   Code = "INVALID_TWO_FACTOR_CODE"
+	TwoFactorEnabled   Code = "TWO_FACTOR_ENABLED"
+	TwoFactorDisabled  Code = "TWO_FACTOR_NOT_ENABLED"
+	TokenNotFound      Code = "TOKEN_NOT_FOUND"
+	InvalidResetToken  Code = "INVALID_RESET_TOKEN"
+	SessionNotFound    Code = "SESSION_NOT_FOUND"
+)
+
+// Error is an error with a code. Err, if set, is the underlying cause; it
+// is logged but never shown to clients.
+type Error struct {
+	Code    Code
+	Message string
+	Err     error
+	// RetryAfter, if set, is how long the client should wait before trying
+	// again
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New returns an error with the given code and message.
+func New(code Code, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Wrap returns an error with the given code and message caused by err.
+func Wrap(code Code, err error, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...), Err: err}
+}
+
+// From returns err as an *Error. Errors without a code are internal.
+func From(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return &Error{Code: Internal, Message: "internal error", Err: err}
+}
+
+// CodeOf returns the code of err, Internal if it has none.
+func CodeOf(err error) Code {
+	return From(err).Code
+}
+
+// Body is the JSON body of error responses.
+type Body struct {
+	Code      Code   `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"`
+	TraceID   string `json:"trace_id,omitempty"`
+}
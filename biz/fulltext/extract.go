@@ -0,0 +1,163 @@
+package fulltext
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// maxContentSize caps how much extracted text is indexed per blob.
+const maxContentSize = 4 << 20
+
+var errUnsupported = errors.New("unsupported file type")
+
+var textExts = map[string]bool{
+	".txt": true, ".md": true, ".markdown": true, ".csv": true, ".log": true,
+	".json": true, ".xml": true, ".yaml": true, ".yml": true, ".toml": true, ".ini": true,
+	".html": true, ".htm": true, ".css": true, ".sql": true, ".proto": true, ".sh": true,
+	".go": true, ".java": true, ".kt": true, ".py": true, ".rb": true, ".php": true,
+	".js": true, ".ts": true, ".c": true, ".h": true, ".cpp": true, ".hpp": true,
+	".cs": true, ".rs": true, ".swift": true,
+}
+
+// extractors return the plain text of the blob stored at name, by file
+// extension; the text ones are added by init.
+var extractors = map[string]func(name string) (string, error){
+	".pdf": extractPDF,
+	".docx": func(name string) (string, error) {
+		return extractOOXML(name, "w:t", "w:p", func(n string) bool { return n == "word/document.xml" })
+	},
+	".xlsx": func(name string) (string, error) {
+		return extractOOXML(name, "t", "si", func(n string) bool {
+			return n == "xl/sharedStrings.xml" || path.Dir(n) == "xl/worksheets"
+		})
+	},
+}
+
+func init() {
+	for ext := range textExts {
+		extractors[ext] = extractText
+	}
+}
+
+// extract returns the plain text of the blob stored at name.
+func extract(name, ext string) (string, error) {
+	fn, ok := extractors[strings.ToLower(ext)]
+	if !ok {
+		return "", errUnsupported
+	}
+	return fn(name)
+}
+
+// supported reports whether text can be extracted from files of extension
+// ext.
+func supported(ext string) bool {
+	_, ok := extractors[strings.ToLower(ext)]
+	return ok
+}
+
+func extractText(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	b, err := io.ReadAll(io.LimitReader(f, maxContentSize))
+	if err != nil {
+		return "", err
+	}
+	return strings.ToValidUTF8(string(b), ""), nil
+}
+
+func extractPDF(name string) (string, error) {
+	f, r, err := pdf.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	text, err := r.GetPlainText()
+	if err != nil {
+		return "", err
+	}
+	b, err := io.ReadAll(io.LimitReader(text, maxContentSize))
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// extractOOXML collects the character data of every textElem element inside
+// the parts of an Office Open XML package selected by match, starting a new
+// line at the end of every breakElem element.
+func extractOOXML(name, textElem, breakElem string, match func(string) bool) (string, error) {
+	zr, err := zip.OpenReader(name)
+	if err != nil {
+		return "", err
+	}
+	defer zr.Close()
+
+	var sb strings.Builder
+	for _, zf := range zr.File {
+		if !match(zf.Name) {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return "", err
+		}
+		err = collectXMLText(&sb, rc, textElem, breakElem)
+		rc.Close()
+		if err != nil {
+			return "", err
+		}
+		if sb.Len() >= maxContentSize {
+			break
+		}
+	}
+	return sb.String(), nil
+}
+
+func collectXMLText(sb *strings.Builder, r io.Reader, textElem, breakElem string) error {
+	dec := xml.NewDecoder(r)
+	inText := false
+	for sb.Len() < maxContentSize {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			inText = qualified(t.Name) == textElem
+		case xml.EndElement:
+			inText = false
+			if qualified(t.Name) == breakElem {
+				sb.WriteByte('\n')
+			}
+		case xml.CharData:
+			if inText {
+				sb.Write(t)
+			}
+		}
+	}
+	return nil
+}
+
+// qualified names an element the way it is written in OOXML parts, e.g. "w:t".
+func qualified(n xml.Name) string {
+	switch n.Space {
+	case "http://schemas.openxmlformats.org/wordprocessingml/2006/main":
+		return "w:" + n.Local
+	}
+	return n.Local
+}
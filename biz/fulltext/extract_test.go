@@ -0,0 +1,26 @@
+package fulltext
+
+import "testing"
+
+func TestSupported(t *testing.T) {
+	tests := []struct {
+		ext  string
+		want bool
+	}{
+		{".txt", true},
+		{".TXT", true},
+		{".go", true},
+		{".pdf", true},
+		{".docx", true},
+		{".xlsx", true},
+		{".png", false},
+		{".mp4", false},
+		{".doc", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := supported(tt.ext); got != tt.want {
+			t.Errorf("supported(%q) = %v, want %v", tt.ext, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package fulltext
+
+import (
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/dal/query"
+	"cloud-storage/biz/jobs"
+	"cloud-storage/biz/logging"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"gorm.io/gen"
+	"gorm.io/gorm"
+)
+
+var index bleve.Index
+
+var log = logging.Logger("fulltext")
+
+// indexJob indexes the content of a blob. Extraction is cheap for most
+// files but PDFs can take a while, hence the timeout.
+var indexJob = jobs.Define("fulltext.index", jobs.Options{Concurrency: 2, Timeout: 10 * time.Minute}, indexBlob)
+
+// indexPayload names the blob an indexJob indexes.
+type indexPayload struct {
+	Hash string `json:"hash"`
+}
+
+// document is what gets indexed for every unique blob, keyed by its hash.
+type document struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// Hit is a single matching blob with highlighted fragments of its content.
+type Hit struct {
+	Hash      string
+	Fragments []string
+}
+
+// Init opens (or creates) the index at path and queues every blob of the
+// repository pool that has not been indexed yet and has text to extract.
+func Init(path string) {
+	var err error
+	index, err = bleve.Open(path)
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		index, err = bleve.New(path, newMapping())
+	}
+	if err != nil {
+		panic(err)
+	}
+
+	go backfill()
+}
+
+func newMapping() mapping.IndexMapping {
+	content := bleve.NewTextFieldMapping()
+	content.Store = true
+	content.IncludeTermVectors = true
+
+	doc := bleve.NewDocumentMapping()
+	doc.AddFieldMappingsAt("name", bleve.NewTextFieldMapping())
+	doc.AddFieldMappingsAt("content", content)
+
+	m := bleve.NewIndexMapping()
+	m.DefaultMapping = doc
+	return m
+}
+
+// Enqueue queues a blob for indexing, unless it is queued already or no
+// text can be extracted from its type of file.
+func Enqueue(ctx context.Context, rp *entity.RepositoryPool, opts ...jobs.EnqueueOption) error {
+	if !supported(rp.Ext) {
+		return nil
+	}
+	return indexJob.Enqueue(ctx, indexPayload{Hash: rp.Hash}, append(opts, jobs.Key(rp.Hash))...)
+}
+
+func indexBlob(ctx context.Context, p indexPayload) error {
+	if indexed(p.Hash) {
+		return nil
+	}
+	rpQ := query.RepositoryPool
+	rp, err := rpQ.WithContext(ctx).WriteDB().Where(rpQ.Hash.Eq(p.Hash)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Collected before its turn came
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	content, err := extract(rp.Path, rp.Ext)
+	if errors.Is(err, errUnsupported) {
+		return nil
+	}
+	if err != nil {
+		// Extracting the same content would fail again
+		return jobs.Permanent(fmt.Errorf("failed to extract %s: %w", rp.Path, err))
+	}
+	return index.Index(rp.Hash, document{Name: rp.Name, Content: content})
+}
+
+func backfill() {
+	var batch []*entity.RepositoryPool
+	err := query.RepositoryPool.FindInBatches(&batch, 100, func(tx gen.Dao, _ int) error {
+		for _, rp := range batch {
+			if indexed(rp.Hash) {
+				continue
+			}
+			if err := Enqueue(context.Background(), rp); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error("failed to scan repository pool", "error", err)
+	}
+}
+
+func indexed(hash string) bool {
+	doc, err := index.Document(hash)
+	return err == nil && doc != nil
+}
+
+// Search matches keyword against the content of the blobs whose hashes are
+// given, so that callers only ever see documents they own.
+func Search(keyword string, hashes []string, from, size int) ([]Hit, uint64, error) {
+	if len(hashes) == 0 {
+		return nil, 0, nil
+	}
+	match := bleve.NewMatchQuery(keyword)
+	match.SetField("content")
+	q := bleve.NewConjunctionQuery(bleve.NewDocIDQuery(hashes), match)
+
+	req := bleve.NewSearchRequestOptions(q, size, from, false)
+	req.Highlight = bleve.NewHighlightWithStyle("html")
+	req.Highlight.AddField("content")
+	res, err := index.Search(req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hits := make([]Hit, 0, len(res.Hits))
+	for _, h := range res.Hits {
+		hits = append(hits, Hit{Hash: h.ID, Fragments: h.Fragments["content"]})
+	}
+	return hits, res.Total, nil
+}
@@ -0,0 +1,18 @@
+// Package admin serves the API for administrators operating the service.
+package admin
+
+import (
+	"cloud-storage/biz/mw"
+
+	"github.com/cloudwego/hertz/pkg/app/server"
+)
+
+// Register mounts the admin API under /admin, open to administrators only.
+func Register(r *server.Hertz) {
+	g := r.Group("/admin", mw.JwtMiddleware.MiddlewareFunc(), mw.RequireAdmin())
+	g.GET("/job/list", JobList)
+	g.GET("/job/:id", JobDetail)
+	g.POST("/job/:id/retry", JobRetry)
+	g.GET("/audit/list", AuditList)
+	g.GET("/audit/export", AuditExport)
+}
@@ -0,0 +1,111 @@
+package admin
+
+import (
+	"cloud-storage/biz/audit"
+	"cloud-storage/biz/errno"
+	"cloud-storage/biz/logging"
+	"cloud-storage/biz/mw"
+	"context"
+	"io"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+)
+
+var log = logging.Logger("http")
+
+type AuditListRequest struct {
+	User   string `query:"user"`
+	Action string `query:"action"`
+	// Since and Until are RFC 3339 times bounding the records listed
+	Since string `query:"since"`
+	Until string `query:"until"`
+	Page  int    `query:"page"`
+	Size  int    `query:"size"`
+}
+
+type AuditListReply struct {
+	List  []*audit.Entry `json:"list"`
+	Count int64          `json:"count"`
+}
+
+func (req *AuditListRequest) filter() (*audit.Filter, error) {
+	f := &audit.Filter{User: req.User, Action: req.Action}
+	var err error
+	if req.Since != "" {
+		if f.Since, err = time.Parse(time.RFC3339, req.Since); err != nil {
+			return nil, errno.New(errno.InvalidArgument, "invalid since: %v", err)
+		}
+	}
+	if req.Until != "" {
+		if f.Until, err = time.Parse(time.RFC3339, req.Until); err != nil {
+			return nil, errno.New(errno.InvalidArgument, "invalid until: %v", err)
+		}
+	}
+	return f, nil
+}
+
+// AuditList lists the audit log, newest first, optionally of one user, one
+// action or a time range.
+// @router /admin/audit/list [GET]
+func AuditList(ctx context.Context, c *app.RequestContext) {
+	var req AuditListRequest
+	if err := c.BindAndValidate(&req); err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+	f, err := req.filter()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if req.Size <= 0 {
+		req.Size = 20
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+
+	list, count, err := audit.List(ctx, f, (req.Page-1)*req.Size, req.Size)
+	if err != nil {
+		c.Error(errno.Wrap(errno.Internal, err, "failed to list audit log"))
+		return
+	}
+	c.JSON(consts.StatusOK, &AuditListReply{List: list, Count: count})
+}
+
+// AuditExport streams the records of the audit log matching the filters
+// of AuditList as JSON lines, oldest first. The export is itself audited.
+// @router /admin/audit/export [GET]
+func AuditExport(ctx context.Context, c *app.RequestContext) {
+	var req AuditListRequest
+	if err := c.BindAndValidate(&req); err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+	f, err := req.filter()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	audit.Record(ctx, &audit.Event{
+		Action: audit.AuditExport,
+		Actor:  mw.UserIdentity(c),
+		After:  map[string]string{"user": req.User, "action": req.Action, "since": req.Since, "until": req.Until},
+	})
+	// The export is streamed, so a failure can only cut it short once the
+	// status is sent
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := audit.Export(ctx, f, pw)
+		if err != nil {
+			log.ErrorContext(ctx, "failed to export audit log", "error", err)
+		}
+		pw.CloseWithError(err)
+	}()
+	c.SetContentType("application/x-ndjson")
+	c.Header("Content-Disposition", `attachment; filename="audit.jsonl"`)
+	c.SetBodyStream(pr, -1)
+}
@@ -0,0 +1,147 @@
+package admin
+
+import (
+	"cloud-storage/biz/audit"
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/errno"
+	"cloud-storage/biz/jobs"
+	"cloud-storage/biz/mw"
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+)
+
+type JobListRequest struct {
+	State string `query:"state"`
+	Type  string `query:"type"`
+	Page  int    `query:"page"`
+	Size  int    `query:"size"`
+}
+
+type JobListReply struct {
+	List  []*Job `json:"list"`
+	Count int64  `json:"count"`
+}
+
+type JobRequest struct {
+	ID uint32 `path:"id"`
+}
+
+// Job is a background job as shown to administrators.
+type Job struct {
+	ID          uint32          `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	State       string          `json:"state"`
+	Attempts    int32           `json:"attempts"`
+	MaxAttempts int32           `json:"max_attempts"`
+	RunAt       time.Time       `json:"run_at"`
+	LastError   string          `json:"last_error,omitempty"`
+	FinishedAt  *time.Time      `json:"finished_at,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+func newJob(j *entity.Job) *Job {
+	job := &Job{
+		ID:          j.ID,
+		Type:        j.Type,
+		Payload:     json.RawMessage(j.Payload),
+		State:       j.State,
+		Attempts:    j.Attempts,
+		MaxAttempts: j.MaxAttempts,
+		RunAt:       j.RunAt,
+		LastError:   j.LastError,
+		CreatedAt:   j.CreatedAt,
+	}
+	if !json.Valid(job.Payload) {
+		job.Payload = nil
+	}
+	if !j.FinishedAt.IsZero() {
+		job.FinishedAt = &j.FinishedAt
+	}
+	return job
+}
+
+// JobList lists the background jobs, newest first, optionally in one state
+// or of one type.
+// @router /admin/job/list [GET]
+func JobList(ctx context.Context, c *app.RequestContext) {
+	var req JobListRequest
+	if err := c.BindAndValidate(&req); err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+	if req.Size <= 0 {
+		req.Size = 20
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+
+	list, count, err := jobs.List(ctx, req.State, req.Type, (req.Page-1)*req.Size, req.Size)
+	if err != nil {
+		c.Error(errno.Wrap(errno.Internal, err, "failed to list jobs"))
+		return
+	}
+	resp := &JobListReply{List: make([]*Job, 0, len(list)), Count: count}
+	for _, j := range list {
+		resp.List = append(resp.List, newJob(j))
+	}
+	c.JSON(consts.StatusOK, resp)
+}
+
+// JobDetail returns a background job.
+// @router /admin/job/:id [GET]
+func JobDetail(ctx context.Context, c *app.RequestContext) {
+	var req JobRequest
+	if err := c.BindAndValidate(&req); err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+
+	j, err := jobs.Get(ctx, req.ID)
+	if errors.Is(err, jobs.ErrNotFound) {
+		c.Error(errno.New(errno.JobNotFound, "job not found"))
+		return
+	}
+	if err != nil {
+		c.Error(errno.Wrap(errno.Internal, err, "failed to query job"))
+		return
+	}
+	c.JSON(consts.StatusOK, newJob(j))
+}
+
+// JobRetry queues a failed job to run again.
+// @router /admin/job/:id/retry [POST]
+func JobRetry(ctx context.Context, c *app.RequestContext) {
+	var req JobRequest
+	if err := c.BindAndValidate(&req); err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+
+	err := jobs.Retry(ctx, req.ID)
+	switch {
+	case errors.Is(err, jobs.ErrNotFound):
+		c.Error(errno.New(errno.JobNotFound, "job not found"))
+		return
+	case errors.Is(err, jobs.ErrNotFailed):
+		c.Error(errno.New(errno.JobNotFailed, "only failed jobs can be retried"))
+		return
+	case err != nil:
+		c.Error(errno.Wrap(errno.Internal, err, "failed to retry job"))
+		return
+	}
+	audit.Record(ctx, &audit.Event{Action: audit.JobRetry, Actor: mw.UserIdentity(c), Target: strconv.FormatUint(uint64(req.ID), 10)})
+	j, err := jobs.Get(ctx, req.ID)
+	if err != nil {
+		c.Error(errno.Wrap(errno.Internal, err, "failed to query job"))
+		return
+	}
+	c.JSON(consts.StatusOK, newJob(j))
+}
@@ -3,7 +3,8 @@
 package chunk
 
 import (
-	"cloud-storage/biz/dal/query"
+	"cloud-storage/biz/errno"
+	"cloud-storage/biz/service"
 	"context"
 
 	chunk "cloud-storage/biz/model/chunk"
@@ -12,8 +13,6 @@ import (
 	"github.com/cloudwego/hertz/pkg/protocol/consts"
 )
 
-var q = query.Q
-
 // FileUploadPrepare .
 // @router /file/upload/prepare [POST]
 func FileUploadPrepare(ctx context.Context, c *app.RequestContext) {
@@ -21,22 +20,19 @@ func FileUploadPrepare(ctx context.Context, c *app.RequestContext) {
 	var req chunk.FileUploadPrepareRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		c.String(consts.StatusBadRequest, err.Error())
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
 		return
 	}
 
-	rp, err := q.RepositoryPool.Where(q.RepositoryPool.Hash.Eq(req.Md5)).First()
+	identity, err := service.Uploads.Prepare(ctx, req.Md5)
 	if err != nil {
-		c.String(consts.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
-	var reply chunk.FileUploadPrepareReply
-	if rp != nil {
-		reply.Identity = rp.Identity
-	}
-
-	c.JSON(consts.StatusOK, &reply)
+	c.JSON(consts.StatusOK, &chunk.FileUploadPrepareReply{
+		Identity: identity,
+	})
 }
 
 // FileUploadChunk .
@@ -46,13 +42,13 @@ func FileUploadChunk(ctx context.Context, c *app.RequestContext) {
 	var req chunk.FileUploadChunkRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		c.String(consts.StatusBadRequest, err.Error())
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
 		return
 	}
 
 	_, err = c.FormFile("file")
 	if err != nil {
-		c.String(consts.StatusBadRequest, err.Error())
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
 		return
 	}
 
@@ -68,7 +64,7 @@ func FileUploadChunkComplete(ctx context.Context, c *app.RequestContext) {
 	var req chunk.FileUploadChunkCompleteRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		c.String(consts.StatusBadRequest, err.Error())
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
 		return
 	}
 
@@ -3,26 +3,16 @@
 package file
 
 import (
-	"cloud-storage/biz/dal/entity"
-	"cloud-storage/biz/dal/query"
+	"cloud-storage/biz/errno"
 	file "cloud-storage/biz/model/file"
+	"cloud-storage/biz/mw"
+	"cloud-storage/biz/service"
 	"context"
-	"crypto/md5"
-	"errors"
-	"fmt"
-	"mime/multipart"
-	"os"
-	"path/filepath"
-	"strconv"
 
 	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/cloudwego/hertz/pkg/protocol/consts"
-	"github.com/duke-git/lancet/v2/random"
-	"gorm.io/gorm"
 )
 
-var q = query.Q
-
 // FileUpload .
 // @router /file/upload [POST]
 func FileUpload(ctx context.Context, c *app.RequestContext) {
@@ -30,73 +20,25 @@ func FileUpload(ctx context.Context, c *app.RequestContext) {
 	var req file.FileUploadRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		c.String(consts.StatusBadRequest, err.Error())
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
 		return
 	}
 
 	fileHeader, err := c.FormFile("file")
 	if err != nil {
-		c.String(consts.StatusBadRequest, "file upload error: %v", err)
+		c.Error(errno.New(errno.InvalidArgument, "file upload error: %v", err))
 		return
 	}
-
-	// Check file if exists
-	bytes := make([]byte, fileHeader.Size)
 	openedFile, err := fileHeader.Open()
 	if err != nil {
-		c.String(consts.StatusInternalServerError, "failed to open file: %v", err)
-		return
-	}
-	defer func(openedFile multipart.File) {
-		err := openedFile.Close()
-		if err != nil {
-			c.String(consts.StatusInternalServerError, "failed to close file: %v", err)
-		}
-	}(openedFile)
-	_, err = openedFile.Read(bytes)
-	if err != nil {
-		c.String(consts.StatusInternalServerError, "failed to read file: %v", err)
-		return
-	}
-	hash := fmt.Sprintf("%x", md5.Sum(bytes))
-	rp, err := q.RepositoryPool.Where(q.RepositoryPool.Hash.Eq(hash)).First()
-	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
-		c.String(consts.StatusInternalServerError, "failed to query repository pool: %v", err)
-		return
-	}
-	if rp != nil {
-		c.String(consts.StatusBadRequest, "file already exists: %s", rp.Hash)
+		c.Error(errno.Wrap(errno.Internal, err, "failed to open file"))
 		return
 	}
+	defer openedFile.Close()
 
-	// Save the file
-	filename := filepath.Base(fileHeader.Filename)
-	joinedPath := filepath.Join(os.TempDir(), "cloud_storage", filename)
-	if err := os.MkdirAll(filepath.Dir(joinedPath), os.ModePerm); err != nil {
-		c.String(consts.StatusInternalServerError, "failed to create directory: %v", err)
-		return
-	}
-	if err := c.SaveUploadedFile(fileHeader, joinedPath); err != nil {
-		c.String(consts.StatusInternalServerError, "failed to save file: %v", err)
-		return
-	}
-
-	// Create repository pool record
-	uuid, err := random.UUIdV4()
+	rp, err := service.Uploads.Upload(ctx, fileHeader.Filename, openedFile)
 	if err != nil {
-		c.String(consts.StatusInternalServerError, "failed to generate UUID: %v", err)
-		return
-	}
-	rp = &entity.RepositoryPool{
-		Identity: uuid,
-		Hash:     hash,
-		Name:     filename,
-		Ext:      filepath.Ext(filename),
-		Size:     int32(fileHeader.Size),
-		Path:     joinedPath,
-	}
-	if err := q.RepositoryPool.Create(rp); err != nil {
-		c.String(consts.StatusInternalServerError, "failed to create repository pool: %v", err)
+		c.Error(err)
 		return
 	}
 
@@ -114,25 +56,18 @@ func UserRepositorySave(ctx context.Context, c *app.RequestContext) {
 	var req file.UserRepositorySaveRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		c.String(consts.StatusBadRequest, err.Error())
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
 		return
 	}
 
-	uuid, err := random.UUIdV4()
-	if err != nil {
-		c.String(consts.StatusInternalServerError, "failed to generate UUID: %v", err)
-		return
-	}
-	ur := entity.UserRepository{
-		Identity:           uuid,
-		UserIdentity:       "",
-		ParentID:           int32(req.ParentId),
+	err = service.Files.Save(ctx, mw.UserIdentity(c), &service.SaveRequest{
+		ParentID:           req.ParentId,
 		RepositoryIdentity: req.RepositoryIdentity,
 		Ext:                req.Ext,
 		Name:               req.Name,
-	}
-	if err := q.UserRepository.Create(&ur); err != nil {
-		c.String(consts.StatusInternalServerError, "failed to create repository: %v", err)
+	})
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -146,41 +81,31 @@ func UserFileList(ctx context.Context, c *app.RequestContext) {
 	var req file.UserFileListRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		c.String(consts.StatusBadRequest, err.Error())
-		return
-	}
-
-	var uf []*file.UserFile
-	size := req.Size
-	if size <= 0 {
-		size = 10 // Default size
-	}
-	page := req.Page
-	if page <= 0 {
-		page = 1 // Default page
-	}
-	offset := (page - 1) * size
-	ID, err := strconv.Atoi(req.Identity)
-	if err != nil {
-		c.String(consts.StatusBadRequest, "invalid identity: %v", err)
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
 		return
 	}
 
-	urQ := q.UserRepository
-	err = urQ.Select(urQ.ID, urQ.Identity, urQ.RepositoryIdentity, urQ.Ext,
-		urQ.Name, q.RepositoryPool.Path, q.RepositoryPool.Size).
-		Where(urQ.ParentID.Eq(int32(ID)), urQ.UserIdentity.Eq("")).
-		LeftJoin(q.RepositoryPool, urQ.UserIdentity.EqCol(q.RepositoryPool.Identity)).
-		Limit(int(size)).Offset(int(offset)).Scan(&uf)
+	rows, count, err := service.Files.List(ctx, mw.UserIdentity(c), &service.ListRequest{
+		ParentID: req.Identity,
+		Page:     req.Page,
+		Size:     req.Size,
+	})
 	if err != nil {
-		c.String(consts.StatusInternalServerError, "failed to query user repository: %v", err)
+		c.Error(err)
 		return
 	}
 
-	count, err := urQ.Where(urQ.ParentID.Eq(int32(ID)), urQ.UserIdentity.Eq("")).Count()
-	if err != nil {
-		c.String(consts.StatusInternalServerError, "failed to count user repository: %v", err)
-		return
+	uf := make([]*file.UserFile, 0, len(rows))
+	for _, row := range rows {
+		uf = append(uf, &file.UserFile{
+			Id:                 int64(row.ID),
+			Identity:           row.Identity,
+			RepositoryIdentity: row.RepositoryIdentity,
+			Name:               row.Name,
+			Ext:                row.Ext,
+			Path:               row.Path,
+			Size:               row.Size,
+		})
 	}
 
 	c.JSON(consts.StatusOK, file.UserFileListReply{
@@ -196,7 +121,7 @@ func UserFolderList(ctx context.Context, c *app.RequestContext) {
 	var req file.UserFolderListRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		c.String(consts.StatusBadRequest, err.Error())
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
 		return
 	}
 
@@ -212,29 +137,13 @@ func UserFileNameUpdate(ctx context.Context, c *app.RequestContext) {
 	var req file.UserFileNameUpdateRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		c.String(consts.StatusBadRequest, err.Error())
-		return
-	}
-
-	sub := q.UserRepository.
-		Select(q.UserRepository.ParentID).
-		Where(q.UserRepository.Identity.Eq(req.Identity))
-	count, err := q.UserRepository.Where(q.UserRepository.Name.Eq(req.Name),
-		q.UserRepository.Columns(q.UserRepository.ParentID).Eq(sub)).
-		Count()
-	if err != nil {
-		c.String(consts.StatusInternalServerError, "failed to count user repository: %v", err)
-		return
-	}
-	if count > 0 {
-		c.String(consts.StatusBadRequest, "file name already exists in the same folder")
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
 		return
 	}
 
-	_, err = q.UserRepository.Where(q.UserRepository.UserIdentity.Eq(req.Identity)).
-		Update(q.UserRepository.Name, req.Name)
+	err = service.Files.Rename(ctx, mw.UserIdentity(c), req.Identity, req.Name)
 	if err != nil {
-		c.String(consts.StatusInternalServerError, "failed to update user repository name: %v", err)
+		c.Error(err)
 		return
 	}
 
@@ -248,40 +157,18 @@ func UserFolderCreate(ctx context.Context, c *app.RequestContext) {
 	var req file.UserFolderCreateRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		c.String(consts.StatusBadRequest, err.Error())
-		return
-	}
-
-	count, err := q.UserRepository.Where(q.UserRepository.Name.Eq(req.Name),
-		q.UserRepository.ParentID.Eq(int32(req.ParentId))).
-		Count()
-	if err != nil {
-		c.String(consts.StatusInternalServerError, "failed to count user repository: %v", err)
-		return
-	}
-	if count > 0 {
-		c.String(consts.StatusBadRequest, "file name already exists in the same folder")
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
 		return
 	}
 
-	uuid, err := random.UUIdV4()
+	identity, err := service.Files.CreateFolder(ctx, mw.UserIdentity(c), req.ParentId, req.Name)
 	if err != nil {
-		c.String(consts.StatusInternalServerError, "failed to generate UUID: %v", err)
-		return
-	}
-	ur := entity.UserRepository{
-		Identity:     uuid,
-		UserIdentity: "",
-		ParentID:     int32(req.ParentId),
-		Name:         req.Name,
-	}
-	if err := q.UserRepository.Create(&ur); err != nil {
-		c.String(consts.StatusInternalServerError, "failed to create repository: %v", err)
+		c.Error(err)
 		return
 	}
 
 	c.JSON(consts.StatusOK, file.UserFolderCreateReply{
-		Identity: uuid,
+		Identity: identity,
 	})
 }
 
@@ -292,14 +179,13 @@ func UserFileDelete(ctx context.Context, c *app.RequestContext) {
 	var req file.UserFileDeleteRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		c.String(consts.StatusBadRequest, err.Error())
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
 		return
 	}
 
-	urQ := q.UserRepository
-	_, err = urQ.Where(urQ.UserIdentity.Eq(""), urQ.Identity.Eq(req.Identity)).Delete()
+	err = service.Files.Delete(ctx, mw.UserIdentity(c), req.Identity)
 	if err != nil {
-		c.String(consts.StatusInternalServerError, "failed to delete user repository: %v", err)
+		c.Error(err)
 		return
 	}
 
@@ -313,26 +199,106 @@ func UserFileMove(ctx context.Context, c *app.RequestContext) {
 	var req file.UserFileMoveRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		c.String(consts.StatusBadRequest, err.Error())
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
 		return
 	}
 
-	urQ := q.UserRepository
-	ur, err := urQ.Where(urQ.Identity.Eq(req.ParentIdentity), urQ.UserIdentity.Eq("")).First()
+	err = service.Files.Move(ctx, mw.UserIdentity(c), req.Identity, req.ParentIdentity)
 	if err != nil {
-		c.String(consts.StatusInternalServerError, "failed to query parent folder: %v", err)
+		c.Error(err)
 		return
 	}
-	if ur == nil {
-		c.String(consts.StatusBadRequest, "parent folder does not exist")
+
+	c.JSON(consts.StatusOK, file.UserFileMoveReply{})
+}
+
+// UserFileSearch .
+// @router /user/file/search [POST]
+func UserFileSearch(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req file.UserFileSearchRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+
+	hits, count, err := service.Files.Search(ctx, mw.UserIdentity(c), &service.SearchRequest{
+		Keyword:        req.Keyword,
+		Match:          req.Match,
+		Exts:           req.Ext,
+		Type:           req.Type,
+		MinSize:        req.MinSize,
+		MaxSize:        req.MaxSize,
+		ModifiedAfter:  req.ModifiedAfter,
+		ModifiedBefore: req.ModifiedBefore,
+		ParentIdentity: req.ParentIdentity,
+		Sort:           req.Sort,
+		Desc:           req.Desc,
+		Page:           req.Page,
+		Size:           req.Size,
+	})
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	_, err = urQ.Where(urQ.Identity.Eq(req.Identity)).Update(urQ.ParentID, ur.ID)
+	list := make([]*file.UserFileSearchHit, 0, len(hits))
+	for _, hit := range hits {
+		list = append(list, &file.UserFileSearchHit{
+			Identity:           hit.Identity,
+			RepositoryIdentity: hit.RepositoryIdentity,
+			Name:               hit.Name,
+			Ext:                hit.Ext,
+			Size:               hit.Size,
+			Path:               hit.FullPath,
+			IsFolder:           hit.RepositoryIdentity == "",
+			UpdatedAt:          hit.UpdatedAt.Unix(),
+		})
+	}
+
+	c.JSON(consts.StatusOK, file.UserFileSearchReply{
+		List:  list,
+		Count: count,
+	})
+}
+
+// UserFileContentSearch .
+// @router /user/file/content/search [POST]
+func UserFileContentSearch(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req file.UserFileContentSearchRequest
+	err = c.BindAndValidate(&req)
 	if err != nil {
-		c.String(consts.StatusInternalServerError, "failed to update user repository parent ID: %v", err)
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
 		return
 	}
 
-	c.JSON(consts.StatusOK, file.UserFileMoveReply{})
+	hits, count, err := service.Files.ContentSearch(ctx, mw.UserIdentity(c), &service.ContentSearchRequest{
+		Keyword: req.Keyword,
+		Page:    req.Page,
+		Size:    req.Size,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	list := make([]*file.UserFileContentSearchHit, 0, len(hits))
+	for _, hit := range hits {
+		list = append(list, &file.UserFileContentSearchHit{
+			Identity:           hit.Identity,
+			RepositoryIdentity: hit.RepositoryIdentity,
+			Name:               hit.Name,
+			Ext:                hit.Ext,
+			Size:               hit.Size,
+			Path:               hit.FullPath,
+			Snippets:           hit.Snippets,
+		})
+	}
+
+	c.JSON(consts.StatusOK, file.UserFileContentSearchReply{
+		List:  list,
+		Count: count,
+	})
 }
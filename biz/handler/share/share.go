@@ -3,18 +3,16 @@
 package share
 
 import (
-	"cloud-storage/biz/dal/entity"
-	"cloud-storage/biz/dal/query"
+	"cloud-storage/biz/errno"
 	share "cloud-storage/biz/model/share"
+	"cloud-storage/biz/mw"
+	"cloud-storage/biz/service"
 	"context"
 
 	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/cloudwego/hertz/pkg/protocol/consts"
-	"github.com/duke-git/lancet/v2/random"
 )
 
-var q = query.Q
-
 // ShareBasicDetail .
 // @router /share/basic/detail [GET]
 func ShareBasicDetail(ctx context.Context, c *app.RequestContext) {
@@ -22,26 +20,23 @@ func ShareBasicDetail(ctx context.Context, c *app.RequestContext) {
 	var req share.ShareBasicDetailRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		c.String(consts.StatusBadRequest, err.Error())
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
 		return
 	}
 
-	sbQ := q.ShareBasic
-	rpQ := q.RepositoryPool
-	_, err = sbQ.Where(sbQ.Identity.Eq(req.Identity)).Update(sbQ.ClickNum, sbQ.ClickNum.Add(1))
+	detail, err := service.Shares.Detail(ctx, req.Identity)
 	if err != nil {
-		c.String(consts.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
-	var reply share.ShareBasicDetailReply
-	err = sbQ.LeftJoin(rpQ, sbQ.RepositoryIdentity.EqCol(rpQ.Identity)).Where(sbQ.Identity.Eq(req.Identity)).Scan(&reply)
-	if err != nil {
-		c.String(consts.StatusInternalServerError, err.Error())
-		return
-	}
-
-	c.JSON(consts.StatusOK, &reply)
+	c.JSON(consts.StatusOK, &share.ShareBasicDetailReply{
+		RepositoryIdentity: detail.RepositoryIdentity,
+		Name:               detail.Name,
+		Ext:                detail.Ext,
+		Size:               detail.Size,
+		Path:               detail.Path,
+	})
 }
 
 // ShareBasicCreate .
@@ -51,29 +46,21 @@ func ShareBasicCreate(ctx context.Context, c *app.RequestContext) {
 	var req share.ShareBasicCreateRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		c.String(consts.StatusBadRequest, err.Error())
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
 		return
 	}
 
-	uuid, err := random.UUIdV4()
-	if err != nil {
-		c.String(consts.StatusInternalServerError, err.Error())
-		return
-	}
-	sb := entity.ShareBasic{
-		Identity:               uuid,
-		UserIdentity:           "",
+	identity, err := service.Shares.Create(ctx, mw.UserIdentity(c), &service.ShareCreateRequest{
 		UserRepositoryIdentity: req.UserRepositoryIdentity,
 		ExpiredTime:            req.ExpiredTime,
-		ClickNum:               0,
-	}
-	if err := q.ShareBasic.Create(&sb); err != nil {
-		c.String(consts.StatusInternalServerError, err.Error())
+	})
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.JSON(consts.StatusOK, share.ShareBasicCreateReply{
-		Identity: uuid,
+		Identity: identity,
 	})
 }
 
@@ -84,36 +71,40 @@ func ShareBasicSave(ctx context.Context, c *app.RequestContext) {
 	var req share.ShareBasicSaveRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		c.String(consts.StatusBadRequest, err.Error())
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
 		return
 	}
 
-	rp, err := q.RepositoryPool.Where(q.RepositoryPool.Identity.Eq(req.RepositoryIdentity)).First()
+	identity, err := service.Shares.Save(ctx, mw.UserIdentity(c), &service.ShareSaveRequest{
+		RepositoryIdentity: req.RepositoryIdentity,
+		ParentID:           req.ParentId,
+	})
 	if err != nil {
-		c.String(consts.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
-	uuid, err := random.UUIdV4()
+	c.JSON(consts.StatusOK, share.ShareBasicSaveReply{
+		Identity: identity,
+	})
+}
+
+// ShareBasicRevoke .
+// @router /share/basic/revoke [POST]
+func ShareBasicRevoke(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req share.ShareBasicRevokeRequest
+	err = c.BindAndValidate(&req)
 	if err != nil {
-		c.String(consts.StatusInternalServerError, err.Error())
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
 		return
 	}
-	ur := entity.UserRepository{
-		Identity:           uuid,
-		UserIdentity:       "",
-		ParentID:           int32(req.ParentId),
-		RepositoryIdentity: req.RepositoryIdentity,
-		Ext:                rp.Ext,
-		Name:               rp.Name,
-	}
 
-	if err := q.UserRepository.Create(&ur); err != nil {
-		c.String(consts.StatusInternalServerError, err.Error())
+	err = service.Shares.Revoke(ctx, mw.UserIdentity(c), req.Identity)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	c.JSON(consts.StatusOK, share.ShareBasicSaveReply{
-		Identity: uuid,
-	})
+	c.JSON(consts.StatusOK, &share.ShareBasicRevokeReply{})
 }
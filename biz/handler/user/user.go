@@ -3,17 +3,18 @@
 package user
 
 import (
-	"cloud-storage/biz/dal/query"
+	"cloud-storage/biz/errno"
 	user "cloud-storage/biz/model/user"
+	"cloud-storage/biz/mw"
+	"cloud-storage/biz/service"
 	"context"
-	"crypto/md5"
-	"fmt"
+	"strings"
+	"time"
+
 	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/cloudwego/hertz/pkg/protocol/consts"
 )
 
-var q = query.Q
-
 // UserLogin .
 // @router /user/login [POST]
 func UserLogin(ctx context.Context, c *app.RequestContext) {
@@ -21,19 +22,48 @@ func UserLogin(ctx context.Context, c *app.RequestContext) {
 	var req user.LoginRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		c.String(consts.StatusBadRequest, err.Error())
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
 		return
 	}
 
-	pwdMd5 := fmt.Sprintf("%x", md5.Sum([]byte(req.Password)))
-	userBasic, err := q.UserBasic.Where(q.UserBasic.Name.Eq(req.Name), q.UserBasic.Password.Eq(pwdMd5)).First()
+	res, err := service.Users.Login(ctx, req.Name, req.Password, req.DeviceName)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(consts.StatusOK, &user.LoginReply{
+		Token:             res.Token,
+		RefreshToken:      "",
+		TwoFactorRequired: res.Challenge != "",
+		ChallengeToken:    res.Challenge,
+	})
+}
+
+// UserLoginTwoFactor .
+// @router /user/login/2fa [POST]
+func UserLoginTwoFactor(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.LoginTwoFactorRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+
+	identity, err := mw.ChallengeIdentity(req.ChallengeToken)
+	if err != nil {
+		c.Error(errno.Wrap(errno.Unauthenticated, err, "invalid or expired login challenge"))
+		return
+	}
+	token, err := service.Users.LoginSecondFactor(ctx, identity, req.Code, req.DeviceName)
 	if err != nil {
-		c.String(consts.StatusBadRequest, "username or password error")
+		c.Error(err)
 		return
 	}
 
 	c.JSON(consts.StatusOK, &user.LoginReply{
-		Token:        userBasic.Identity,
+		Token:        token,
 		RefreshToken: "",
 	})
 }
@@ -45,7 +75,7 @@ func UserDetail(ctx context.Context, c *app.RequestContext) {
 	var req user.UserDetailRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		c.String(consts.StatusBadRequest, err.Error())
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
 		return
 	}
 
@@ -61,7 +91,7 @@ func MailCodeSendRegister(ctx context.Context, c *app.RequestContext) {
 	var req user.MailCodeSendRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		c.String(consts.StatusBadRequest, err.Error())
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
 		return
 	}
 
@@ -77,7 +107,7 @@ func UserRegister(ctx context.Context, c *app.RequestContext) {
 	var req user.UserRegisterRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		c.String(consts.StatusBadRequest, err.Error())
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
 		return
 	}
 
@@ -86,6 +116,46 @@ func UserRegister(ctx context.Context, c *app.RequestContext) {
 	c.JSON(consts.StatusOK, resp)
 }
 
+// UserPasswordForgot .
+// @router /user/password/forgot [POST]
+func UserPasswordForgot(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.UserPasswordForgotRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+
+	err = service.Users.RequestPasswordReset(ctx, req.Email)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(consts.StatusOK, &user.UserPasswordForgotReply{})
+}
+
+// UserPasswordReset .
+// @router /user/password/reset [POST]
+func UserPasswordReset(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.UserPasswordResetRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+
+	err = service.Users.ResetPasswordWithToken(ctx, req.Token, req.Password)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(consts.StatusOK, &user.UserPasswordResetReply{})
+}
+
 // RefreshAuthorization .
 // @router /refresh/authorization [POST]
 func RefreshAuthorization(ctx context.Context, c *app.RequestContext) {
@@ -93,11 +163,429 @@ func RefreshAuthorization(ctx context.Context, c *app.RequestContext) {
 	var req user.RefreshAuthorizationRequest
 	err = c.BindAndValidate(&req)
 	if err != nil {
-		c.String(consts.StatusBadRequest, err.Error())
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
 		return
 	}
 
-	resp := new(user.RefreshAuthorizationReply)
+	token, err := mw.RefreshToken(ctx, c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
-	c.JSON(consts.StatusOK, resp)
+	c.JSON(consts.StatusOK, &user.RefreshAuthorizationReply{
+		Token:        token,
+		RefreshToken: "",
+	})
+}
+
+// UserAccessKeyCreate .
+// @router /user/access/key/create [POST]
+func UserAccessKeyCreate(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.UserAccessKeyCreateRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+
+	key, err := service.Users.CreateAccessKey(ctx, mw.UserIdentity(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(consts.StatusOK, &user.UserAccessKeyCreateReply{
+		AccessKeyId:     key.ID,
+		SecretAccessKey: key.Secret,
+	})
+}
+
+// UserAccessKeyList .
+// @router /user/access/key/list [POST]
+func UserAccessKeyList(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.UserAccessKeyListRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+
+	keys, err := service.Users.ListAccessKeys(ctx, mw.UserIdentity(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	list := make([]*user.UserAccessKey, 0, len(keys))
+	for _, key := range keys {
+		list = append(list, &user.UserAccessKey{
+			AccessKeyId: key.AccessKeyID,
+			CreatedAt:   key.CreatedAt.Unix(),
+		})
+	}
+
+	c.JSON(consts.StatusOK, &user.UserAccessKeyListReply{
+		List: list,
+	})
+}
+
+// UserAccessKeyDelete .
+// @router /user/access/key/delete [DELETE]
+func UserAccessKeyDelete(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.UserAccessKeyDeleteRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+
+	err = service.Users.DeleteAccessKey(ctx, mw.UserIdentity(c), req.AccessKeyId)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(consts.StatusOK, &user.UserAccessKeyDeleteReply{})
+}
+
+// UserTokenCreate .
+// @router /user/token/create [POST]
+func UserTokenCreate(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.UserTokenCreateRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+
+	var expiresAt time.Time
+	if req.ExpiresAt > 0 {
+		expiresAt = time.Unix(req.ExpiresAt, 0)
+	}
+	token, err := service.Users.CreateAPIToken(ctx, mw.UserIdentity(c), &service.APITokenCreateRequest{
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(consts.StatusOK, &user.UserTokenCreateReply{
+		Identity: token.ID,
+		Token:    token.Token,
+	})
+}
+
+// UserTokenList .
+// @router /user/token/list [POST]
+func UserTokenList(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.UserTokenListRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+
+	tokens, err := service.Users.ListAPITokens(ctx, mw.UserIdentity(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	list := make([]*user.UserToken, 0, len(tokens))
+	for _, token := range tokens {
+		list = append(list, &user.UserToken{
+			Identity:   token.Identity,
+			Name:       token.Name,
+			Prefix:     token.TokenPrefix,
+			Scopes:     strings.Split(token.Scopes, ","),
+			ExpiresAt:  unixOrZero(token.ExpiresAt),
+			LastUsedAt: unixOrZero(token.LastUsedAt),
+			LastUsedIp: token.LastUsedIP,
+			CreatedAt:  token.CreatedAt.Unix(),
+		})
+	}
+
+	c.JSON(consts.StatusOK, &user.UserTokenListReply{
+		List: list,
+	})
+}
+
+// UserTokenRevoke .
+// @router /user/token/revoke [POST]
+func UserTokenRevoke(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.UserTokenRevokeRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+
+	err = service.Users.RevokeAPIToken(ctx, mw.UserIdentity(c), req.Identity)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(consts.StatusOK, &user.UserTokenRevokeReply{})
+}
+
+// UserSessionList .
+// @router /user/session/list [POST]
+func UserSessionList(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.UserSessionListRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+
+	sessions, err := service.Users.ListSessions(ctx, mw.UserIdentity(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	current := mw.SessionIdentity(c)
+	list := make([]*user.UserSession, 0, len(sessions))
+	for _, session := range sessions {
+		list = append(list, &user.UserSession{
+			Identity:   session.Identity,
+			DeviceName: session.DeviceName,
+			UserAgent:  session.UserAgent,
+			Ip:         session.IP,
+			CreatedAt:  session.CreatedAt.Unix(),
+			LastSeenAt: session.LastSeenAt.Unix(),
+			ExpiresAt:  session.ExpiresAt.Unix(),
+			Current:    session.Identity == current,
+		})
+	}
+
+	c.JSON(consts.StatusOK, &user.UserSessionListReply{
+		List: list,
+	})
+}
+
+// UserSessionRevoke .
+// @router /user/session/revoke [POST]
+func UserSessionRevoke(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.UserSessionRevokeRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+
+	err = service.Users.RevokeSession(ctx, mw.UserIdentity(c), req.Identity)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(consts.StatusOK, &user.UserSessionRevokeReply{})
+}
+
+// UserSessionRevokeOthers .
+// @router /user/session/revoke/others [POST]
+func UserSessionRevokeOthers(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.UserSessionRevokeOthersRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+
+	count, err := service.Users.RevokeOtherSessions(ctx, mw.UserIdentity(c), mw.SessionIdentity(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(consts.StatusOK, &user.UserSessionRevokeOthersReply{
+		Count: count,
+	})
+}
+
+// unixOrZero returns t in Unix seconds, or 0 for the zero time that stands
+// for a NULL column.
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// UserSshKeyCreate .
+// @router /user/ssh/key/create [POST]
+func UserSshKeyCreate(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.UserSshKeyCreateRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+
+	fingerprint, err := service.Users.CreateSSHKey(ctx, mw.UserIdentity(c), &service.SSHKeyCreateRequest{
+		Name:      req.Name,
+		PublicKey: req.PublicKey,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(consts.StatusOK, &user.UserSshKeyCreateReply{
+		Fingerprint: fingerprint,
+	})
+}
+
+// UserSshKeyList .
+// @router /user/ssh/key/list [POST]
+func UserSshKeyList(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.UserSshKeyListRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+
+	keys, err := service.Users.ListSSHKeys(ctx, mw.UserIdentity(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	list := make([]*user.UserSshKey, 0, len(keys))
+	for _, key := range keys {
+		list = append(list, &user.UserSshKey{
+			Name:        key.Name,
+			Fingerprint: key.Fingerprint,
+			CreatedAt:   key.CreatedAt.Unix(),
+		})
+	}
+
+	c.JSON(consts.StatusOK, &user.UserSshKeyListReply{
+		List: list,
+	})
+}
+
+// UserSshKeyDelete .
+// @router /user/ssh/key/delete [DELETE]
+func UserSshKeyDelete(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.UserSshKeyDeleteRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+
+	err = service.Users.DeleteSSHKey(ctx, mw.UserIdentity(c), req.Fingerprint)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(consts.StatusOK, &user.UserSshKeyDeleteReply{})
+}
+
+// UserTwoFactorEnroll .
+// @router /user/2fa/enroll [POST]
+func UserTwoFactorEnroll(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.UserTwoFactorEnrollRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+
+	enrollment, err := service.Users.EnrollTOTP(ctx, mw.UserIdentity(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(consts.StatusOK, &user.UserTwoFactorEnrollReply{
+		Secret: enrollment.Secret,
+		Uri:    enrollment.URI,
+	})
+}
+
+// UserTwoFactorVerify .
+// @router /user/2fa/verify [POST]
+func UserTwoFactorVerify(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.UserTwoFactorVerifyRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+
+	codes, err := service.Users.EnableTOTP(ctx, mw.UserIdentity(c), req.Code)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(consts.StatusOK, &user.UserTwoFactorVerifyReply{
+		RecoveryCodes: codes,
+	})
+}
+
+// UserTwoFactorDisable .
+// @router /user/2fa/disable [POST]
+func UserTwoFactorDisable(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.UserTwoFactorDisableRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+
+	err = service.Users.DisableTOTP(ctx, mw.UserIdentity(c), req.Code)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(consts.StatusOK, &user.UserTwoFactorDisableReply{})
+}
+
+// UserRecoveryCodeRegenerate .
+// @router /user/2fa/recovery/codes [POST]
+func UserRecoveryCodeRegenerate(ctx context.Context, c *app.RequestContext) {
+	var err error
+	var req user.UserRecoveryCodeRegenerateRequest
+	err = c.BindAndValidate(&req)
+	if err != nil {
+		c.Error(errno.New(errno.InvalidArgument, "%v", err))
+		return
+	}
+
+	codes, err := service.Users.RegenerateRecoveryCodes(ctx, mw.UserIdentity(c), req.Code)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(consts.StatusOK, &user.UserRecoveryCodeRegenerateReply{
+		RecoveryCodes: codes,
+	})
 }
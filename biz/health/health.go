@@ -0,0 +1,111 @@
+// Package health answers the probes of orchestrators: /healthz reports
+// whether the process is alive and /readyz whether it can serve requests,
+// i.e. reach the database, find its schema at the required version and
+// store content.
+//
+// On SIGTERM, /readyz fails while the server keeps serving for a delay, see
+// SignalWaiter, so that no new requests are sent its way by the time it
+// stops listening.
+package health
+
+import (
+	"cloud-storage/biz/blob"
+	"cloud-storage/biz/dal"
+	"cloud-storage/biz/logging"
+	"context"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/cloudwego/hertz/pkg/common/utils"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+)
+
+// checkTimeout bounds the time readiness checks take, so that a hung
+// database fails the probe rather than timing it out.
+const checkTimeout = 3 * time.Second
+
+var log = logging.Logger("health")
+
+var draining atomic.Bool
+
+// checks are what a server needs to be ready, by name.
+var checks = []struct {
+	name  string
+	check func(ctx context.Context) error
+}{
+	{"database", dal.Ping},
+	{"migrations", func(context.Context) error { return dal.CheckSchema() }},
+	{"storage", func(context.Context) error { return blob.Writable() }},
+}
+
+// Register adds the probes to r.
+func Register(r *server.Hertz) {
+	r.GET("/healthz", Live)
+	r.GET("/readyz", Ready)
+}
+
+// Drain makes the server report not ready from now on.
+func Drain() {
+	draining.Store(true)
+}
+
+// SignalWaiter returns the signal waiter of Hertz.SetCustomSignalWaiter.
+// On SIGINT or SIGTERM it drains the server and lets it serve for delay, in
+// which the orchestrator sees /readyz fail, before the graceful shutdown
+// closes the listener. A second signal cuts the delay short.
+func SignalWaiter(delay time.Duration) func(errCh chan error) error {
+	return func(errCh chan error) error {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		select {
+		case sig := <-signals:
+			log.Info("draining before shutdown", "signal", sig.String(), "delay", delay)
+		case err := <-errCh:
+			return err
+		}
+		Drain()
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-signals:
+		case err := <-errCh:
+			return err
+		}
+		return nil
+	}
+}
+
+// Live reports that the process serves requests at all.
+func Live(ctx context.Context, c *app.RequestContext) {
+	c.JSON(consts.StatusOK, utils.H{"status": "ok"})
+}
+
+// Ready runs the readiness checks. The causes of failures are logged
+// rather than shown, as the probe needs no authentication.
+func Ready(ctx context.Context, c *app.RequestContext) {
+	if draining.Load() {
+		c.JSON(consts.StatusServiceUnavailable, utils.H{"status": "draining"})
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	code, status := consts.StatusOK, "ok"
+	results := make(map[string]string, len(checks))
+	for _, ch := range checks {
+		if err := ch.check(ctx); err != nil {
+			log.WarnContext(ctx, "readiness check failed", "check", ch.name, "error", err)
+			results[ch.name] = "failed"
+			code, status = consts.StatusServiceUnavailable, "unavailable"
+			continue
+		}
+		results[ch.name] = "ok"
+	}
+	c.JSON(code, utils.H{"status": status, "checks": results})
+}
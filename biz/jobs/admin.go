@@ -0,0 +1,82 @@
+package jobs
+
+import (
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/dal/query"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gen"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrNotFound is returned for a job that does not exist.
+	ErrNotFound = errors.New("job not found")
+	// ErrNotFailed is returned when retrying a job that did not fail.
+	ErrNotFailed = errors.New("job has not failed")
+)
+
+// List returns a page of the jobs, newest first, and the total number of
+// jobs. A non-empty state or type only lists the jobs in that state or of
+// that type.
+func List(ctx context.Context, state, typ string, offset, limit int) ([]*entity.Job, int64, error) {
+	jQ := query.Job
+	var conds []gen.Condition
+	if state != "" {
+		conds = append(conds, jQ.State.Eq(state))
+	}
+	if typ != "" {
+		conds = append(conds, jQ.Type.Eq(typ))
+	}
+	return jQ.WithContext(ctx).Where(conds...).Order(jQ.ID.Desc()).FindByPage(offset, limit)
+}
+
+// Get returns the job with the given ID.
+func Get(ctx context.Context, id uint32) (*entity.Job, error) {
+	jQ := query.Job
+	j, err := jQ.WithContext(ctx).WriteDB().Where(jQ.ID.Eq(id)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	return j, err
+}
+
+// Retry queues a failed job to run again now, with all its attempts.
+func Retry(ctx context.Context, id uint32) error {
+	jQ := query.Job
+	info, err := jQ.WithContext(ctx).Where(jQ.ID.Eq(id), jQ.State.Eq(StateFailed)).UpdateSimple(
+		jQ.State.Value(StatePending),
+		jQ.Attempts.Value(0),
+		jQ.RunAt.Value(time.Now()),
+		jQ.FinishedAt.Null(),
+	)
+	if err != nil {
+		return err
+	}
+	if info.RowsAffected == 0 {
+		if _, err := Get(ctx, id); err != nil {
+			return err
+		}
+		return ErrNotFailed
+	}
+	wake()
+	return nil
+}
+
+// prune deletes the jobs that finished longer than the retention ago.
+var prune = Define("jobs.prune", Options{}, func(ctx context.Context, _ struct{}) error {
+	if cfg.Retention <= 0 {
+		return nil
+	}
+	jQ := query.Job
+	_, err := jQ.WithContext(ctx).
+		Where(jQ.State.In(StateSucceeded, StateFailed), jQ.FinishedAt.Lt(time.Now().Add(-cfg.Retention))).
+		Delete()
+	return err
+})
+
+func init() {
+	Schedule("jobs.prune", "@hourly", prune, struct{}{})
+}
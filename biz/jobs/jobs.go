@@ -0,0 +1,196 @@
+// Package jobs runs work off the request path from a queue kept in the
+// database, so that it needs no broker and survives restarts.
+//
+// Every kind of job is defined once with Define, which ties a name to a
+// handler taking a typed payload. Jobs are rows of the job table: a worker
+// claims a due job by moving it from pending to running under a lease that
+// it renews while the handler runs, so that the jobs of a process that died
+// are taken over by others once the lease expires. Failed jobs are retried
+// with exponential backoff until they run out of attempts.
+package jobs
+
+import (
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/dal/query"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// The states of a job.
+const (
+	StatePending   = "pending"
+	StateRunning   = "running"
+	StateSucceeded = "succeeded"
+	StateFailed    = "failed"
+)
+
+// Options tune how the jobs of a type run.
+type Options struct {
+	// Concurrency is how many jobs of the type a process runs at once, 1 if 0
+	Concurrency int
+	// MaxAttempts is how often a job runs before it fails for good, 5 if 0
+	MaxAttempts int
+	// Timeout cancels the context of an attempt running longer, none if 0
+	Timeout time.Duration
+}
+
+// Type is a kind of job whose payload is a T, stored as JSON.
+type Type[T any] struct {
+	name string
+}
+
+// handler runs the jobs of a type.
+type handler struct {
+	name string
+	opts Options
+	run  func(ctx context.Context, payload string) error
+	// slots holds a token for every job of the type running in this process
+	slots chan struct{}
+}
+
+var (
+	mu       sync.Mutex
+	handlers = make(map[string]*handler)
+)
+
+// Define registers fn as the handler of the jobs called name. It is meant
+// to be called while initializing packages and panics if name is taken.
+func Define[T any](name string, opts Options, fn func(ctx context.Context, payload T) error) *Type[T] {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 5
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := handlers[name]; ok {
+		panic("jobs: " + name + " is defined twice")
+	}
+	handlers[name] = &handler{
+		name: name,
+		opts: opts,
+		run: func(ctx context.Context, payload string) error {
+			var p T
+			if err := json.Unmarshal([]byte(payload), &p); err != nil {
+				return Permanent(fmt.Errorf("invalid payload: %w", err))
+			}
+			return fn(ctx, p)
+		},
+		slots: make(chan struct{}, opts.Concurrency),
+	}
+	return &Type[T]{name: name}
+}
+
+// Name returns the name the type was defined with.
+func (t *Type[T]) Name() string {
+	return t.name
+}
+
+// EnqueueOption changes how Enqueue queues a job.
+type EnqueueOption func(*enqueueOptions)
+
+type enqueueOptions struct {
+	runAt time.Time
+	key   string
+	tx    *query.Query
+}
+
+// At delays the job until t.
+func At(t time.Time) EnqueueOption {
+	return func(o *enqueueOptions) { o.runAt = t }
+}
+
+// After delays the job by d.
+func After(d time.Duration) EnqueueOption {
+	return func(o *enqueueOptions) { o.runAt = time.Now().Add(d) }
+}
+
+// Key skips queueing the job if a job of the same type and key is already
+// pending, e.g. to index a blob once however often it is uploaded.
+func Key(key string) EnqueueOption {
+	return func(o *enqueueOptions) { o.key = key }
+}
+
+// In queues the job as part of the transaction tx, so that it only runs if
+// the transaction commits.
+func In(tx *query.Query) EnqueueOption {
+	return func(o *enqueueOptions) { o.tx = tx }
+}
+
+// Enqueue queues a job of type t with the given payload, to run as soon as
+// a worker is free unless delayed with At or After.
+func (t *Type[T]) Enqueue(ctx context.Context, payload T, opts ...EnqueueOption) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	o := enqueueOptions{runAt: time.Now(), tx: query.Q}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return enqueue(ctx, o.tx, t.name, string(data), o.key, o.runAt)
+}
+
+func enqueue(ctx context.Context, q *query.Query, name, payload, key string, runAt time.Time) error {
+	mu.Lock()
+	h, ok := handlers[name]
+	mu.Unlock()
+	if !ok {
+		return fmt.Errorf("jobs: %s is not defined", name)
+	}
+
+	jQ := q.Job
+	if key != "" {
+		// Two jobs of the same key may still slip in when queued at the same
+		// moment, which handlers have to put up with anyway
+		n, err := jQ.WithContext(ctx).WriteDB().
+			Where(jQ.Type.Eq(name), jQ.DedupKey.Eq(key), jQ.State.Eq(StatePending)).
+			Count()
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			return nil
+		}
+	}
+	err := jQ.WithContext(ctx).Omit(jQ.LockedUntil, jQ.FinishedAt).Create(&entity.Job{
+		Type:        name,
+		Payload:     payload,
+		DedupKey:    key,
+		State:       StatePending,
+		MaxAttempts: int32(h.opts.MaxAttempts),
+		RunAt:       runAt,
+	})
+	if err != nil {
+		return err
+	}
+	wake()
+	return nil
+}
+
+// permanentError fails a job without retrying it.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so that the job failing with it is not retried, e.g.
+// because the blob it works on is gone.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+func isPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
@@ -0,0 +1,106 @@
+package jobs
+
+import (
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/dal/query"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"gorm.io/gorm"
+)
+
+// scheduleInterval is how often the schedules are checked. Cron specs have
+// a resolution of a minute, so this keeps them within a quarter of it.
+const scheduleInterval = 15 * time.Second
+
+// entry queues a job whenever its schedule comes due.
+type entry struct {
+	name     string
+	schedule cron.Schedule
+	typeName string
+	payload  string
+}
+
+var (
+	entriesMu sync.Mutex
+	entries   []*entry
+)
+
+// Schedule queues a job of type t with the given payload at the times of
+// spec, a standard cron spec such as "30 3 * * *" or a descriptor such as
+// "@daily". Every process runs the schedules, but each time is taken by
+// one of them only. name identifies the schedule in the database; like
+// Define, Schedule is meant to be called while initializing packages and
+// panics on a malformed spec.
+func Schedule[T any](name, spec string, t *Type[T], payload T) {
+	s, err := cron.ParseStandard(spec)
+	if err != nil {
+		panic("jobs: schedule " + name + ": " + err.Error())
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		panic("jobs: schedule " + name + ": " + err.Error())
+	}
+	entriesMu.Lock()
+	defer entriesMu.Unlock()
+	entries = append(entries, &entry{name: name, schedule: s, typeName: t.name, payload: string(data)})
+}
+
+func schedule(ctx context.Context) {
+	ticker := time.NewTicker(scheduleInterval)
+	defer ticker.Stop()
+	for {
+		entriesMu.Lock()
+		es := append([]*entry(nil), entries...)
+		entriesMu.Unlock()
+		for _, e := range es {
+			if err := e.check(ctx); err != nil && ctx.Err() == nil {
+				log.ErrorContext(ctx, "failed to check schedule", "schedule", e.name, "error", err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check queues the job of the entry if it is due. The time of the next run
+// is moved on with a conditional update in the same transaction as the job
+// is queued, so that only the process whose update wins queues it.
+func (e *entry) check(ctx context.Context) error {
+	jsQ := query.JobSchedule
+	now := time.Now()
+	js, err := jsQ.WithContext(ctx).WriteDB().Where(jsQ.Name.Eq(e.name)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = jsQ.WithContext(ctx).Create(&entity.JobSchedule{Name: e.name, NextRunAt: e.schedule.Next(now)})
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if js.NextRunAt.After(now) {
+		return nil
+	}
+
+	return query.Q.Transaction(func(tx *query.Query) error {
+		jsQ := tx.JobSchedule
+		info, err := jsQ.WithContext(ctx).
+			Where(jsQ.ID.Eq(js.ID), jsQ.NextRunAt.Eq(js.NextRunAt)).
+			UpdateSimple(jsQ.NextRunAt.Value(e.schedule.Next(now)))
+		if err != nil || info.RowsAffected == 0 {
+			return err
+		}
+		// A run still pending from last time, e.g. while no worker was free,
+		// covers this one too
+		return enqueue(ctx, tx, e.typeName, e.payload, "schedule:"+e.name, now)
+	})
+}
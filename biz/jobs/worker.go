@@ -0,0 +1,285 @@
+package jobs
+
+import (
+	"cloud-storage/biz/config"
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/dal/query"
+	"cloud-storage/biz/logging"
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/duke-git/lancet/v2/random"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gen"
+)
+
+const (
+	// backoffBase and backoffMax bound the delay before a failed job runs
+	// again, which doubles with every attempt
+	backoffBase = 10 * time.Second
+	backoffMax  = time.Hour
+	// maxErrorSize caps the error message kept with a failed attempt
+	maxErrorSize = 4096
+)
+
+var (
+	log    = logging.Logger("jobs")
+	tracer = otel.Tracer("cloud-storage/biz/jobs")
+)
+
+var (
+	cfg      config.Jobs
+	workerID string
+	wakeup   = make(chan struct{}, 1)
+)
+
+// Start runs the workers of every defined job type and the schedules in the
+// background until ctx is done.
+func Start(ctx context.Context, c *config.Jobs) {
+	cfg = *c
+	host, _ := os.Hostname()
+	workerID = fmt.Sprintf("%s:%d:%s", host, os.Getpid(), random.RandString(6))
+	if len(workerID) > 64 {
+		workerID = workerID[len(workerID)-64:]
+	}
+
+	go poll(ctx)
+	go schedule(ctx)
+}
+
+// wake makes the workers of this process look for jobs before the next
+// poll, as there is one they might take.
+func wake() {
+	select {
+	case wakeup <- struct{}{}:
+	default:
+	}
+}
+
+func poll(ctx context.Context) {
+	ticker := time.NewTicker(cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		reclaim(ctx)
+		claimAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-wakeup:
+		}
+	}
+}
+
+// reclaim releases the jobs whose lease expired, as the process running
+// them is gone. They were claimed by an attempt, so those out of attempts
+// fail.
+func reclaim(ctx context.Context) {
+	jQ := query.Job
+	now := time.Now()
+	expired := []gen.Condition{jQ.State.Eq(StateRunning), jQ.LockedUntil.Lt(now)}
+	_, err := jQ.WithContext(ctx).Where(expired...).Where(jQ.Attempts.GteCol(jQ.MaxAttempts)).UpdateSimple(
+		jQ.State.Value(StateFailed),
+		jQ.LockedBy.Value(""),
+		jQ.LastError.Value("lease expired"),
+		jQ.FinishedAt.Value(now),
+	)
+	if err == nil {
+		_, err = jQ.WithContext(ctx).Where(expired...).UpdateSimple(
+			jQ.State.Value(StatePending),
+			jQ.LockedBy.Value(""),
+			jQ.LastError.Value("lease expired"),
+			jQ.RunAt.Value(now),
+		)
+	}
+	if err != nil && ctx.Err() == nil {
+		log.ErrorContext(ctx, "failed to reclaim expired jobs", "error", err)
+	}
+}
+
+// claimAll claims as many due jobs of every type as there are free slots.
+func claimAll(ctx context.Context) {
+	mu.Lock()
+	hs := make([]*handler, 0, len(handlers))
+	for _, h := range handlers {
+		hs = append(hs, h)
+	}
+	mu.Unlock()
+
+	for _, h := range hs {
+		free := cap(h.slots) - len(h.slots)
+		if free == 0 || ctx.Err() != nil {
+			continue
+		}
+		jQ := query.Job
+		due, err := jQ.WithContext(ctx).WriteDB().
+			Where(jQ.Type.Eq(h.name), jQ.State.Eq(StatePending), jQ.RunAt.Lte(time.Now())).
+			Order(jQ.RunAt, jQ.ID).
+			Limit(free).
+			Find()
+		if err != nil {
+			if ctx.Err() == nil {
+				log.ErrorContext(ctx, "failed to look for jobs", "type", h.name, "error", err)
+			}
+			continue
+		}
+		for _, j := range due {
+			if !claim(ctx, j) {
+				continue
+			}
+			h.slots <- struct{}{}
+			go func() {
+				defer func() { <-h.slots }()
+				run(ctx, h, j)
+			}()
+		}
+	}
+}
+
+// claim moves a pending job to running under the lease of this process.
+// It reports false if another process claimed it first.
+func claim(ctx context.Context, j *entity.Job) bool {
+	jQ := query.Job
+	now := time.Now()
+	info, err := jQ.WithContext(ctx).Where(jQ.ID.Eq(j.ID), jQ.State.Eq(StatePending)).UpdateSimple(
+		jQ.State.Value(StateRunning),
+		jQ.LockedBy.Value(workerID),
+		jQ.LockedUntil.Value(now.Add(cfg.Lease)),
+		jQ.Attempts.Add(1),
+	)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.ErrorContext(ctx, "failed to claim job", "job_id", j.ID, "error", err)
+		}
+		return false
+	}
+	j.Attempts++
+	return info.RowsAffected == 1
+}
+
+// run runs an attempt of a claimed job and records its outcome.
+func run(ctx context.Context, h *handler, j *entity.Job) {
+	runCtx, cancel := context.WithCancel(ctx)
+	if h.opts.Timeout > 0 {
+		runCtx, cancel = context.WithTimeout(ctx, h.opts.Timeout)
+	}
+	defer cancel()
+	go renew(runCtx, cancel, j.ID)
+
+	runCtx, span := tracer.Start(runCtx, "job "+j.Type, trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.Int64("job.id", int64(j.ID)),
+			attribute.Int64("job.attempt", int64(j.Attempts)),
+		))
+	err := call(runCtx, h, j.Payload)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+	// Record the outcome even when shutting down, so that the job does not
+	// wait for its lease to expire
+	finish(context.WithoutCancel(ctx), j, err)
+}
+
+// call runs the handler, turning a panic into an error.
+func call(ctx context.Context, h *handler, payload string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+	return h.run(ctx, payload)
+}
+
+// renew extends the lease of a running job until ctx is done. If the lease
+// was lost, e.g. because the database was unreachable for longer than the
+// lease, another process may run the job now, so this attempt is canceled.
+func renew(ctx context.Context, cancel context.CancelFunc, id uint32) {
+	ticker := time.NewTicker(cfg.Lease / 3)
+	defer ticker.Stop()
+	jQ := query.Job
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		info, err := jQ.WithContext(ctx).
+			Where(jQ.ID.Eq(id), jQ.State.Eq(StateRunning), jQ.LockedBy.Eq(workerID)).
+			UpdateSimple(jQ.LockedUntil.Value(time.Now().Add(cfg.Lease)))
+		if err != nil {
+			if ctx.Err() == nil {
+				log.WarnContext(ctx, "failed to renew the lease of job", "job_id", id, "error", err)
+			}
+			continue
+		}
+		if info.RowsAffected == 0 {
+			log.WarnContext(ctx, "lost the lease of job", "job_id", id)
+			cancel()
+			return
+		}
+	}
+}
+
+// finish records the outcome of an attempt: success, another attempt after
+// a backoff, or failure once out of attempts.
+func finish(ctx context.Context, j *entity.Job, err error) {
+	jQ := query.Job
+	now := time.Now()
+	update := jQ.WithContext(ctx).Where(jQ.ID.Eq(j.ID), jQ.State.Eq(StateRunning), jQ.LockedBy.Eq(workerID))
+	var updateErr error
+	switch {
+	case err == nil:
+		_, updateErr = update.UpdateSimple(
+			jQ.State.Value(StateSucceeded),
+			jQ.LockedBy.Value(""),
+			jQ.LastError.Value(""),
+			jQ.FinishedAt.Value(now),
+		)
+	case isPermanent(err) || j.Attempts >= j.MaxAttempts:
+		log.ErrorContext(ctx, "job failed for good", "type", j.Type, "job_id", j.ID, "attempt", j.Attempts, "error", err)
+		_, updateErr = update.UpdateSimple(
+			jQ.State.Value(StateFailed),
+			jQ.LockedBy.Value(""),
+			jQ.LastError.Value(truncate(err.Error())),
+			jQ.FinishedAt.Value(now),
+		)
+	default:
+		log.WarnContext(ctx, "job failed", "type", j.Type, "job_id", j.ID, "attempt", j.Attempts, "max_attempts", j.MaxAttempts, "error", err)
+		_, updateErr = update.UpdateSimple(
+			jQ.State.Value(StatePending),
+			jQ.LockedBy.Value(""),
+			jQ.LastError.Value(truncate(err.Error())),
+			jQ.RunAt.Value(now.Add(backoff(int(j.Attempts)))),
+		)
+	}
+	if updateErr != nil {
+		log.ErrorContext(ctx, "failed to record the outcome of job", "job_id", j.ID, "error", updateErr)
+	}
+}
+
+// backoff returns the delay before the attempt after the given one, with
+// up to 20% jitter so that jobs failing together do not retry together.
+func backoff(attempt int) time.Duration {
+	d := backoffMax
+	if attempt < 20 {
+		d = min(backoffBase<<(attempt-1), backoffMax)
+	}
+	return d + time.Duration(rand.Int64N(int64(d)/5+1))
+}
+
+func truncate(s string) string {
+	if len(s) <= maxErrorSize {
+		return s
+	}
+	return strings.ToValidUTF8(s[:maxErrorSize], "")
+}
@@ -0,0 +1,57 @@
+package logging
+
+import (
+	"cloud-storage/biz/tracing"
+	"context"
+	"log/slog"
+	"sync"
+)
+
+type requestKey struct{}
+
+// request describes the request a context belongs to. The user is only
+// known once the request is authenticated, after the context was derived.
+type request struct {
+	id string
+
+	mu   sync.Mutex
+	user string
+}
+
+// WithRequest returns a copy of ctx whose records carry the request ID.
+func WithRequest(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestKey{}, &request{id: requestID})
+}
+
+// SetUser makes the records logged with ctx, or a context derived from it,
+// carry the identity of the user making the request. It does nothing if
+// ctx was not returned by WithRequest.
+func SetUser(ctx context.Context, userIdentity string) {
+	req, ok := ctx.Value(requestKey{}).(*request)
+	if !ok {
+		return
+	}
+	req.mu.Lock()
+	req.user = userIdentity
+	req.mu.Unlock()
+}
+
+func contextAttrs(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+	var attrs []slog.Attr
+	if req, ok := ctx.Value(requestKey{}).(*request); ok {
+		attrs = append(attrs, slog.String("request_id", req.id))
+		req.mu.Lock()
+		user := req.user
+		req.mu.Unlock()
+		if user != "" {
+			attrs = append(attrs, slog.String("user", user))
+		}
+	}
+	if traceID := tracing.TraceID(ctx); traceID != "" {
+		attrs = append(attrs, slog.String("trace_id", traceID))
+	}
+	return attrs
+}
@@ -0,0 +1,107 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// levelNotice sits between info and warn, like the notice level of hlog.
+const levelNotice = slog.LevelInfo + 2
+
+// hertzLogger passes the logs of hlog, used by Hertz itself, on to slog.
+// Its level and output follow the configuration of the logs rather than
+// SetLevel and SetOutput.
+type hertzLogger struct {
+	log *slog.Logger
+}
+
+func (l *hertzLogger) logf(ctx context.Context, level slog.Level, format string, v ...interface{}) {
+	if l.log.Enabled(ctx, level) {
+		l.log.Log(ctx, level, fmt.Sprintf(format, v...))
+	}
+}
+
+func (l *hertzLogger) logln(level slog.Level, v ...interface{}) {
+	if l.log.Enabled(context.Background(), level) {
+		l.log.Log(context.Background(), level, fmt.Sprint(v...))
+	}
+}
+
+func (l *hertzLogger) Trace(v ...interface{})  { l.logln(slog.LevelDebug, v...) }
+func (l *hertzLogger) Debug(v ...interface{})  { l.logln(slog.LevelDebug, v...) }
+func (l *hertzLogger) Info(v ...interface{})   { l.logln(slog.LevelInfo, v...) }
+func (l *hertzLogger) Notice(v ...interface{}) { l.logln(levelNotice, v...) }
+func (l *hertzLogger) Warn(v ...interface{})   { l.logln(slog.LevelWarn, v...) }
+func (l *hertzLogger) Error(v ...interface{})  { l.logln(slog.LevelError, v...) }
+
+func (l *hertzLogger) Fatal(v ...interface{}) {
+	l.logln(slog.LevelError, v...)
+	os.Exit(1)
+}
+
+func (l *hertzLogger) Tracef(format string, v ...interface{}) {
+	l.logf(context.Background(), slog.LevelDebug, format, v...)
+}
+
+func (l *hertzLogger) Debugf(format string, v ...interface{}) {
+	l.logf(context.Background(), slog.LevelDebug, format, v...)
+}
+
+func (l *hertzLogger) Infof(format string, v ...interface{}) {
+	l.logf(context.Background(), slog.LevelInfo, format, v...)
+}
+
+func (l *hertzLogger) Noticef(format string, v ...interface{}) {
+	l.logf(context.Background(), levelNotice, format, v...)
+}
+
+func (l *hertzLogger) Warnf(format string, v ...interface{}) {
+	l.logf(context.Background(), slog.LevelWarn, format, v...)
+}
+
+func (l *hertzLogger) Errorf(format string, v ...interface{}) {
+	l.logf(context.Background(), slog.LevelError, format, v...)
+}
+
+func (l *hertzLogger) Fatalf(format string, v ...interface{}) {
+	l.logf(context.Background(), slog.LevelError, format, v...)
+	os.Exit(1)
+}
+
+func (l *hertzLogger) CtxTracef(ctx context.Context, format string, v ...interface{}) {
+	l.logf(ctx, slog.LevelDebug, format, v...)
+}
+
+func (l *hertzLogger) CtxDebugf(ctx context.Context, format string, v ...interface{}) {
+	l.logf(ctx, slog.LevelDebug, format, v...)
+}
+
+func (l *hertzLogger) CtxInfof(ctx context.Context, format string, v ...interface{}) {
+	l.logf(ctx, slog.LevelInfo, format, v...)
+}
+
+func (l *hertzLogger) CtxNoticef(ctx context.Context, format string, v ...interface{}) {
+	l.logf(ctx, levelNotice, format, v...)
+}
+
+func (l *hertzLogger) CtxWarnf(ctx context.Context, format string, v ...interface{}) {
+	l.logf(ctx, slog.LevelWarn, format, v...)
+}
+
+func (l *hertzLogger) CtxErrorf(ctx context.Context, format string, v ...interface{}) {
+	l.logf(ctx, slog.LevelError, format, v...)
+}
+
+func (l *hertzLogger) CtxFatalf(ctx context.Context, format string, v ...interface{}) {
+	l.logf(ctx, slog.LevelError, format, v...)
+	os.Exit(1)
+}
+
+func (l *hertzLogger) SetLevel(hlog.Level) {}
+
+func (l *hertzLogger) SetOutput(io.Writer) {}
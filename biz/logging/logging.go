@@ -0,0 +1,129 @@
+// Package logging writes the logs of the server as structured records with
+// log/slog, in JSON or text.
+//
+// Every part of the server logs through the logger of its component, see
+// Logger, whose level can be set apart from the others, e.g. to see the
+// queries of the db component without the debug logs of everything else.
+// Records logged with a context carry the ID of the request, the user it is
+// made on behalf of and the trace it belongs to, see WithRequest.
+package logging
+
+import (
+	"cloud-storage/biz/config"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+var (
+	mu sync.RWMutex
+	// base is the handler every component logs through, with the level of
+	// the component checked beforehand
+	base         slog.Handler = newHandler(os.Stderr, "text")
+	defaultLevel              = slog.LevelInfo
+	levels                    = map[string]slog.Level{}
+)
+
+// Init makes the logs follow c. Until then, records of level info and above
+// are written as text to standard error.
+func Init(c *config.Log) error {
+	level, err := ParseLevel(c.Level)
+	if err != nil {
+		return err
+	}
+	componentLevels := make(map[string]slog.Level, len(c.Levels))
+	for component, s := range c.Levels {
+		l, err := ParseLevel(s)
+		if err != nil {
+			return fmt.Errorf("component %s: %w", component, err)
+		}
+		componentLevels[component] = l
+	}
+
+	mu.Lock()
+	base = newHandler(os.Stderr, c.Format)
+	defaultLevel = level
+	levels = componentLevels
+	mu.Unlock()
+
+	slog.SetDefault(Logger("app"))
+	hlog.SetLogger(&hertzLogger{log: Logger("hertz")})
+	return nil
+}
+
+// ParseLevel parses debug, info, warn or error.
+func ParseLevel(s string) (slog.Level, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("unknown log level %q", s)
+	}
+	return l, nil
+}
+
+func newHandler(w io.Writer, format string) slog.Handler {
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug, ReplaceAttr: redactAttr}
+	if strings.EqualFold(format, "json") {
+		return slog.NewJSONHandler(w, opts)
+	}
+	return slog.NewTextHandler(w, opts)
+}
+
+func enabled(component string, l slog.Level) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	min, ok := levels[component]
+	if !ok {
+		min = defaultLevel
+	}
+	return l >= min
+}
+
+func current() slog.Handler {
+	mu.RLock()
+	defer mu.RUnlock()
+	return base
+}
+
+// Logger returns the logger of component. It may be created before Init,
+// e.g. in a package variable, and follows the configuration set later.
+func Logger(component string) *slog.Logger {
+	return slog.New(&handler{component: component})
+}
+
+// handler passes the records of a component on to the current base handler.
+type handler struct {
+	component string
+	// with replays the WithAttrs and WithGroup calls on the base handler,
+	// which may change after the logger was derived
+	with []func(slog.Handler) slog.Handler
+}
+
+func (h *handler) Enabled(_ context.Context, l slog.Level) bool {
+	return enabled(h.component, l)
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	next := current().WithAttrs(append([]slog.Attr{slog.String("component", h.component)}, contextAttrs(ctx)...))
+	for _, with := range h.with {
+		next = with(next)
+	}
+	return next.Handle(ctx, r)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.derive(func(next slog.Handler) slog.Handler { return next.WithAttrs(attrs) })
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return h.derive(func(next slog.Handler) slog.Handler { return next.WithGroup(name) })
+}
+
+func (h *handler) derive(with func(slog.Handler) slog.Handler) slog.Handler {
+	return &handler{component: h.component, with: append(h.with[:len(h.with):len(h.with)], with)}
+}
@@ -0,0 +1,48 @@
+package logging
+
+import (
+	"log/slog"
+	"net/url"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitive are the parts of the names of attributes and query parameters
+// whose values are never logged.
+var sensitive = []string{"password", "passwd", "secret", "token", "authorization", "cookie", "signature"}
+
+func isSensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range sensitive {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactAttr(_ []string, a slog.Attr) slog.Attr {
+	if isSensitive(a.Key) {
+		return slog.String(a.Key, redacted)
+	}
+	return a
+}
+
+// RedactQuery returns the path and query of a request URI with the values
+// of sensitive parameters, such as share tokens and presigned S3 signatures,
+// replaced.
+func RedactQuery(uri string) string {
+	path, rawQuery, ok := strings.Cut(uri, "?")
+	if !ok {
+		return uri
+	}
+	params := strings.Split(rawQuery, "&")
+	for i, p := range params {
+		name, _, _ := strings.Cut(p, "=")
+		if unescaped, err := url.QueryUnescape(name); err == nil && isSensitive(unescaped) {
+			params[i] = name + "=" + redacted
+		}
+	}
+	return path + "?" + strings.Join(params, "&")
+}
@@ -0,0 +1,144 @@
+// Package mail sends plain text mail to users, such as the links of
+// password resets, through the SMTP server of the configuration. It is
+// meant to be the one way out for mail: the registration codes of
+// MailCodeSendRegister, not implemented yet, are to go through it too.
+package mail
+
+import (
+	"bytes"
+	"cloud-storage/biz/config"
+	"cloud-storage/biz/logging"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+var log = logging.Logger("mail")
+
+// implicitTLSPort is the port of SMTP servers that speak TLS from the
+// start; servers on other ports are asked to upgrade with STARTTLS.
+const implicitTLSPort = 465
+
+// Message is a plain text mail to a single recipient.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends mail.
+type Mailer interface {
+	Send(ctx context.Context, m *Message) error
+}
+
+// New returns the mailer of c, which drops every message if no SMTP server
+// is configured.
+func New(c *config.Mailer) Mailer {
+	if c.Host == "" {
+		return discard{}
+	}
+	return &smtpMailer{c: *c}
+}
+
+// discard drops messages, leaving a warning in the log. The body is not
+// logged as it may hold secrets.
+type discard struct{}
+
+func (discard) Send(ctx context.Context, m *Message) error {
+	log.WarnContext(ctx, "mail is disabled, message dropped", "to", m.To, "subject", m.Subject)
+	return nil
+}
+
+type smtpMailer struct {
+	c config.Mailer
+}
+
+func (s *smtpMailer) Send(ctx context.Context, m *Message) error {
+	from, err := netmail.ParseAddress(s.c.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender %q: %w", s.c.From, err)
+	}
+	to, err := netmail.ParseAddress(m.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient %q: %w", m.To, err)
+	}
+	data, err := format(from, to, m)
+	if err != nil {
+		return err
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(s.c.Host, strconv.Itoa(s.c.Port)))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	tlsConfig := &tls.Config{ServerName: s.c.Host}
+	if s.c.Port == implicitTLSPort {
+		conn = tls.Client(conn, tlsConfig)
+	}
+	c, err := smtp.NewClient(conn, s.c.Host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+	// PlainAuth refuses to send the password unencrypted, but to localhost
+	if s.c.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.c.Username, s.c.Password, s.c.Host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to.Address); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// format returns m as an RFC 5322 message. The subject and body may be
+// UTF-8 text of any length.
+func format(from, to *netmail.Address, m *Message) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+	w := quotedprintable.NewWriter(&b)
+	if _, err := w.Write([]byte(m.Body)); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"gorm.io/gorm"
+)
+
+const startKey = "cloud-storage:metrics_start"
+
+var (
+	queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "Latency of the database queries by operation and table.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+	queryErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "db",
+		Name:      "query_errors_total",
+		Help:      "Database queries that failed, not counting those finding no record.",
+	}, []string{"operation", "table"})
+)
+
+// GormPlugin returns the gorm plugin timing the queries of a database.
+func GormPlugin() gorm.Plugin {
+	return gormPlugin{}
+}
+
+type gormPlugin struct{}
+
+func (gormPlugin) Name() string {
+	return "cloud-storage:metrics"
+}
+
+func (gormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Create().Before("gorm:create").Register("cloud-storage:metrics_start", start),
+		cb.Create().After("gorm:create").Register("cloud-storage:metrics_observe", observe("create")),
+		cb.Query().Before("gorm:query").Register("cloud-storage:metrics_start", start),
+		cb.Query().After("gorm:query").Register("cloud-storage:metrics_observe", observe("query")),
+		cb.Update().Before("gorm:update").Register("cloud-storage:metrics_start", start),
+		cb.Update().After("gorm:update").Register("cloud-storage:metrics_observe", observe("update")),
+		cb.Delete().Before("gorm:delete").Register("cloud-storage:metrics_start", start),
+		cb.Delete().After("gorm:delete").Register("cloud-storage:metrics_observe", observe("delete")),
+		cb.Row().Before("gorm:row").Register("cloud-storage:metrics_start", start),
+		cb.Row().After("gorm:row").Register("cloud-storage:metrics_observe", observe("row")),
+		cb.Raw().Before("gorm:raw").Register("cloud-storage:metrics_start", start),
+		cb.Raw().After("gorm:raw").Register("cloud-storage:metrics_observe", observe("raw")),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func start(db *gorm.DB) {
+	db.InstanceSet(startKey, time.Now())
+}
+
+func observe(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		v, ok := db.InstanceGet(startKey)
+		if !ok {
+			return
+		}
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		queryDuration.WithLabelValues(operation, table).Observe(time.Since(v.(time.Time)).Seconds())
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			queryErrors.WithLabelValues(operation, table).Inc()
+		}
+	}
+}
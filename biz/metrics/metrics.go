@@ -0,0 +1,28 @@
+// Package metrics exposes the metrics of the server to Prometheus.
+//
+// Metrics are registered with the default registry by the packages they
+// describe, e.g. the HTTP metrics by mw and the blob metrics by blob, which
+// also carries the Go runtime and process metrics.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace prefixes the names of the metrics of the server.
+const Namespace = "cloud_storage"
+
+// ListenAndServe serves the metrics at /metrics of addr.
+func ListenAndServe(addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return srv.ListenAndServe()
+}
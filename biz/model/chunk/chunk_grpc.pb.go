@@ -0,0 +1,203 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.32.0
+// source: chunk.proto
+
+package chunk
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Chunk_FileUploadPrepare_FullMethodName       = "/chunk.chunk/FileUploadPrepare"
+	Chunk_FileUploadChunk_FullMethodName         = "/chunk.chunk/FileUploadChunk"
+	Chunk_FileUploadChunkComplete_FullMethodName = "/chunk.chunk/FileUploadChunkComplete"
+)
+
+// ChunkClient is the client API for Chunk service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ChunkClient interface {
+	// 文件上传前基本信息处理
+	FileUploadPrepare(ctx context.Context, in *FileUploadPrepareRequest, opts ...grpc.CallOption) (*FileUploadPrepareReply, error)
+	// 文件分片上传
+	FileUploadChunk(ctx context.Context, in *FileUploadChunkRequest, opts ...grpc.CallOption) (*FileUploadChunkReply, error)
+	// 文件分片上传完成
+	FileUploadChunkComplete(ctx context.Context, in *FileUploadChunkCompleteRequest, opts ...grpc.CallOption) (*FileUploadChunkCompleteReply, error)
+}
+
+type chunkClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewChunkClient(cc grpc.ClientConnInterface) ChunkClient {
+	return &chunkClient{cc}
+}
+
+func (c *chunkClient) FileUploadPrepare(ctx context.Context, in *FileUploadPrepareRequest, opts ...grpc.CallOption) (*FileUploadPrepareReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileUploadPrepareReply)
+	err := c.cc.Invoke(ctx, Chunk_FileUploadPrepare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chunkClient) FileUploadChunk(ctx context.Context, in *FileUploadChunkRequest, opts ...grpc.CallOption) (*FileUploadChunkReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileUploadChunkReply)
+	err := c.cc.Invoke(ctx, Chunk_FileUploadChunk_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chunkClient) FileUploadChunkComplete(ctx context.Context, in *FileUploadChunkCompleteRequest, opts ...grpc.CallOption) (*FileUploadChunkCompleteReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileUploadChunkCompleteReply)
+	err := c.cc.Invoke(ctx, Chunk_FileUploadChunkComplete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChunkServer is the server API for Chunk service.
+// All implementations must embed UnimplementedChunkServer
+// for forward compatibility.
+type ChunkServer interface {
+	// 文件上传前基本信息处理
+	FileUploadPrepare(context.Context, *FileUploadPrepareRequest) (*FileUploadPrepareReply, error)
+	// 文件分片上传
+	FileUploadChunk(context.Context, *FileUploadChunkRequest) (*FileUploadChunkReply, error)
+	// 文件分片上传完成
+	FileUploadChunkComplete(context.Context, *FileUploadChunkCompleteRequest) (*FileUploadChunkCompleteReply, error)
+	mustEmbedUnimplementedChunkServer()
+}
+
+// UnimplementedChunkServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedChunkServer struct{}
+
+func (UnimplementedChunkServer) FileUploadPrepare(context.Context, *FileUploadPrepareRequest) (*FileUploadPrepareReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FileUploadPrepare not implemented")
+}
+func (UnimplementedChunkServer) FileUploadChunk(context.Context, *FileUploadChunkRequest) (*FileUploadChunkReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FileUploadChunk not implemented")
+}
+func (UnimplementedChunkServer) FileUploadChunkComplete(context.Context, *FileUploadChunkCompleteRequest) (*FileUploadChunkCompleteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FileUploadChunkComplete not implemented")
+}
+func (UnimplementedChunkServer) mustEmbedUnimplementedChunkServer() {}
+func (UnimplementedChunkServer) testEmbeddedByValue()               {}
+
+// UnsafeChunkServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ChunkServer will
+// result in compilation errors.
+type UnsafeChunkServer interface {
+	mustEmbedUnimplementedChunkServer()
+}
+
+func RegisterChunkServer(s grpc.ServiceRegistrar, srv ChunkServer) {
+	// If the following call pancis, it indicates UnimplementedChunkServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Chunk_ServiceDesc, srv)
+}
+
+func _Chunk_FileUploadPrepare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileUploadPrepareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChunkServer).FileUploadPrepare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chunk_FileUploadPrepare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChunkServer).FileUploadPrepare(ctx, req.(*FileUploadPrepareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chunk_FileUploadChunk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileUploadChunkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChunkServer).FileUploadChunk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chunk_FileUploadChunk_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChunkServer).FileUploadChunk(ctx, req.(*FileUploadChunkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chunk_FileUploadChunkComplete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileUploadChunkCompleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChunkServer).FileUploadChunkComplete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chunk_FileUploadChunkComplete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChunkServer).FileUploadChunkComplete(ctx, req.(*FileUploadChunkCompleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Chunk_ServiceDesc is the grpc.ServiceDesc for Chunk service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Chunk_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "chunk.chunk",
+	HandlerType: (*ChunkServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "FileUploadPrepare",
+			Handler:    _Chunk_FileUploadPrepare_Handler,
+		},
+		{
+			MethodName: "FileUploadChunk",
+			Handler:    _Chunk_FileUploadChunk_Handler,
+		},
+		{
+			MethodName: "FileUploadChunkComplete",
+			Handler:    _Chunk_FileUploadChunkComplete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "chunk.proto",
+}
@@ -0,0 +1,560 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.32.0
+// source: file.proto
+
+package file
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	File_FileUpload_FullMethodName             = "/file.file/FileUpload"
+	File_UserRepositorySave_FullMethodName     = "/file.file/UserRepositorySave"
+	File_UserFileList_FullMethodName           = "/file.file/UserFileList"
+	File_UserFolderList_FullMethodName         = "/file.file/UserFolderList"
+	File_UserFileNameUpdate_FullMethodName     = "/file.file/UserFileNameUpdate"
+	File_UserFolderCreate_FullMethodName       = "/file.file/UserFolderCreate"
+	File_UserFileDelete_FullMethodName         = "/file.file/UserFileDelete"
+	File_UserFileMove_FullMethodName           = "/file.file/UserFileMove"
+	File_UserFileSearch_FullMethodName         = "/file.file/UserFileSearch"
+	File_UserFileContentSearch_FullMethodName  = "/file.file/UserFileContentSearch"
+	File_FileUploadStream_FullMethodName       = "/file.file/FileUploadStream"
+	File_UserFileDownloadStream_FullMethodName = "/file.file/UserFileDownloadStream"
+)
+
+// FileClient is the client API for File service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FileClient interface {
+	// 文件上传
+	FileUpload(ctx context.Context, in *FileUploadRequest, opts ...grpc.CallOption) (*FileUploadReply, error)
+	// 用户文件的关联存储
+	UserRepositorySave(ctx context.Context, in *UserRepositorySaveRequest, opts ...grpc.CallOption) (*UserRepositorySaveReply, error)
+	// 用户文件列表
+	UserFileList(ctx context.Context, in *UserFileListRequest, opts ...grpc.CallOption) (*UserFileListReply, error)
+	// 用户文件夹列表
+	UserFolderList(ctx context.Context, in *UserFolderListRequest, opts ...grpc.CallOption) (*UserFolderListReply, error)
+	// 用户文件名称修改
+	UserFileNameUpdate(ctx context.Context, in *UserFileNameUpdateRequest, opts ...grpc.CallOption) (*UserFileNameUpdateReply, error)
+	// 用户-文件夹创建
+	UserFolderCreate(ctx context.Context, in *UserFolderCreateRequest, opts ...grpc.CallOption) (*UserFolderCreateReply, error)
+	// 用户-文件删除
+	UserFileDelete(ctx context.Context, in *UserFileDeleteRequest, opts ...grpc.CallOption) (*UserFileDeleteReply, error)
+	// 用户-文件移动
+	UserFileMove(ctx context.Context, in *UserFileMoveRequest, opts ...grpc.CallOption) (*UserFileMoveReply, error)
+	// 用户-文件搜索
+	UserFileSearch(ctx context.Context, in *UserFileSearchRequest, opts ...grpc.CallOption) (*UserFileSearchReply, error)
+	// 用户-文件内容搜索
+	UserFileContentSearch(ctx context.Context, in *UserFileContentSearchRequest, opts ...grpc.CallOption) (*UserFileContentSearchReply, error)
+	// 文件流式上传，仅 gRPC
+	FileUploadStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[FileUploadStreamRequest, FileUploadReply], error)
+	// 用户-文件流式下载，仅 gRPC
+	UserFileDownloadStream(ctx context.Context, in *UserFileDownloadStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UserFileDownloadStreamReply], error)
+}
+
+type fileClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFileClient(cc grpc.ClientConnInterface) FileClient {
+	return &fileClient{cc}
+}
+
+func (c *fileClient) FileUpload(ctx context.Context, in *FileUploadRequest, opts ...grpc.CallOption) (*FileUploadReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileUploadReply)
+	err := c.cc.Invoke(ctx, File_FileUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileClient) UserRepositorySave(ctx context.Context, in *UserRepositorySaveRequest, opts ...grpc.CallOption) (*UserRepositorySaveReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserRepositorySaveReply)
+	err := c.cc.Invoke(ctx, File_UserRepositorySave_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileClient) UserFileList(ctx context.Context, in *UserFileListRequest, opts ...grpc.CallOption) (*UserFileListReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserFileListReply)
+	err := c.cc.Invoke(ctx, File_UserFileList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileClient) UserFolderList(ctx context.Context, in *UserFolderListRequest, opts ...grpc.CallOption) (*UserFolderListReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserFolderListReply)
+	err := c.cc.Invoke(ctx, File_UserFolderList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileClient) UserFileNameUpdate(ctx context.Context, in *UserFileNameUpdateRequest, opts ...grpc.CallOption) (*UserFileNameUpdateReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserFileNameUpdateReply)
+	err := c.cc.Invoke(ctx, File_UserFileNameUpdate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileClient) UserFolderCreate(ctx context.Context, in *UserFolderCreateRequest, opts ...grpc.CallOption) (*UserFolderCreateReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserFolderCreateReply)
+	err := c.cc.Invoke(ctx, File_UserFolderCreate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileClient) UserFileDelete(ctx context.Context, in *UserFileDeleteRequest, opts ...grpc.CallOption) (*UserFileDeleteReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserFileDeleteReply)
+	err := c.cc.Invoke(ctx, File_UserFileDelete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileClient) UserFileMove(ctx context.Context, in *UserFileMoveRequest, opts ...grpc.CallOption) (*UserFileMoveReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserFileMoveReply)
+	err := c.cc.Invoke(ctx, File_UserFileMove_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileClient) UserFileSearch(ctx context.Context, in *UserFileSearchRequest, opts ...grpc.CallOption) (*UserFileSearchReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserFileSearchReply)
+	err := c.cc.Invoke(ctx, File_UserFileSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileClient) UserFileContentSearch(ctx context.Context, in *UserFileContentSearchRequest, opts ...grpc.CallOption) (*UserFileContentSearchReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserFileContentSearchReply)
+	err := c.cc.Invoke(ctx, File_UserFileContentSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileClient) FileUploadStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[FileUploadStreamRequest, FileUploadReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &File_ServiceDesc.Streams[0], File_FileUploadStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FileUploadStreamRequest, FileUploadReply]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type File_FileUploadStreamClient = grpc.ClientStreamingClient[FileUploadStreamRequest, FileUploadReply]
+
+func (c *fileClient) UserFileDownloadStream(ctx context.Context, in *UserFileDownloadStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UserFileDownloadStreamReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &File_ServiceDesc.Streams[1], File_UserFileDownloadStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UserFileDownloadStreamRequest, UserFileDownloadStreamReply]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type File_UserFileDownloadStreamClient = grpc.ServerStreamingClient[UserFileDownloadStreamReply]
+
+// FileServer is the server API for File service.
+// All implementations must embed UnimplementedFileServer
+// for forward compatibility.
+type FileServer interface {
+	// 文件上传
+	FileUpload(context.Context, *FileUploadRequest) (*FileUploadReply, error)
+	// 用户文件的关联存储
+	UserRepositorySave(context.Context, *UserRepositorySaveRequest) (*UserRepositorySaveReply, error)
+	// 用户文件列表
+	UserFileList(context.Context, *UserFileListRequest) (*UserFileListReply, error)
+	// 用户文件夹列表
+	UserFolderList(context.Context, *UserFolderListRequest) (*UserFolderListReply, error)
+	// 用户文件名称修改
+	UserFileNameUpdate(context.Context, *UserFileNameUpdateRequest) (*UserFileNameUpdateReply, error)
+	// 用户-文件夹创建
+	UserFolderCreate(context.Context, *UserFolderCreateRequest) (*UserFolderCreateReply, error)
+	// 用户-文件删除
+	UserFileDelete(context.Context, *UserFileDeleteRequest) (*UserFileDeleteReply, error)
+	// 用户-文件移动
+	UserFileMove(context.Context, *UserFileMoveRequest) (*UserFileMoveReply, error)
+	// 用户-文件搜索
+	UserFileSearch(context.Context, *UserFileSearchRequest) (*UserFileSearchReply, error)
+	// 用户-文件内容搜索
+	UserFileContentSearch(context.Context, *UserFileContentSearchRequest) (*UserFileContentSearchReply, error)
+	// 文件流式上传，仅 gRPC
+	FileUploadStream(grpc.ClientStreamingServer[FileUploadStreamRequest, FileUploadReply]) error
+	// 用户-文件流式下载，仅 gRPC
+	UserFileDownloadStream(*UserFileDownloadStreamRequest, grpc.ServerStreamingServer[UserFileDownloadStreamReply]) error
+	mustEmbedUnimplementedFileServer()
+}
+
+// UnimplementedFileServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFileServer struct{}
+
+func (UnimplementedFileServer) FileUpload(context.Context, *FileUploadRequest) (*FileUploadReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FileUpload not implemented")
+}
+func (UnimplementedFileServer) UserRepositorySave(context.Context, *UserRepositorySaveRequest) (*UserRepositorySaveReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserRepositorySave not implemented")
+}
+func (UnimplementedFileServer) UserFileList(context.Context, *UserFileListRequest) (*UserFileListReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserFileList not implemented")
+}
+func (UnimplementedFileServer) UserFolderList(context.Context, *UserFolderListRequest) (*UserFolderListReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserFolderList not implemented")
+}
+func (UnimplementedFileServer) UserFileNameUpdate(context.Context, *UserFileNameUpdateRequest) (*UserFileNameUpdateReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserFileNameUpdate not implemented")
+}
+func (UnimplementedFileServer) UserFolderCreate(context.Context, *UserFolderCreateRequest) (*UserFolderCreateReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserFolderCreate not implemented")
+}
+func (UnimplementedFileServer) UserFileDelete(context.Context, *UserFileDeleteRequest) (*UserFileDeleteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserFileDelete not implemented")
+}
+func (UnimplementedFileServer) UserFileMove(context.Context, *UserFileMoveRequest) (*UserFileMoveReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserFileMove not implemented")
+}
+func (UnimplementedFileServer) UserFileSearch(context.Context, *UserFileSearchRequest) (*UserFileSearchReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserFileSearch not implemented")
+}
+func (UnimplementedFileServer) UserFileContentSearch(context.Context, *UserFileContentSearchRequest) (*UserFileContentSearchReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserFileContentSearch not implemented")
+}
+func (UnimplementedFileServer) FileUploadStream(grpc.ClientStreamingServer[FileUploadStreamRequest, FileUploadReply]) error {
+	return status.Errorf(codes.Unimplemented, "method FileUploadStream not implemented")
+}
+func (UnimplementedFileServer) UserFileDownloadStream(*UserFileDownloadStreamRequest, grpc.ServerStreamingServer[UserFileDownloadStreamReply]) error {
+	return status.Errorf(codes.Unimplemented, "method UserFileDownloadStream not implemented")
+}
+func (UnimplementedFileServer) mustEmbedUnimplementedFileServer() {}
+func (UnimplementedFileServer) testEmbeddedByValue()              {}
+
+// UnsafeFileServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FileServer will
+// result in compilation errors.
+type UnsafeFileServer interface {
+	mustEmbedUnimplementedFileServer()
+}
+
+func RegisterFileServer(s grpc.ServiceRegistrar, srv FileServer) {
+	// If the following call pancis, it indicates UnimplementedFileServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&File_ServiceDesc, srv)
+}
+
+func _File_FileUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServer).FileUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: File_FileUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServer).FileUpload(ctx, req.(*FileUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _File_UserRepositorySave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRepositorySaveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServer).UserRepositorySave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: File_UserRepositorySave_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServer).UserRepositorySave(ctx, req.(*UserRepositorySaveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _File_UserFileList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserFileListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServer).UserFileList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: File_UserFileList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServer).UserFileList(ctx, req.(*UserFileListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _File_UserFolderList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserFolderListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServer).UserFolderList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: File_UserFolderList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServer).UserFolderList(ctx, req.(*UserFolderListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _File_UserFileNameUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserFileNameUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServer).UserFileNameUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: File_UserFileNameUpdate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServer).UserFileNameUpdate(ctx, req.(*UserFileNameUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _File_UserFolderCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserFolderCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServer).UserFolderCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: File_UserFolderCreate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServer).UserFolderCreate(ctx, req.(*UserFolderCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _File_UserFileDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserFileDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServer).UserFileDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: File_UserFileDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServer).UserFileDelete(ctx, req.(*UserFileDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _File_UserFileMove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserFileMoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServer).UserFileMove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: File_UserFileMove_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServer).UserFileMove(ctx, req.(*UserFileMoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _File_UserFileSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserFileSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServer).UserFileSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: File_UserFileSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServer).UserFileSearch(ctx, req.(*UserFileSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _File_UserFileContentSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserFileContentSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServer).UserFileContentSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: File_UserFileContentSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServer).UserFileContentSearch(ctx, req.(*UserFileContentSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _File_FileUploadStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FileServer).FileUploadStream(&grpc.GenericServerStream[FileUploadStreamRequest, FileUploadReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type File_FileUploadStreamServer = grpc.ClientStreamingServer[FileUploadStreamRequest, FileUploadReply]
+
+func _File_UserFileDownloadStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UserFileDownloadStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FileServer).UserFileDownloadStream(m, &grpc.GenericServerStream[UserFileDownloadStreamRequest, UserFileDownloadStreamReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type File_UserFileDownloadStreamServer = grpc.ServerStreamingServer[UserFileDownloadStreamReply]
+
+// File_ServiceDesc is the grpc.ServiceDesc for File service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var File_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "file.file",
+	HandlerType: (*FileServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "FileUpload",
+			Handler:    _File_FileUpload_Handler,
+		},
+		{
+			MethodName: "UserRepositorySave",
+			Handler:    _File_UserRepositorySave_Handler,
+		},
+		{
+			MethodName: "UserFileList",
+			Handler:    _File_UserFileList_Handler,
+		},
+		{
+			MethodName: "UserFolderList",
+			Handler:    _File_UserFolderList_Handler,
+		},
+		{
+			MethodName: "UserFileNameUpdate",
+			Handler:    _File_UserFileNameUpdate_Handler,
+		},
+		{
+			MethodName: "UserFolderCreate",
+			Handler:    _File_UserFolderCreate_Handler,
+		},
+		{
+			MethodName: "UserFileDelete",
+			Handler:    _File_UserFileDelete_Handler,
+		},
+		{
+			MethodName: "UserFileMove",
+			Handler:    _File_UserFileMove_Handler,
+		},
+		{
+			MethodName: "UserFileSearch",
+			Handler:    _File_UserFileSearch_Handler,
+		},
+		{
+			MethodName: "UserFileContentSearch",
+			Handler:    _File_UserFileContentSearch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "FileUploadStream",
+			Handler:       _File_FileUploadStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "UserFileDownloadStream",
+			Handler:       _File_UserFileDownloadStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "file.proto",
+}
@@ -351,6 +351,91 @@ func (x *ShareBasicCreateReply) GetIdentity() string {
 	return ""
 }
 
+type ShareBasicRevokeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Identity string `protobuf:"bytes,1,opt,name=identity,proto3" form:"identity" json:"identity,omitempty" query:"identity"`
+}
+
+func (x *ShareBasicRevokeRequest) Reset() {
+	*x = ShareBasicRevokeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_share_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShareBasicRevokeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareBasicRevokeRequest) ProtoMessage() {}
+
+func (x *ShareBasicRevokeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_share_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareBasicRevokeRequest.ProtoReflect.Descriptor instead.
+func (*ShareBasicRevokeRequest) Descriptor() ([]byte, []int) {
+	return file_share_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ShareBasicRevokeRequest) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+	return ""
+}
+
+type ShareBasicRevokeReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ShareBasicRevokeReply) Reset() {
+	*x = ShareBasicRevokeReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_share_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShareBasicRevokeReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareBasicRevokeReply) ProtoMessage() {}
+
+func (x *ShareBasicRevokeReply) ProtoReflect() protoreflect.Message {
+	mi := &file_share_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareBasicRevokeReply.ProtoReflect.Descriptor instead.
+func (*ShareBasicRevokeReply) Descriptor() ([]byte, []int) {
+	return file_share_proto_rawDescGZIP(), []int{7}
+}
+
 var File_share_proto protoreflect.FileDescriptor
 
 var file_share_proto_rawDesc = []byte{
@@ -389,7 +474,12 @@ var file_share_proto_rawDesc = []byte{
 	0x65, 0x22, 0x33, 0x0a, 0x15, 0x53, 0x68, 0x61, 0x72, 0x65, 0x42, 0x61, 0x73, 0x69, 0x63, 0x43,
 	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x64,
 	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x64,
-	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x32, 0xc0, 0x02, 0x0a, 0x05, 0x73, 0x68, 0x61, 0x72, 0x65,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x22, 0x35, 0x0a, 0x17, 0x53, 0x68, 0x61, 0x72, 0x65, 0x42,
+	0x61, 0x73, 0x69, 0x63, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x22, 0x17, 0x0a,
+	0x15, 0x53, 0x68, 0x61, 0x72, 0x65, 0x42, 0x61, 0x73, 0x69, 0x63, 0x52, 0x65, 0x76, 0x6f, 0x6b,
+	0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x32, 0xab, 0x03, 0x0a, 0x05, 0x73, 0x68, 0x61, 0x72, 0x65,
 	0x12, 0x69, 0x0a, 0x10, 0x53, 0x68, 0x61, 0x72, 0x65, 0x42, 0x61, 0x73, 0x69, 0x63, 0x44, 0x65,
 	0x74, 0x61, 0x69, 0x6c, 0x12, 0x1e, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x2e, 0x53, 0x68, 0x61,
 	0x72, 0x65, 0x42, 0x61, 0x73, 0x69, 0x63, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71,
@@ -409,10 +499,16 @@ var file_share_proto_rawDesc = []byte{
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x2e, 0x53,
 	0x68, 0x61, 0x72, 0x65, 0x42, 0x61, 0x73, 0x69, 0x63, 0x53, 0x61, 0x76, 0x65, 0x52, 0x65, 0x70,
 	0x6c, 0x79, 0x22, 0x15, 0xd2, 0xc1, 0x18, 0x11, 0x2f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x2f, 0x62,
-	0x61, 0x73, 0x69, 0x63, 0x2f, 0x73, 0x61, 0x76, 0x65, 0x42, 0x1f, 0x5a, 0x1d, 0x63, 0x6c, 0x6f,
-	0x75, 0x64, 0x2d, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2f, 0x62, 0x69, 0x7a, 0x2f, 0x6d,
-	0x6f, 0x64, 0x65, 0x6c, 0x2f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x61, 0x73, 0x69, 0x63, 0x2f, 0x73, 0x61, 0x76, 0x65, 0x12, 0x69, 0x0a, 0x10, 0x53, 0x68, 0x61,
+	0x72, 0x65, 0x42, 0x61, 0x73, 0x69, 0x63, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x12, 0x1e, 0x2e,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x42, 0x61, 0x73, 0x69, 0x63,
+	0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x42, 0x61, 0x73, 0x69, 0x63,
+	0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x17, 0xd2, 0xc1, 0x18,
+	0x13, 0x2f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x2f, 0x62, 0x61, 0x73, 0x69, 0x63, 0x2f, 0x72, 0x65,
+	0x76, 0x6f, 0x6b, 0x65, 0x42, 0x1f, 0x5a, 0x1d, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2d, 0x73, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x2f, 0x62, 0x69, 0x7a, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2f,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -427,7 +523,7 @@ func file_share_proto_rawDescGZIP() []byte {
 	return file_share_proto_rawDescData
 }
 
-var file_share_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_share_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_share_proto_goTypes = []interface{}{
 	(*ShareBasicSaveRequest)(nil),   // 0: share.ShareBasicSaveRequest
 	(*ShareBasicSaveReply)(nil),     // 1: share.ShareBasicSaveReply
@@ -435,16 +531,20 @@ var file_share_proto_goTypes = []interface{}{
 	(*ShareBasicDetailReply)(nil),   // 3: share.ShareBasicDetailReply
 	(*ShareBasicCreateRequest)(nil), // 4: share.ShareBasicCreateRequest
 	(*ShareBasicCreateReply)(nil),   // 5: share.ShareBasicCreateReply
+	(*ShareBasicRevokeRequest)(nil), // 6: share.ShareBasicRevokeRequest
+	(*ShareBasicRevokeReply)(nil),   // 7: share.ShareBasicRevokeReply
 }
 var file_share_proto_depIdxs = []int32{
 	2, // 0: share.share.ShareBasicDetail:input_type -> share.ShareBasicDetailRequest
 	4, // 1: share.share.ShareBasicCreate:input_type -> share.ShareBasicCreateRequest
 	0, // 2: share.share.ShareBasicSave:input_type -> share.ShareBasicSaveRequest
-	3, // 3: share.share.ShareBasicDetail:output_type -> share.ShareBasicDetailReply
-	5, // 4: share.share.ShareBasicCreate:output_type -> share.ShareBasicCreateReply
-	1, // 5: share.share.ShareBasicSave:output_type -> share.ShareBasicSaveReply
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
+	6, // 3: share.share.ShareBasicRevoke:input_type -> share.ShareBasicRevokeRequest
+	3, // 4: share.share.ShareBasicDetail:output_type -> share.ShareBasicDetailReply
+	5, // 5: share.share.ShareBasicCreate:output_type -> share.ShareBasicCreateReply
+	1, // 6: share.share.ShareBasicSave:output_type -> share.ShareBasicSaveReply
+	7, // 7: share.share.ShareBasicRevoke:output_type -> share.ShareBasicRevokeReply
+	4, // [4:8] is the sub-list for method output_type
+	0, // [0:4] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
@@ -528,6 +628,30 @@ func file_share_proto_init() {
 				return nil
 			}
 		}
+		file_share_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShareBasicRevokeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_share_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShareBasicRevokeReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -535,7 +659,7 @@ func file_share_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_share_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,243 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.32.0
+// source: share.proto
+
+package share
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Share_ShareBasicDetail_FullMethodName = "/share.share/ShareBasicDetail"
+	Share_ShareBasicCreate_FullMethodName = "/share.share/ShareBasicCreate"
+	Share_ShareBasicSave_FullMethodName   = "/share.share/ShareBasicSave"
+	Share_ShareBasicRevoke_FullMethodName = "/share.share/ShareBasicRevoke"
+)
+
+// ShareClient is the client API for Share service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ShareClient interface {
+	// 获取资源详情
+	ShareBasicDetail(ctx context.Context, in *ShareBasicDetailRequest, opts ...grpc.CallOption) (*ShareBasicDetailReply, error)
+	// 创建分享记录
+	ShareBasicCreate(ctx context.Context, in *ShareBasicCreateRequest, opts ...grpc.CallOption) (*ShareBasicCreateReply, error)
+	// 资源保存
+	ShareBasicSave(ctx context.Context, in *ShareBasicSaveRequest, opts ...grpc.CallOption) (*ShareBasicSaveReply, error)
+	// 撤销分享，链接立即失效
+	ShareBasicRevoke(ctx context.Context, in *ShareBasicRevokeRequest, opts ...grpc.CallOption) (*ShareBasicRevokeReply, error)
+}
+
+type shareClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewShareClient(cc grpc.ClientConnInterface) ShareClient {
+	return &shareClient{cc}
+}
+
+func (c *shareClient) ShareBasicDetail(ctx context.Context, in *ShareBasicDetailRequest, opts ...grpc.CallOption) (*ShareBasicDetailReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareBasicDetailReply)
+	err := c.cc.Invoke(ctx, Share_ShareBasicDetail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shareClient) ShareBasicCreate(ctx context.Context, in *ShareBasicCreateRequest, opts ...grpc.CallOption) (*ShareBasicCreateReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareBasicCreateReply)
+	err := c.cc.Invoke(ctx, Share_ShareBasicCreate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shareClient) ShareBasicSave(ctx context.Context, in *ShareBasicSaveRequest, opts ...grpc.CallOption) (*ShareBasicSaveReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareBasicSaveReply)
+	err := c.cc.Invoke(ctx, Share_ShareBasicSave_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shareClient) ShareBasicRevoke(ctx context.Context, in *ShareBasicRevokeRequest, opts ...grpc.CallOption) (*ShareBasicRevokeReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareBasicRevokeReply)
+	err := c.cc.Invoke(ctx, Share_ShareBasicRevoke_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ShareServer is the server API for Share service.
+// All implementations must embed UnimplementedShareServer
+// for forward compatibility.
+type ShareServer interface {
+	// 获取资源详情
+	ShareBasicDetail(context.Context, *ShareBasicDetailRequest) (*ShareBasicDetailReply, error)
+	// 创建分享记录
+	ShareBasicCreate(context.Context, *ShareBasicCreateRequest) (*ShareBasicCreateReply, error)
+	// 资源保存
+	ShareBasicSave(context.Context, *ShareBasicSaveRequest) (*ShareBasicSaveReply, error)
+	// 撤销分享，链接立即失效
+	ShareBasicRevoke(context.Context, *ShareBasicRevokeRequest) (*ShareBasicRevokeReply, error)
+	mustEmbedUnimplementedShareServer()
+}
+
+// UnimplementedShareServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedShareServer struct{}
+
+func (UnimplementedShareServer) ShareBasicDetail(context.Context, *ShareBasicDetailRequest) (*ShareBasicDetailReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareBasicDetail not implemented")
+}
+func (UnimplementedShareServer) ShareBasicCreate(context.Context, *ShareBasicCreateRequest) (*ShareBasicCreateReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareBasicCreate not implemented")
+}
+func (UnimplementedShareServer) ShareBasicSave(context.Context, *ShareBasicSaveRequest) (*ShareBasicSaveReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareBasicSave not implemented")
+}
+func (UnimplementedShareServer) ShareBasicRevoke(context.Context, *ShareBasicRevokeRequest) (*ShareBasicRevokeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareBasicRevoke not implemented")
+}
+func (UnimplementedShareServer) mustEmbedUnimplementedShareServer() {}
+func (UnimplementedShareServer) testEmbeddedByValue()               {}
+
+// UnsafeShareServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ShareServer will
+// result in compilation errors.
+type UnsafeShareServer interface {
+	mustEmbedUnimplementedShareServer()
+}
+
+func RegisterShareServer(s grpc.ServiceRegistrar, srv ShareServer) {
+	// If the following call pancis, it indicates UnimplementedShareServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Share_ServiceDesc, srv)
+}
+
+func _Share_ShareBasicDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareBasicDetailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShareServer).ShareBasicDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Share_ShareBasicDetail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShareServer).ShareBasicDetail(ctx, req.(*ShareBasicDetailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Share_ShareBasicCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareBasicCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShareServer).ShareBasicCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Share_ShareBasicCreate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShareServer).ShareBasicCreate(ctx, req.(*ShareBasicCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Share_ShareBasicSave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareBasicSaveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShareServer).ShareBasicSave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Share_ShareBasicSave_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShareServer).ShareBasicSave(ctx, req.(*ShareBasicSaveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Share_ShareBasicRevoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareBasicRevokeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShareServer).ShareBasicRevoke(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Share_ShareBasicRevoke_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShareServer).ShareBasicRevoke(ctx, req.(*ShareBasicRevokeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Share_ServiceDesc is the grpc.ServiceDesc for Share service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Share_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "share.share",
+	HandlerType: (*ShareServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ShareBasicDetail",
+			Handler:    _Share_ShareBasicDetail_Handler,
+		},
+		{
+			MethodName: "ShareBasicCreate",
+			Handler:    _Share_ShareBasicCreate_Handler,
+		},
+		{
+			MethodName: "ShareBasicSave",
+			Handler:    _Share_ShareBasicSave_Handler,
+		},
+		{
+			MethodName: "ShareBasicRevoke",
+			Handler:    _Share_ShareBasicRevoke_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "share.proto",
+}
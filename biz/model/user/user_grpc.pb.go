@@ -0,0 +1,1043 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.32.0
+// source: user.proto
+
+package user
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	User_UserLogin_FullMethodName                  = "/user.user/UserLogin"
+	User_UserLoginTwoFactor_FullMethodName         = "/user.user/UserLoginTwoFactor"
+	User_UserDetail_FullMethodName                 = "/user.user/UserDetail"
+	User_MailCodeSendRegister_FullMethodName       = "/user.user/MailCodeSendRegister"
+	User_UserRegister_FullMethodName               = "/user.user/UserRegister"
+	User_UserPasswordForgot_FullMethodName         = "/user.user/UserPasswordForgot"
+	User_UserPasswordReset_FullMethodName          = "/user.user/UserPasswordReset"
+	User_RefreshAuthorization_FullMethodName       = "/user.user/RefreshAuthorization"
+	User_UserAccessKeyCreate_FullMethodName        = "/user.user/UserAccessKeyCreate"
+	User_UserAccessKeyList_FullMethodName          = "/user.user/UserAccessKeyList"
+	User_UserAccessKeyDelete_FullMethodName        = "/user.user/UserAccessKeyDelete"
+	User_UserSshKeyCreate_FullMethodName           = "/user.user/UserSshKeyCreate"
+	User_UserSshKeyList_FullMethodName             = "/user.user/UserSshKeyList"
+	User_UserSshKeyDelete_FullMethodName           = "/user.user/UserSshKeyDelete"
+	User_UserTokenCreate_FullMethodName            = "/user.user/UserTokenCreate"
+	User_UserTokenList_FullMethodName              = "/user.user/UserTokenList"
+	User_UserTokenRevoke_FullMethodName            = "/user.user/UserTokenRevoke"
+	User_UserSessionList_FullMethodName            = "/user.user/UserSessionList"
+	User_UserSessionRevoke_FullMethodName          = "/user.user/UserSessionRevoke"
+	User_UserSessionRevokeOthers_FullMethodName    = "/user.user/UserSessionRevokeOthers"
+	User_UserTwoFactorEnroll_FullMethodName        = "/user.user/UserTwoFactorEnroll"
+	User_UserTwoFactorVerify_FullMethodName        = "/user.user/UserTwoFactorVerify"
+	User_UserTwoFactorDisable_FullMethodName       = "/user.user/UserTwoFactorDisable"
+	User_UserRecoveryCodeRegenerate_FullMethodName = "/user.user/UserRecoveryCodeRegenerate"
+)
+
+// UserClient is the client API for User service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type UserClient interface {
+	// 用户登录
+	UserLogin(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginReply, error)
+	// 两步验证登录，完成 UserLogin 返回的挑战
+	UserLoginTwoFactor(ctx context.Context, in *LoginTwoFactorRequest, opts ...grpc.CallOption) (*LoginReply, error)
+	// 用户详情
+	UserDetail(ctx context.Context, in *UserDetailRequest, opts ...grpc.CallOption) (*UserDetailReply, error)
+	// 验证码发送
+	MailCodeSendRegister(ctx context.Context, in *MailCodeSendRequest, opts ...grpc.CallOption) (*MailCodeSendReply, error)
+	// 用户注册
+	UserRegister(ctx context.Context, in *UserRegisterRequest, opts ...grpc.CallOption) (*UserRegisterReply, error)
+	// 忘记密码，向邮箱发送重置链接
+	UserPasswordForgot(ctx context.Context, in *UserPasswordForgotRequest, opts ...grpc.CallOption) (*UserPasswordForgotReply, error)
+	// 用重置链接中的令牌设置新密码
+	UserPasswordReset(ctx context.Context, in *UserPasswordResetRequest, opts ...grpc.CallOption) (*UserPasswordResetReply, error)
+	// 刷新Authorization
+	RefreshAuthorization(ctx context.Context, in *RefreshAuthorizationRequest, opts ...grpc.CallOption) (*RefreshAuthorizationReply, error)
+	// S3 访问密钥创建
+	UserAccessKeyCreate(ctx context.Context, in *UserAccessKeyCreateRequest, opts ...grpc.CallOption) (*UserAccessKeyCreateReply, error)
+	// S3 访问密钥列表
+	UserAccessKeyList(ctx context.Context, in *UserAccessKeyListRequest, opts ...grpc.CallOption) (*UserAccessKeyListReply, error)
+	// S3 访问密钥删除
+	UserAccessKeyDelete(ctx context.Context, in *UserAccessKeyDeleteRequest, opts ...grpc.CallOption) (*UserAccessKeyDeleteReply, error)
+	// SSH 公钥添加
+	UserSshKeyCreate(ctx context.Context, in *UserSshKeyCreateRequest, opts ...grpc.CallOption) (*UserSshKeyCreateReply, error)
+	// SSH 公钥列表
+	UserSshKeyList(ctx context.Context, in *UserSshKeyListRequest, opts ...grpc.CallOption) (*UserSshKeyListReply, error)
+	// SSH 公钥删除
+	UserSshKeyDelete(ctx context.Context, in *UserSshKeyDeleteRequest, opts ...grpc.CallOption) (*UserSshKeyDeleteReply, error)
+	// 个人访问令牌创建
+	UserTokenCreate(ctx context.Context, in *UserTokenCreateRequest, opts ...grpc.CallOption) (*UserTokenCreateReply, error)
+	// 个人访问令牌列表
+	UserTokenList(ctx context.Context, in *UserTokenListRequest, opts ...grpc.CallOption) (*UserTokenListReply, error)
+	// 个人访问令牌吊销
+	UserTokenRevoke(ctx context.Context, in *UserTokenRevokeRequest, opts ...grpc.CallOption) (*UserTokenRevokeReply, error)
+	// 登录会话列表
+	UserSessionList(ctx context.Context, in *UserSessionListRequest, opts ...grpc.CallOption) (*UserSessionListReply, error)
+	// 登录会话注销，其访问令牌立即失效
+	UserSessionRevoke(ctx context.Context, in *UserSessionRevokeRequest, opts ...grpc.CallOption) (*UserSessionRevokeReply, error)
+	// 注销当前会话以外的所有登录会话
+	UserSessionRevokeOthers(ctx context.Context, in *UserSessionRevokeOthersRequest, opts ...grpc.CallOption) (*UserSessionRevokeOthersReply, error)
+	// 两步验证：生成 TOTP 密钥
+	UserTwoFactorEnroll(ctx context.Context, in *UserTwoFactorEnrollRequest, opts ...grpc.CallOption) (*UserTwoFactorEnrollReply, error)
+	// 两步验证：校验验证码并启用，返回恢复码
+	UserTwoFactorVerify(ctx context.Context, in *UserTwoFactorVerifyRequest, opts ...grpc.CallOption) (*UserTwoFactorVerifyReply, error)
+	// 两步验证：停用
+	UserTwoFactorDisable(ctx context.Context, in *UserTwoFactorDisableRequest, opts ...grpc.CallOption) (*UserTwoFactorDisableReply, error)
+	// 两步验证：重新生成恢复码
+	UserRecoveryCodeRegenerate(ctx context.Context, in *UserRecoveryCodeRegenerateRequest, opts ...grpc.CallOption) (*UserRecoveryCodeRegenerateReply, error)
+}
+
+type userClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserClient(cc grpc.ClientConnInterface) UserClient {
+	return &userClient{cc}
+}
+
+func (c *userClient) UserLogin(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginReply)
+	err := c.cc.Invoke(ctx, User_UserLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserLoginTwoFactor(ctx context.Context, in *LoginTwoFactorRequest, opts ...grpc.CallOption) (*LoginReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginReply)
+	err := c.cc.Invoke(ctx, User_UserLoginTwoFactor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserDetail(ctx context.Context, in *UserDetailRequest, opts ...grpc.CallOption) (*UserDetailReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserDetailReply)
+	err := c.cc.Invoke(ctx, User_UserDetail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) MailCodeSendRegister(ctx context.Context, in *MailCodeSendRequest, opts ...grpc.CallOption) (*MailCodeSendReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MailCodeSendReply)
+	err := c.cc.Invoke(ctx, User_MailCodeSendRegister_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserRegister(ctx context.Context, in *UserRegisterRequest, opts ...grpc.CallOption) (*UserRegisterReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserRegisterReply)
+	err := c.cc.Invoke(ctx, User_UserRegister_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserPasswordForgot(ctx context.Context, in *UserPasswordForgotRequest, opts ...grpc.CallOption) (*UserPasswordForgotReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserPasswordForgotReply)
+	err := c.cc.Invoke(ctx, User_UserPasswordForgot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserPasswordReset(ctx context.Context, in *UserPasswordResetRequest, opts ...grpc.CallOption) (*UserPasswordResetReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserPasswordResetReply)
+	err := c.cc.Invoke(ctx, User_UserPasswordReset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) RefreshAuthorization(ctx context.Context, in *RefreshAuthorizationRequest, opts ...grpc.CallOption) (*RefreshAuthorizationReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefreshAuthorizationReply)
+	err := c.cc.Invoke(ctx, User_RefreshAuthorization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserAccessKeyCreate(ctx context.Context, in *UserAccessKeyCreateRequest, opts ...grpc.CallOption) (*UserAccessKeyCreateReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserAccessKeyCreateReply)
+	err := c.cc.Invoke(ctx, User_UserAccessKeyCreate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserAccessKeyList(ctx context.Context, in *UserAccessKeyListRequest, opts ...grpc.CallOption) (*UserAccessKeyListReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserAccessKeyListReply)
+	err := c.cc.Invoke(ctx, User_UserAccessKeyList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserAccessKeyDelete(ctx context.Context, in *UserAccessKeyDeleteRequest, opts ...grpc.CallOption) (*UserAccessKeyDeleteReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserAccessKeyDeleteReply)
+	err := c.cc.Invoke(ctx, User_UserAccessKeyDelete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserSshKeyCreate(ctx context.Context, in *UserSshKeyCreateRequest, opts ...grpc.CallOption) (*UserSshKeyCreateReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserSshKeyCreateReply)
+	err := c.cc.Invoke(ctx, User_UserSshKeyCreate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserSshKeyList(ctx context.Context, in *UserSshKeyListRequest, opts ...grpc.CallOption) (*UserSshKeyListReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserSshKeyListReply)
+	err := c.cc.Invoke(ctx, User_UserSshKeyList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserSshKeyDelete(ctx context.Context, in *UserSshKeyDeleteRequest, opts ...grpc.CallOption) (*UserSshKeyDeleteReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserSshKeyDeleteReply)
+	err := c.cc.Invoke(ctx, User_UserSshKeyDelete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserTokenCreate(ctx context.Context, in *UserTokenCreateRequest, opts ...grpc.CallOption) (*UserTokenCreateReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserTokenCreateReply)
+	err := c.cc.Invoke(ctx, User_UserTokenCreate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserTokenList(ctx context.Context, in *UserTokenListRequest, opts ...grpc.CallOption) (*UserTokenListReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserTokenListReply)
+	err := c.cc.Invoke(ctx, User_UserTokenList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserTokenRevoke(ctx context.Context, in *UserTokenRevokeRequest, opts ...grpc.CallOption) (*UserTokenRevokeReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserTokenRevokeReply)
+	err := c.cc.Invoke(ctx, User_UserTokenRevoke_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserSessionList(ctx context.Context, in *UserSessionListRequest, opts ...grpc.CallOption) (*UserSessionListReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserSessionListReply)
+	err := c.cc.Invoke(ctx, User_UserSessionList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserSessionRevoke(ctx context.Context, in *UserSessionRevokeRequest, opts ...grpc.CallOption) (*UserSessionRevokeReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserSessionRevokeReply)
+	err := c.cc.Invoke(ctx, User_UserSessionRevoke_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserSessionRevokeOthers(ctx context.Context, in *UserSessionRevokeOthersRequest, opts ...grpc.CallOption) (*UserSessionRevokeOthersReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserSessionRevokeOthersReply)
+	err := c.cc.Invoke(ctx, User_UserSessionRevokeOthers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserTwoFactorEnroll(ctx context.Context, in *UserTwoFactorEnrollRequest, opts ...grpc.CallOption) (*UserTwoFactorEnrollReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserTwoFactorEnrollReply)
+	err := c.cc.Invoke(ctx, User_UserTwoFactorEnroll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserTwoFactorVerify(ctx context.Context, in *UserTwoFactorVerifyRequest, opts ...grpc.CallOption) (*UserTwoFactorVerifyReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserTwoFactorVerifyReply)
+	err := c.cc.Invoke(ctx, User_UserTwoFactorVerify_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserTwoFactorDisable(ctx context.Context, in *UserTwoFactorDisableRequest, opts ...grpc.CallOption) (*UserTwoFactorDisableReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserTwoFactorDisableReply)
+	err := c.cc.Invoke(ctx, User_UserTwoFactorDisable_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserRecoveryCodeRegenerate(ctx context.Context, in *UserRecoveryCodeRegenerateRequest, opts ...grpc.CallOption) (*UserRecoveryCodeRegenerateReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserRecoveryCodeRegenerateReply)
+	err := c.cc.Invoke(ctx, User_UserRecoveryCodeRegenerate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserServer is the server API for User service.
+// All implementations must embed UnimplementedUserServer
+// for forward compatibility.
+type UserServer interface {
+	// 用户登录
+	UserLogin(context.Context, *LoginRequest) (*LoginReply, error)
+	// 两步验证登录，完成 UserLogin 返回的挑战
+	UserLoginTwoFactor(context.Context, *LoginTwoFactorRequest) (*LoginReply, error)
+	// 用户详情
+	UserDetail(context.Context, *UserDetailRequest) (*UserDetailReply, error)
+	// 验证码发送
+	MailCodeSendRegister(context.Context, *MailCodeSendRequest) (*MailCodeSendReply, error)
+	// 用户注册
+	UserRegister(context.Context, *UserRegisterRequest) (*UserRegisterReply, error)
+	// 忘记密码，向邮箱发送重置链接
+	UserPasswordForgot(context.Context, *UserPasswordForgotRequest) (*UserPasswordForgotReply, error)
+	// 用重置链接中的令牌设置新密码
+	UserPasswordReset(context.Context, *UserPasswordResetRequest) (*UserPasswordResetReply, error)
+	// 刷新Authorization
+	RefreshAuthorization(context.Context, *RefreshAuthorizationRequest) (*RefreshAuthorizationReply, error)
+	// S3 访问密钥创建
+	UserAccessKeyCreate(context.Context, *UserAccessKeyCreateRequest) (*UserAccessKeyCreateReply, error)
+	// S3 访问密钥列表
+	UserAccessKeyList(context.Context, *UserAccessKeyListRequest) (*UserAccessKeyListReply, error)
+	// S3 访问密钥删除
+	UserAccessKeyDelete(context.Context, *UserAccessKeyDeleteRequest) (*UserAccessKeyDeleteReply, error)
+	// SSH 公钥添加
+	UserSshKeyCreate(context.Context, *UserSshKeyCreateRequest) (*UserSshKeyCreateReply, error)
+	// SSH 公钥列表
+	UserSshKeyList(context.Context, *UserSshKeyListRequest) (*UserSshKeyListReply, error)
+	// SSH 公钥删除
+	UserSshKeyDelete(context.Context, *UserSshKeyDeleteRequest) (*UserSshKeyDeleteReply, error)
+	// 个人访问令牌创建
+	UserTokenCreate(context.Context, *UserTokenCreateRequest) (*UserTokenCreateReply, error)
+	// 个人访问令牌列表
+	UserTokenList(context.Context, *UserTokenListRequest) (*UserTokenListReply, error)
+	// 个人访问令牌吊销
+	UserTokenRevoke(context.Context, *UserTokenRevokeRequest) (*UserTokenRevokeReply, error)
+	// 登录会话列表
+	UserSessionList(context.Context, *UserSessionListRequest) (*UserSessionListReply, error)
+	// 登录会话注销，其访问令牌立即失效
+	UserSessionRevoke(context.Context, *UserSessionRevokeRequest) (*UserSessionRevokeReply, error)
+	// 注销当前会话以外的所有登录会话
+	UserSessionRevokeOthers(context.Context, *UserSessionRevokeOthersRequest) (*UserSessionRevokeOthersReply, error)
+	// 两步验证：生成 TOTP 密钥
+	UserTwoFactorEnroll(context.Context, *UserTwoFactorEnrollRequest) (*UserTwoFactorEnrollReply, error)
+	// 两步验证：校验验证码并启用，返回恢复码
+	UserTwoFactorVerify(context.Context, *UserTwoFactorVerifyRequest) (*UserTwoFactorVerifyReply, error)
+	// 两步验证：停用
+	UserTwoFactorDisable(context.Context, *UserTwoFactorDisableRequest) (*UserTwoFactorDisableReply, error)
+	// 两步验证：重新生成恢复码
+	UserRecoveryCodeRegenerate(context.Context, *UserRecoveryCodeRegenerateRequest) (*UserRecoveryCodeRegenerateReply, error)
+	mustEmbedUnimplementedUserServer()
+}
+
+// UnimplementedUserServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUserServer struct{}
+
+func (UnimplementedUserServer) UserLogin(context.Context, *LoginRequest) (*LoginReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserLogin not implemented")
+}
+func (UnimplementedUserServer) UserLoginTwoFactor(context.Context, *LoginTwoFactorRequest) (*LoginReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserLoginTwoFactor not implemented")
+}
+func (UnimplementedUserServer) UserDetail(context.Context, *UserDetailRequest) (*UserDetailReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserDetail not implemented")
+}
+func (UnimplementedUserServer) MailCodeSendRegister(context.Context, *MailCodeSendRequest) (*MailCodeSendReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MailCodeSendRegister not implemented")
+}
+func (UnimplementedUserServer) UserRegister(context.Context, *UserRegisterRequest) (*UserRegisterReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserRegister not implemented")
+}
+func (UnimplementedUserServer) UserPasswordForgot(context.Context, *UserPasswordForgotRequest) (*UserPasswordForgotReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserPasswordForgot not implemented")
+}
+func (UnimplementedUserServer) UserPasswordReset(context.Context, *UserPasswordResetRequest) (*UserPasswordResetReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserPasswordReset not implemented")
+}
+func (UnimplementedUserServer) RefreshAuthorization(context.Context, *RefreshAuthorizationRequest) (*RefreshAuthorizationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshAuthorization not implemented")
+}
+func (UnimplementedUserServer) UserAccessKeyCreate(context.Context, *UserAccessKeyCreateRequest) (*UserAccessKeyCreateReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserAccessKeyCreate not implemented")
+}
+func (UnimplementedUserServer) UserAccessKeyList(context.Context, *UserAccessKeyListRequest) (*UserAccessKeyListReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserAccessKeyList not implemented")
+}
+func (UnimplementedUserServer) UserAccessKeyDelete(context.Context, *UserAccessKeyDeleteRequest) (*UserAccessKeyDeleteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserAccessKeyDelete not implemented")
+}
+func (UnimplementedUserServer) UserSshKeyCreate(context.Context, *UserSshKeyCreateRequest) (*UserSshKeyCreateReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserSshKeyCreate not implemented")
+}
+func (UnimplementedUserServer) UserSshKeyList(context.Context, *UserSshKeyListRequest) (*UserSshKeyListReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserSshKeyList not implemented")
+}
+func (UnimplementedUserServer) UserSshKeyDelete(context.Context, *UserSshKeyDeleteRequest) (*UserSshKeyDeleteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserSshKeyDelete not implemented")
+}
+func (UnimplementedUserServer) UserTokenCreate(context.Context, *UserTokenCreateRequest) (*UserTokenCreateReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserTokenCreate not implemented")
+}
+func (UnimplementedUserServer) UserTokenList(context.Context, *UserTokenListRequest) (*UserTokenListReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserTokenList not implemented")
+}
+func (UnimplementedUserServer) UserTokenRevoke(context.Context, *UserTokenRevokeRequest) (*UserTokenRevokeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserTokenRevoke not implemented")
+}
+func (UnimplementedUserServer) UserSessionList(context.Context, *UserSessionListRequest) (*UserSessionListReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserSessionList not implemented")
+}
+func (UnimplementedUserServer) UserSessionRevoke(context.Context, *UserSessionRevokeRequest) (*UserSessionRevokeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserSessionRevoke not implemented")
+}
+func (UnimplementedUserServer) UserSessionRevokeOthers(context.Context, *UserSessionRevokeOthersRequest) (*UserSessionRevokeOthersReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserSessionRevokeOthers not implemented")
+}
+func (UnimplementedUserServer) UserTwoFactorEnroll(context.Context, *UserTwoFactorEnrollRequest) (*UserTwoFactorEnrollReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserTwoFactorEnroll not implemented")
+}
+func (UnimplementedUserServer) UserTwoFactorVerify(context.Context, *UserTwoFactorVerifyRequest) (*UserTwoFactorVerifyReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserTwoFactorVerify not implemented")
+}
+func (UnimplementedUserServer) UserTwoFactorDisable(context.Context, *UserTwoFactorDisableRequest) (*UserTwoFactorDisableReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserTwoFactorDisable not implemented")
+}
+func (UnimplementedUserServer) UserRecoveryCodeRegenerate(context.Context, *UserRecoveryCodeRegenerateRequest) (*UserRecoveryCodeRegenerateReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserRecoveryCodeRegenerate not implemented")
+}
+func (UnimplementedUserServer) mustEmbedUnimplementedUserServer() {}
+func (UnimplementedUserServer) testEmbeddedByValue()              {}
+
+// UnsafeUserServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserServer will
+// result in compilation errors.
+type UnsafeUserServer interface {
+	mustEmbedUnimplementedUserServer()
+}
+
+func RegisterUserServer(s grpc.ServiceRegistrar, srv UserServer) {
+	// If the following call pancis, it indicates UnimplementedUserServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&User_ServiceDesc, srv)
+}
+
+func _User_UserLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_UserLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserLogin(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserLoginTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginTwoFactorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserLoginTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_UserLoginTwoFactor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserLoginTwoFactor(ctx, req.(*LoginTwoFactorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserDetailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_UserDetail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserDetail(ctx, req.(*UserDetailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_MailCodeSendRegister_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MailCodeSendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).MailCodeSendRegister(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_MailCodeSendRegister_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).MailCodeSendRegister(ctx, req.(*MailCodeSendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserRegister_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserRegister(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_UserRegister_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserRegister(ctx, req.(*UserRegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserPasswordForgot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserPasswordForgotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserPasswordForgot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_UserPasswordForgot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserPasswordForgot(ctx, req.(*UserPasswordForgotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserPasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserPasswordResetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserPasswordReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_UserPasswordReset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserPasswordReset(ctx, req.(*UserPasswordResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_RefreshAuthorization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshAuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).RefreshAuthorization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_RefreshAuthorization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).RefreshAuthorization(ctx, req.(*RefreshAuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserAccessKeyCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserAccessKeyCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserAccessKeyCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_UserAccessKeyCreate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserAccessKeyCreate(ctx, req.(*UserAccessKeyCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserAccessKeyList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserAccessKeyListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserAccessKeyList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_UserAccessKeyList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserAccessKeyList(ctx, req.(*UserAccessKeyListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserAccessKeyDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserAccessKeyDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserAccessKeyDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_UserAccessKeyDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserAccessKeyDelete(ctx, req.(*UserAccessKeyDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserSshKeyCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserSshKeyCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserSshKeyCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_UserSshKeyCreate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserSshKeyCreate(ctx, req.(*UserSshKeyCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserSshKeyList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserSshKeyListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserSshKeyList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_UserSshKeyList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserSshKeyList(ctx, req.(*UserSshKeyListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserSshKeyDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserSshKeyDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserSshKeyDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_UserSshKeyDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserSshKeyDelete(ctx, req.(*UserSshKeyDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserTokenCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserTokenCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserTokenCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_UserTokenCreate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserTokenCreate(ctx, req.(*UserTokenCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserTokenList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserTokenListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserTokenList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_UserTokenList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserTokenList(ctx, req.(*UserTokenListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserTokenRevoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserTokenRevokeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserTokenRevoke(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_UserTokenRevoke_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserTokenRevoke(ctx, req.(*UserTokenRevokeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserSessionList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserSessionListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserSessionList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_UserSessionList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserSessionList(ctx, req.(*UserSessionListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserSessionRevoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserSessionRevokeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserSessionRevoke(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_UserSessionRevoke_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserSessionRevoke(ctx, req.(*UserSessionRevokeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserSessionRevokeOthers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserSessionRevokeOthersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserSessionRevokeOthers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_UserSessionRevokeOthers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserSessionRevokeOthers(ctx, req.(*UserSessionRevokeOthersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserTwoFactorEnroll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserTwoFactorEnrollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserTwoFactorEnroll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_UserTwoFactorEnroll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserTwoFactorEnroll(ctx, req.(*UserTwoFactorEnrollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserTwoFactorVerify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserTwoFactorVerifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserTwoFactorVerify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_UserTwoFactorVerify_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserTwoFactorVerify(ctx, req.(*UserTwoFactorVerifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserTwoFactorDisable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserTwoFactorDisableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserTwoFactorDisable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_UserTwoFactorDisable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserTwoFactorDisable(ctx, req.(*UserTwoFactorDisableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserRecoveryCodeRegenerate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRecoveryCodeRegenerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserRecoveryCodeRegenerate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_UserRecoveryCodeRegenerate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserRecoveryCodeRegenerate(ctx, req.(*UserRecoveryCodeRegenerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// User_ServiceDesc is the grpc.ServiceDesc for User service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var User_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "user.user",
+	HandlerType: (*UserServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UserLogin",
+			Handler:    _User_UserLogin_Handler,
+		},
+		{
+			MethodName: "UserLoginTwoFactor",
+			Handler:    _User_UserLoginTwoFactor_Handler,
+		},
+		{
+			MethodName: "UserDetail",
+			Handler:    _User_UserDetail_Handler,
+		},
+		{
+			MethodName: "MailCodeSendRegister",
+			Handler:    _User_MailCodeSendRegister_Handler,
+		},
+		{
+			MethodName: "UserRegister",
+			Handler:    _User_UserRegister_Handler,
+		},
+		{
+			MethodName: "UserPasswordForgot",
+			Handler:    _User_UserPasswordForgot_Handler,
+		},
+		{
+			MethodName: "UserPasswordReset",
+			Handler:    _User_UserPasswordReset_Handler,
+		},
+		{
+			MethodName: "RefreshAuthorization",
+			Handler:    _User_RefreshAuthorization_Handler,
+		},
+		{
+			MethodName: "UserAccessKeyCreate",
+			Handler:    _User_UserAccessKeyCreate_Handler,
+		},
+		{
+			MethodName: "UserAccessKeyList",
+			Handler:    _User_UserAccessKeyList_Handler,
+		},
+		{
+			MethodName: "UserAccessKeyDelete",
+			Handler:    _User_UserAccessKeyDelete_Handler,
+		},
+		{
+			MethodName: "UserSshKeyCreate",
+			Handler:    _User_UserSshKeyCreate_Handler,
+		},
+		{
+			MethodName: "UserSshKeyList",
+			Handler:    _User_UserSshKeyList_Handler,
+		},
+		{
+			MethodName: "UserSshKeyDelete",
+			Handler:    _User_UserSshKeyDelete_Handler,
+		},
+		{
+			MethodName: "UserTokenCreate",
+			Handler:    _User_UserTokenCreate_Handler,
+		},
+		{
+			MethodName: "UserTokenList",
+			Handler:    _User_UserTokenList_Handler,
+		},
+		{
+			MethodName: "UserTokenRevoke",
+			Handler:    _User_UserTokenRevoke_Handler,
+		},
+		{
+			MethodName: "UserSessionList",
+			Handler:    _User_UserSessionList_Handler,
+		},
+		{
+			MethodName: "UserSessionRevoke",
+			Handler:    _User_UserSessionRevoke_Handler,
+		},
+		{
+			MethodName: "UserSessionRevokeOthers",
+			Handler:    _User_UserSessionRevokeOthers_Handler,
+		},
+		{
+			MethodName: "UserTwoFactorEnroll",
+			Handler:    _User_UserTwoFactorEnroll_Handler,
+		},
+		{
+			MethodName: "UserTwoFactorVerify",
+			Handler:    _User_UserTwoFactorVerify_Handler,
+		},
+		{
+			MethodName: "UserTwoFactorDisable",
+			Handler:    _User_UserTwoFactorDisable_Handler,
+		},
+		{
+			MethodName: "UserRecoveryCodeRegenerate",
+			Handler:    _User_UserRecoveryCodeRegenerate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "user.proto",
+}
@@ -0,0 +1,29 @@
+package mw
+
+import (
+	"cloud-storage/biz/logging"
+	"context"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+// AccessLog logs every request once it is served. It goes after
+// RequestIDHandler, so that the records carry the request ID and, once the
+// request is authenticated, the user.
+func AccessLog() app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		start := time.Now()
+		c.Next(ctx)
+
+		log.InfoContext(ctx, "request",
+			"method", string(c.Method()),
+			"path", logging.RedactQuery(string(c.Request.URI().RequestURI())),
+			"route", c.FullPath(),
+			"status", c.Response.StatusCode(),
+			"duration", time.Since(start),
+			"bytes", c.Response.Header.ContentLength(),
+			"client_ip", c.ClientIP(),
+			"user_agent", string(c.UserAgent()))
+	}
+}
@@ -0,0 +1,34 @@
+package mw
+
+import (
+	"cloud-storage/biz/errno"
+	"cloud-storage/biz/service"
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+// RequireAdmin rejects the requests of users who are not administrators,
+// and, if so configured, of administrators who logged in without a second
+// factor. It goes after JwtMiddleware.
+func RequireAdmin() app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		admin, err := service.Users.IsAdmin(ctx, UserIdentity(c))
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		if !admin {
+			c.Error(errno.New(errno.PermissionDenied, "administrators only"))
+			c.Abort()
+			return
+		}
+		if adminTwoFactor && !TwoFactor(c) {
+			c.Error(errno.New(errno.TwoFactorRequired, "administrators must log in with two-factor authentication"))
+			c.Abort()
+			return
+		}
+		c.Next(ctx)
+	}
+}
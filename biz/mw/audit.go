@@ -0,0 +1,16 @@
+package mw
+
+import (
+	"cloud-storage/biz/audit"
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+// AuditClient makes the actions audited while serving a request record the
+// address and user agent of its client.
+func AuditClient() app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		c.Next(audit.WithClient(ctx, c.ClientIP(), string(c.UserAgent())))
+	}
+}
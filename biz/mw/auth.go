@@ -0,0 +1,49 @@
+package mw
+
+import (
+	"cloud-storage/biz/errno"
+	"cloud-storage/biz/logging"
+	"cloud-storage/biz/service"
+	"context"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+)
+
+// Auth authenticates requests by the JWT of a login, as JwtMiddleware does,
+// or by a personal access token, which must grant scope. The endpoints
+// managing the account and its credentials pass an empty scope: they take
+// no personal access token, so that a leaked one cannot mint others.
+func Auth(scope string) app.HandlerFunc {
+	jwtAuth := JwtMiddleware.MiddlewareFunc()
+	return func(ctx context.Context, c *app.RequestContext) {
+		token := strings.TrimPrefix(string(c.GetHeader(consts.HeaderAuthorization)), JwtMiddleware.TokenHeadName+" ")
+		if !service.IsAPIToken(token) {
+			jwtAuth(ctx, c)
+			return
+		}
+		if scope == "" {
+			c.Error(errno.New(errno.PermissionDenied, "personal access tokens cannot be used here"))
+			c.Abort()
+			return
+		}
+		auth, err := service.Users.AuthenticateAPIToken(ctx, token, c.ClientIP())
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		if !auth.Allows(scope) {
+			c.Error(errno.New(errno.PermissionDenied, "access token lacks the %s scope", scope))
+			c.Abort()
+			return
+		}
+		c.Set(JwtMiddleware.IdentityKey, map[string]interface{}{
+			"identity": auth.UserIdentity,
+			"token":    auth.TokenIdentity,
+		})
+		logging.SetUser(ctx, auth.UserIdentity)
+		c.Next(ctx)
+	}
+}
@@ -0,0 +1,77 @@
+package mw
+
+import (
+	"cloud-storage/biz/errno"
+	"cloud-storage/biz/logging"
+	"cloud-storage/biz/tracing"
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrorHandler responds to requests whose handler failed with c.Error. The
+// last error is rendered as an errno.Body with the status code matching its
+// code; the cause of the error is logged, never sent.
+func ErrorHandler() app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		c.Next(ctx)
+		last := c.Errors.Last()
+		if last == nil {
+			return
+		}
+		e := errno.From(last.Err)
+		traceID := tracing.TraceID(ctx)
+		if e.Code == errno.Internal {
+			log.ErrorContext(ctx, "request failed",
+				"method", string(c.Method()),
+				"path", logging.RedactQuery(string(c.Request.URI().RequestURI())),
+				"error", last.Err)
+			span := trace.SpanFromContext(ctx)
+			span.RecordError(last.Err)
+			span.SetStatus(codes.Error, e.Message)
+		}
+		if e.RetryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(retryAfterSeconds(e.RetryAfter)))
+		}
+		c.JSON(StatusCode(e.Code), &errno.Body{
+			Code:      e.Code,
+			Message:   e.Message,
+			RequestID: RequestID(c),
+			TraceID:   traceID,
+		})
+	}
+}
+
+// StatusCode returns the HTTP status code of responses failing with code.
+func StatusCode(code errno.Code) int {
+	switch code {
+	case errno.InvalidArgument, errno.InvalidResetToken:
+		return consts.StatusBadRequest
+	case errno.Unauthenticated, errno.InvalidCredentials, errno.InvalidTwoFactor:
+		return consts.StatusUnauthorized
+	case errno.PermissionDenied, errno.AccountDisabled, errno.TwoFactorRequired:
+		return consts.StatusForbidden
+	case errno.FileNotFound, errno.FolderNotFound, errno.ShareNotFound, errno.JobNotFound, errno.UserNotFound, errno.TokenNotFound, errno.SessionNotFound:
+		return consts.StatusNotFound
+	case errno.NameConflict, errno.SSHKeyConflict, errno.JobNotFailed, errno.TwoFactorEnabled, errno.TwoFactorDisabled:
+		return consts.StatusConflict
+	case errno.ShareExpired:
+		return consts.StatusGone
+	case errno.QuotaExceeded:
+		return consts.StatusInsufficientStorage
+	case errno.RateLimited, errno.AccountLocked:
+		return consts.StatusTooManyRequests
+	}
+	return consts.StatusInternalServerError
+}
+
+// retryAfterSeconds rounds d up to the whole seconds of a Retry-After
+// header, so that clients waiting for it are not refused again.
+func retryAfterSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
@@ -1,38 +1,263 @@
 package mw
 
 import (
+	"cloud-storage/biz/config"
 	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/errno"
+	"cloud-storage/biz/logging"
+	"cloud-storage/biz/service"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"strings"
+	"time"
+
 	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	gojwt "github.com/golang-jwt/jwt/v4"
 	"github.com/hertz-contrib/jwt"
 )
 
 var JwtMiddleware *jwt.HertzJWTMiddleware
 
-func InitJwt() {
+var log = logging.Logger("http")
+
+var (
+	// challengeKey signs challenge tokens. It is derived from the key of
+	// access tokens, so that neither kind passes for the other.
+	challengeKey []byte
+	challengeTTL time.Duration
+	// adminTwoFactor makes RequireAdmin refuse logins without a second
+	// factor
+	adminTwoFactor bool
+)
+
+// challengeAudience marks challenge tokens.
+const challengeAudience = "two-factor"
+
+// session is what an access token is issued for: a session of a user.
+type session struct {
+	user     *entity.UserBasic
+	identity string
+}
+
+// Login is what an access token stands for.
+type Login struct {
+	UserIdentity string
+	// Session is the identity of the session the token was issued for
+	Session string
+	// IssuedAt is when the user logged in
+	IssuedAt time.Time
+}
+
+func InitJwt(c *config.JWT, tf *config.TwoFactor) {
+	mac := hmac.New(sha256.New, []byte(c.Key))
+	mac.Write([]byte("two-factor challenge"))
+	challengeKey = mac.Sum(nil)
+	challengeTTL = tf.ChallengeTTL
+	adminTwoFactor = tf.RequireAdmin
+
 	var err error
 	JwtMiddleware, err = jwt.New(&jwt.HertzJWTMiddleware{
-		Key: []byte("llmons"),
+		Key:        []byte(c.Key),
+		Timeout:    c.TTL,
+		MaxRefresh: c.MaxRefresh,
 		PayloadFunc: func(data interface{}) jwt.MapClaims {
-			if userBasic, ok := data.(entity.UserBasic); ok {
+			if s, ok := data.(session); ok {
 				return jwt.MapClaims{
-					"id":       userBasic.ID,
-					"identity": userBasic.Identity,
-					"name":     userBasic.Name,
+					"id":       s.user.ID,
+					"identity": s.user.Identity,
+					"name":     s.user.Name,
+					// Users with two-factor authentication only get
+					// tokens once they gave their code
+					"mfa": s.user.TotpEnabled,
+					"sid": s.identity,
 				}
 			}
 			return jwt.MapClaims{}
 		},
+		Unauthorized: func(ctx context.Context, c *app.RequestContext, code int, message string) {
+			// Authorizator reports why it refused a token itself
+			if len(c.Errors) > 0 {
+				return
+			}
+			if code == consts.StatusForbidden {
+				c.Error(errno.New(errno.PermissionDenied, "%s", message))
+				return
+			}
+			c.Error(errno.New(errno.Unauthenticated, "%s", message))
+		},
 		IdentityHandler: func(ctx context.Context, c *app.RequestContext) interface{} {
 			claims := jwt.ExtractClaims(ctx, c)
 			return map[string]interface{}{
 				"id":       claims["id"],
 				"identity": claims["identity"],
 				"name":     claims["name"],
+				"mfa":      claims["mfa"],
+				"session":  claims["sid"],
 			}
 		},
+		// Tokens outlive the accounts they were issued for being disabled,
+		// the passwords they were issued for being changed and their
+		// sessions being signed out
+		Authorizator: func(data interface{}, ctx context.Context, c *app.RequestContext) bool {
+			claims := jwt.ExtractClaims(ctx, c)
+			identity, _ := claims["identity"].(string)
+			sid, _ := claims["sid"].(string)
+			if err := service.Users.CheckLogin(ctx, identity, sid, issuedAt(claims)); err != nil {
+				c.Error(err)
+				return false
+			}
+			logging.SetUser(ctx, identity)
+			return true
+		},
 	})
 	if err != nil {
 		panic(err)
 	}
 }
+
+// UserIdentity returns the identity of the user authenticated by JwtMiddleware,
+// or an empty string if the request carries no valid token.
+func UserIdentity(c *app.RequestContext) string {
+	v, ok := c.Get(JwtMiddleware.IdentityKey)
+	if !ok {
+		return ""
+	}
+	claims, ok := v.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	identity, _ := claims["identity"].(string)
+	return identity
+}
+
+// TwoFactor reports whether the user authenticated by JwtMiddleware logged
+// in with a second factor.
+func TwoFactor(c *app.RequestContext) bool {
+	v, _ := c.Get(JwtMiddleware.IdentityKey)
+	claims, _ := v.(map[string]interface{})
+	mfa, _ := claims["mfa"].(bool)
+	return mfa
+}
+
+// SessionIdentity returns the identity of the session of the user
+// authenticated by JwtMiddleware, or an empty string if there is none.
+func SessionIdentity(c *app.RequestContext) string {
+	v, _ := c.Get(JwtMiddleware.IdentityKey)
+	claims, _ := v.(map[string]interface{})
+	identity, _ := claims["session"].(string)
+	return identity
+}
+
+// TokenLogin validates a token issued by JwtMiddleware, with or without its
+// "Bearer" prefix, and returns the login it stands for.
+func TokenLogin(token string) (*Login, error) {
+	t, err := JwtMiddleware.ParseTokenString(strings.TrimPrefix(token, JwtMiddleware.TokenHeadName+" "))
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := t.Claims.(gojwt.MapClaims)
+	if !ok || !t.Valid {
+		return nil, jwt.ErrInvalidAuthHeader
+	}
+	return loginOf(claims)
+}
+
+// RefreshToken issues a new access token for the session of the token of
+// the request, which may have expired as long as it was issued within the
+// refresh window. The session must still be valid; it then lasts as long
+// as the new token.
+func RefreshToken(ctx context.Context, c *app.RequestContext) (string, error) {
+	claims, err := JwtMiddleware.CheckIfTokenExpire(ctx, c)
+	if err != nil {
+		return "", errno.Wrap(errno.Unauthenticated, err, "invalid token or too late to refresh it")
+	}
+	login, err := loginOf(claims)
+	if err != nil {
+		return "", errno.Wrap(errno.Unauthenticated, err, "invalid token")
+	}
+	// The claims are copied over, so that a second factor given since
+	// does not count for the login
+	token, expire, err := JwtMiddleware.RefreshToken(ctx, c)
+	if err != nil {
+		return "", errno.Wrap(errno.Internal, err, "failed to generate token")
+	}
+	if err := service.Users.RefreshSession(ctx, login.UserIdentity, login.Session, login.IssuedAt, sessionEnd(expire)); err != nil {
+		return "", err
+	}
+	logging.SetUser(ctx, login.UserIdentity)
+	return token, nil
+}
+
+// loginOf returns the login the claims of an access token stand for.
+func loginOf(claims map[string]interface{}) (*Login, error) {
+	identity, _ := claims["identity"].(string)
+	if identity == "" {
+		return nil, jwt.ErrInvalidAuthHeader
+	}
+	sid, _ := claims["sid"].(string)
+	return &Login{UserIdentity: identity, Session: sid, IssuedAt: issuedAt(claims)}, nil
+}
+
+// issuedAt returns when the token of claims was issued, the zero time if it
+// does not say.
+func issuedAt(claims map[string]interface{}) time.Time {
+	switch v := claims["orig_iat"].(type) {
+	case float64:
+		return time.Unix(int64(v), 0)
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return time.Unix(n, 0)
+		}
+	}
+	return time.Time{}
+}
+
+// GenerateToken issues the access token of a new session of the user and
+// returns it with when the session ends unless the token is refreshed.
+func GenerateToken(u *entity.UserBasic, sessionIdentity string) (string, time.Time, error) {
+	token, expire, err := JwtMiddleware.TokenGenerator(session{user: u, identity: sessionIdentity})
+	return token, sessionEnd(expire), err
+}
+
+// sessionEnd returns when the session of a token expiring at expire ends:
+// when the token expires, or can no longer be refreshed if that is later.
+func sessionEnd(expire time.Time) time.Time {
+	if refresh := JwtMiddleware.TimeFunc().Add(JwtMiddleware.MaxRefresh); refresh.After(expire) {
+		return refresh
+	}
+	return expire
+}
+
+// GenerateChallenge issues the challenge token of a user who gave their
+// password but still owes the second factor.
+func GenerateChallenge(u *entity.UserBasic) (string, error) {
+	now := time.Now()
+	return gojwt.NewWithClaims(gojwt.SigningMethodHS256, gojwt.RegisteredClaims{
+		Subject:   u.Identity,
+		Audience:  gojwt.ClaimStrings{challengeAudience},
+		IssuedAt:  gojwt.NewNumericDate(now),
+		ExpiresAt: gojwt.NewNumericDate(now.Add(challengeTTL)),
+	}).SignedString(challengeKey)
+}
+
+// ChallengeIdentity validates a challenge token of GenerateChallenge and
+// returns the identity of the user it belongs to.
+func ChallengeIdentity(token string) (string, error) {
+	var claims gojwt.RegisteredClaims
+	t, err := gojwt.ParseWithClaims(token, &claims, func(t *gojwt.Token) (interface{}, error) {
+		if t.Method != gojwt.SigningMethodHS256 {
+			return nil, jwt.ErrInvalidSigningAlgorithm
+		}
+		return challengeKey, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if !t.Valid || !claims.VerifyAudience(challengeAudience, true) || claims.Subject == "" {
+		return "", jwt.ErrInvalidAuthHeader
+	}
+	return claims.Subject, nil
+}
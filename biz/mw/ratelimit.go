@@ -0,0 +1,79 @@
+package mw
+
+import (
+	"cloud-storage/biz/errno"
+	"cloud-storage/biz/metrics"
+	"cloud-storage/biz/ratelimit"
+	"context"
+	"net"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var httpRateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "http",
+	Name:      "rate_limited_total",
+	Help:      "HTTP requests refused by a rate limit, by limit.",
+}, []string{"limit"})
+
+// RateLimit refuses requests beyond the rate limit called name, see package
+// ratelimit, with 429 Too Many Requests and a Retry-After header. Requests
+// are counted per the key returned by key; those without one are not
+// limited. If the limits cannot be checked, requests are let through rather
+// than making the endpoint unavailable.
+func RateLimit(name string, key func(c *app.RequestContext) string) app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		k := key(c)
+		if k == "" {
+			c.Next(ctx)
+			return
+		}
+		retryAfter, err := ratelimit.Allow(ctx, name, k)
+		if err != nil {
+			log.WarnContext(ctx, "failed to check rate limit", "limit", name, "error", err)
+			c.Next(ctx)
+			return
+		}
+		if retryAfter > 0 {
+			httpRateLimited.WithLabelValues(name).Inc()
+			e := errno.New(errno.RateLimited, "too many requests")
+			e.RetryAfter = retryAfter
+			c.Error(e)
+			c.Abort()
+			return
+		}
+		c.Next(ctx)
+	}
+}
+
+// ClientIP keys rate limits by the address of the client.
+func ClientIP(c *app.RequestContext) string {
+	return c.ClientIP()
+}
+
+// ClientIPFunc returns how RequestContext.ClientIP finds the address of
+// clients: the socket address, unless the peer is one of proxies, whose
+// X-Forwarded-For and X-Real-IP headers are then believed. Clients could
+// otherwise pick any address, and so escape rate limits.
+func ClientIPFunc(proxies []*net.IPNet) app.ClientIP {
+	return app.ClientIPWithOption(app.ClientIPOptions{
+		RemoteIPHeaders: []string{"X-Forwarded-For", "X-Real-IP"},
+		TrustedCIDRs:    proxies,
+	})
+}
+
+// Field keys rate limits by a field of the request, bound into a new T as
+// the handler binds it, whether it came in the query, a form, JSON or, over
+// gRPC, protobuf.
+func Field[T any](field func(req *T) string) func(c *app.RequestContext) string {
+	return func(c *app.RequestContext) string {
+		var req T
+		if err := c.Bind(&req); err != nil {
+			return ""
+		}
+		return field(&req)
+	}
+}
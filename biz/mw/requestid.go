@@ -0,0 +1,49 @@
+package mw
+
+import (
+	"cloud-storage/biz/logging"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+// RequestIDHeader carries the ID of a request, both ways.
+const RequestIDHeader = "X-Request-ID"
+
+const requestIDKey = "request_id"
+
+// RequestIDHandler assigns every request an ID, the one sent by the client
+// if it is usable, and returns it in the response header. The records logged
+// with the context of the request carry the ID.
+func RequestIDHandler() app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		id := string(c.GetHeader(RequestIDHeader))
+		if !validRequestID(id) {
+			b := make([]byte, 16)
+			_, _ = rand.Read(b)
+			id = hex.EncodeToString(b)
+		}
+		c.Set(requestIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Next(logging.WithRequest(ctx, id))
+	}
+}
+
+// RequestID returns the ID assigned to the request by RequestIDHandler.
+func RequestID(c *app.RequestContext) string {
+	return c.GetString(requestIDKey)
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 64 {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often MemoryStore drops the buckets that are full
+// again, which behave like buckets never used.
+const sweepInterval = time.Minute
+
+// MemoryStore keeps the buckets in memory.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens float64
+	// last is when tokens was last brought up to date
+	last time.Time
+	// full is when the bucket is full again if left alone
+	full time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket), lastSweep: time.Now()}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, l Limit) (time.Duration, error) {
+	now := time.Now()
+	rate := float64(l.N) / float64(l.Per) // tokens per nanosecond
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.lastSweep) >= sweepInterval {
+		s.sweep(now)
+	}
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.N), last: now}
+		s.buckets[key] = b
+	}
+	b.refill(now, l.N, rate)
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) / rate), nil
+	}
+	b.tokens--
+	b.full = now.Add(time.Duration((float64(l.N) - b.tokens) / rate))
+	return 0, nil
+}
+
+func (s *MemoryStore) Peek(_ context.Context, key string, l Limit) (time.Duration, error) {
+	now := time.Now()
+	rate := float64(l.N) / float64(l.Per)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.buckets[key]
+	if !ok {
+		return 0, nil
+	}
+	b.refill(now, l.N, rate)
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) / rate), nil
+	}
+	return 0, nil
+}
+
+// refill adds the tokens that came in since the bucket was last brought up
+// to date, up to n.
+func (b *bucket) refill(now time.Time, n int, rate float64) {
+	b.tokens += float64(now.Sub(b.last)) * rate
+	if b.tokens > float64(n) {
+		b.tokens = float64(n)
+	}
+	b.last = now
+}
+
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if !now.Before(b.full) {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
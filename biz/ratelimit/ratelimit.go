@@ -0,0 +1,137 @@
+// Package ratelimit throttles requests with token buckets: each key, such
+// as a client IP address or a user name, has a bucket of N tokens refilled
+// at N per period, and a request takes one token or is refused.
+//
+// The buckets live in a Store. MemoryStore keeps them in the process, which
+// is enough for a single server; servers behind a load balancer share a
+// Store of their own, e.g. on Redis, so that their limits add up.
+package ratelimit
+
+import (
+	"cloud-storage/biz/config"
+	"context"
+	"time"
+)
+
+// The limits, named as in the configuration.
+const (
+	LoginIP            = "login_ip"
+	LoginUser          = "login_user"
+	MailCodeIP         = "mail_code_ip"
+	MailCodeEmail      = "mail_code_email"
+	PasswordResetIP    = "password_reset_ip"
+	PasswordResetEmail = "password_reset_email"
+	ShareIP            = "share_ip"
+	Share              = "share"
+)
+
+// Limit allows N requests per period Per, in bursts of up to N.
+type Limit struct {
+	N   int
+	Per time.Duration
+}
+
+// Store keeps the token buckets.
+type Store interface {
+	// Take takes a token from the bucket of key, which is full when first
+	// used. If the bucket is empty, it returns how long until a token is
+	// available and takes nothing.
+	Take(ctx context.Context, key string, l Limit) (retryAfter time.Duration, err error)
+	// Peek returns how long until the bucket of key has a token, 0 if it
+	// has one now, without taking it.
+	Peek(ctx context.Context, key string, l Limit) (retryAfter time.Duration, err error)
+}
+
+var (
+	store  Store
+	limits = map[string]Limit{}
+)
+
+// Init sets up the limits configured in c on store, a new MemoryStore if
+// nil. Until Init is called, every request is allowed.
+func Init(c *config.RateLimit, s Store) error {
+	rates := map[string]string{
+		LoginIP:            c.LoginIP,
+		LoginUser:          c.LoginUser,
+		MailCodeIP:         c.MailCodeIP,
+		MailCodeEmail:      c.MailCodeEmail,
+		PasswordResetIP:    c.PasswordResetIP,
+		PasswordResetEmail: c.PasswordResetEmail,
+		ShareIP:            c.ShareIP,
+		Share:              c.Share,
+	}
+	l := make(map[string]Limit, len(rates))
+	for name, rate := range rates {
+		n, per, err := config.ParseRate(rate)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			l[name] = Limit{N: n, Per: per}
+		}
+	}
+	if s == nil {
+		s = NewMemoryStore()
+	}
+	store, limits = s, l
+	return nil
+}
+
+// Allow takes a token for key from the limit called name. If there is none,
+// it returns how long until there is; 0 means the request is allowed,
+// always so if the limit is disabled.
+func Allow(ctx context.Context, name, key string) (time.Duration, error) {
+	l, ok := limits[name]
+	if !ok {
+		return 0, nil
+	}
+	return store.Take(ctx, name+":"+key, l)
+}
+
+// Check returns how long until the limit called name has a token for key,
+// like Allow but without taking it. Checking every attempt and calling
+// Allow only for those that fail limits the failures alone, for clients
+// that log in with every request, such as WebDAV ones.
+func Check(ctx context.Context, name, key string) (time.Duration, error) {
+	l, ok := limits[name]
+	if !ok {
+		return 0, nil
+	}
+	return store.Peek(ctx, name+":"+key, l)
+}
+
+// Login is a client logging in by password over a protocol other than the
+// HTTP API, such as WebDAV or SFTP, under the LoginIP and LoginUser limits.
+// Only failed attempts take tokens: Check before authenticating, Fail when
+// the credentials are wrong.
+type Login struct {
+	IP   string
+	Name string
+}
+
+func (l Login) keys() map[string]string {
+	return map[string]string{LoginIP: l.IP, LoginUser: l.Name}
+}
+
+// Check returns how long until the client may try again, 0 if it may now.
+func (l Login) Check(ctx context.Context) (time.Duration, error) {
+	var wait time.Duration
+	for name, key := range l.keys() {
+		d, err := Check(ctx, name, key)
+		if err != nil {
+			return 0, err
+		}
+		wait = max(wait, d)
+	}
+	return wait, nil
+}
+
+// Fail counts a failed attempt.
+func (l Login) Fail(ctx context.Context) error {
+	for name, key := range l.keys() {
+		if _, err := Allow(ctx, name, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package ratelimit
+
+import (
+	"cloud-storage/biz/config"
+	"context"
+	"testing"
+	"time"
+)
+
+// backdate moves the clock of the bucket of key back by d, as if d had
+// passed since it was last used.
+func backdate(s *MemoryStore, key string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := s.buckets[key]
+	b.last = b.last.Add(-d)
+	b.full = b.full.Add(-d)
+}
+
+func TestMemoryStoreTake(t *testing.T) {
+	ctx := context.Background()
+	l := Limit{N: 3, Per: time.Minute}
+
+	tests := []struct {
+		name string
+		// elapsed is how long to let pass before the takes
+		elapsed time.Duration
+		takes   int
+		// allowed is how many of the takes succeed
+		allowed int
+	}{
+		{"burst", 0, 3, 3},
+		{"over burst", 0, 5, 3},
+		{"one refilled", 20 * time.Second, 2, 1},
+		{"half refilled", 10 * time.Second, 1, 0},
+		{"all refilled", time.Minute, 4, 3},
+		{"refill capped at N", time.Hour, 4, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewMemoryStore()
+			if tt.elapsed > 0 {
+				// Empty the bucket first, then let time pass.
+				for i := 0; i < l.N; i++ {
+					s.Take(ctx, "k", l)
+				}
+				backdate(s, "k", tt.elapsed)
+			}
+			allowed := 0
+			var retry time.Duration
+			for i := 0; i < tt.takes; i++ {
+				d, err := s.Take(ctx, "k", l)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if d == 0 {
+					allowed++
+				} else {
+					retry = d
+				}
+			}
+			if allowed != tt.allowed {
+				t.Errorf("allowed %d of %d, want %d", allowed, tt.takes, tt.allowed)
+			}
+			if allowed < tt.takes && (retry <= 0 || retry > l.Per/time.Duration(l.N)) {
+				t.Errorf("retry after %v, want within (0, %v]", retry, l.Per/time.Duration(l.N))
+			}
+		})
+	}
+}
+
+func TestMemoryStoreKeys(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+	l := Limit{N: 1, Per: time.Minute}
+	if d, _ := s.Take(ctx, "a", l); d != 0 {
+		t.Fatalf("first take of a refused, retry after %v", d)
+	}
+	if d, _ := s.Take(ctx, "a", l); d == 0 {
+		t.Error("second take of a allowed")
+	}
+	if d, _ := s.Take(ctx, "b", l); d != 0 {
+		t.Errorf("take of b refused after a ran out, retry after %v", d)
+	}
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+	l := Limit{N: 2, Per: time.Minute}
+	s.Take(ctx, "full", l)
+	s.Take(ctx, "empty", l)
+	s.Take(ctx, "empty", l)
+	backdate(s, "full", 30*time.Second)
+	backdate(s, "empty", 30*time.Second)
+
+	s.sweep(time.Now())
+	if _, ok := s.buckets["full"]; ok {
+		t.Error("sweep kept a bucket that is full again")
+	}
+	if _, ok := s.buckets["empty"]; !ok {
+		t.Error("sweep dropped a bucket still refilling")
+	}
+}
+
+func TestInit(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       config.RateLimit
+		wantErr bool
+		want    map[string]Limit
+	}{
+		{"disabled", config.RateLimit{}, false, map[string]Limit{}},
+		{
+			"some",
+			config.RateLimit{LoginIP: "10/1m", Share: "100/1h"},
+			false,
+			map[string]Limit{LoginIP: {10, time.Minute}, Share: {100, time.Hour}},
+		},
+		{"no period", config.RateLimit{LoginUser: "10"}, true, nil},
+		{"zero count", config.RateLimit{LoginUser: "0/1m"}, true, nil},
+		{"bad period", config.RateLimit{LoginUser: "10/soon"}, true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(s Store, l map[string]Limit) { store, limits = s, l }(store, limits)
+			err := Init(&tt.c, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Init error = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(limits) != len(tt.want) {
+				t.Fatalf("limits = %v, want %v", limits, tt.want)
+			}
+			for name, l := range tt.want {
+				if limits[name] != l {
+					t.Errorf("limit %s = %v, want %v", name, limits[name], l)
+				}
+			}
+		})
+	}
+}
+
+func TestAllow(t *testing.T) {
+	defer func(s Store, l map[string]Limit) { store, limits = s, l }(store, limits)
+	if err := Init(&config.RateLimit{LoginIP: "1/1m"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name, limit, key string
+		allowed          bool
+	}{
+		{"first", LoginIP, "10.0.0.1", true},
+		{"exhausted", LoginIP, "10.0.0.1", false},
+		{"other key", LoginIP, "10.0.0.2", true},
+		{"disabled limit", LoginUser, "10.0.0.1", true},
+		{"disabled limit again", LoginUser, "10.0.0.1", true},
+	}
+	for _, tt := range tests {
+		d, err := Allow(ctx, tt.limit, tt.key)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if (d == 0) != tt.allowed {
+			t.Errorf("%s: Allow(%s, %s) retry after %v, want allowed %v", tt.name, tt.limit, tt.key, d, tt.allowed)
+		}
+	}
+}
+
+func TestMemoryStorePeek(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+	l := Limit{N: 1, Per: time.Minute}
+	for i := 0; i < 2; i++ {
+		if d, _ := s.Peek(ctx, "k", l); d != 0 {
+			t.Fatalf("peek %d of an unused bucket: retry after %v", i, d)
+		}
+	}
+	s.Take(ctx, "k", l)
+	if d, _ := s.Peek(ctx, "k", l); d <= 0 || d > time.Minute {
+		t.Errorf("peek of an empty bucket: retry after %v, want within (0, 1m]", d)
+	}
+	backdate(s, "k", time.Minute)
+	if d, _ := s.Peek(ctx, "k", l); d != 0 {
+		t.Errorf("peek of a refilled bucket: retry after %v", d)
+	}
+	if d, _ := s.Take(ctx, "k", l); d != 0 {
+		t.Errorf("take after peeks refused, retry after %v", d)
+	}
+}
+
+func TestLogin(t *testing.T) {
+	defer func(s Store, l map[string]Limit) { store, limits = s, l }(store, limits)
+	if err := Init(&config.RateLimit{LoginIP: "3/1m", LoginUser: "2/1m"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	alice := Login{IP: "10.0.0.1", Name: "alice"}
+
+	for i := 0; i < 5; i++ {
+		if d, _ := alice.Check(ctx); d != 0 {
+			t.Fatalf("check %d without failures: retry after %v", i, d)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		alice.Fail(ctx)
+	}
+	if d, _ := alice.Check(ctx); d == 0 {
+		t.Error("alice allowed after running out of user tokens")
+	}
+	// Same address, other user: one IP token is left
+	bob := Login{IP: "10.0.0.1", Name: "bob"}
+	if d, _ := bob.Check(ctx); d != 0 {
+		t.Errorf("bob refused with an IP token left, retry after %v", d)
+	}
+	bob.Fail(ctx)
+	if d, _ := bob.Check(ctx); d == 0 {
+		t.Error("bob allowed after the address ran out of tokens")
+	}
+	// Other address, same user
+	if d, _ := (Login{IP: "10.0.0.2", Name: "alice"}).Check(ctx); d == 0 {
+		t.Error("alice allowed from another address after running out of user tokens")
+	}
+}
@@ -3,6 +3,9 @@
 package chunk
 
 import (
+	"cloud-storage/biz/mw"
+	"cloud-storage/biz/service"
+
 	"github.com/cloudwego/hertz/pkg/app"
 )
 
@@ -27,16 +30,13 @@ func _chunkMw() []app.HandlerFunc {
 }
 
 func _fileuploadchunkMw() []app.HandlerFunc {
-	// your code...
-	return nil
+	return []app.HandlerFunc{mw.Auth(service.ScopeFilesWrite)}
 }
 
 func _fileuploadchunkcompleteMw() []app.HandlerFunc {
-	// your code...
-	return nil
+	return []app.HandlerFunc{mw.Auth(service.ScopeFilesWrite)}
 }
 
 func _fileuploadprepareMw() []app.HandlerFunc {
-	// your code...
-	return nil
+	return []app.HandlerFunc{mw.Auth(service.ScopeFilesWrite)}
 }
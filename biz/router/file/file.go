@@ -28,6 +28,11 @@ func Register(r *server.Hertz) {
 			_file0.DELETE("/delete", append(_userfiledeleteMw(), file.UserFileDelete)...)
 			_file0.POST("/list", append(_userfilelistMw(), file.UserFileList)...)
 			_file0.PUT("/move", append(_userfilemoveMw(), file.UserFileMove)...)
+			_file0.POST("/search", append(_userfilesearchMw(), file.UserFileSearch)...)
+			{
+				_content := _file0.Group("/content", _contentMw()...)
+				_content.POST("/search", append(_userfilecontentsearchMw(), file.UserFileContentSearch)...)
+			}
 			{
 				_name := _file0.Group("/name", _nameMw()...)
 				_name.POST("/update", append(_userfilenameupdateMw(), file.UserFileNameUpdate)...)
@@ -3,6 +3,9 @@
 package file
 
 import (
+	"cloud-storage/biz/mw"
+	"cloud-storage/biz/service"
+
 	"github.com/cloudwego/hertz/pkg/app"
 )
 
@@ -17,7 +20,7 @@ func _fileMw() []app.HandlerFunc {
 }
 
 func _fileuploadMw() []app.HandlerFunc {
-	return nil
+	return []app.HandlerFunc{mw.Auth(service.ScopeFilesWrite)}
 }
 
 func _userMw() []app.HandlerFunc {
@@ -31,18 +34,15 @@ func _file0Mw() []app.HandlerFunc {
 }
 
 func _userfiledeleteMw() []app.HandlerFunc {
-	// your code...
-	return nil
+	return []app.HandlerFunc{mw.Auth(service.ScopeFilesWrite)}
 }
 
 func _userfilelistMw() []app.HandlerFunc {
-	// your code...
-	return nil
+	return []app.HandlerFunc{mw.Auth(service.ScopeFilesRead)}
 }
 
 func _userfilemoveMw() []app.HandlerFunc {
-	// your code...
-	return nil
+	return []app.HandlerFunc{mw.Auth(service.ScopeFilesWrite)}
 }
 
 func _nameMw() []app.HandlerFunc {
@@ -51,8 +51,7 @@ func _nameMw() []app.HandlerFunc {
 }
 
 func _userfilenameupdateMw() []app.HandlerFunc {
-	// your code...
-	return nil
+	return []app.HandlerFunc{mw.Auth(service.ScopeFilesWrite)}
 }
 
 func _folderMw() []app.HandlerFunc {
@@ -61,13 +60,11 @@ func _folderMw() []app.HandlerFunc {
 }
 
 func _userfoldercreateMw() []app.HandlerFunc {
-	// your code...
-	return nil
+	return []app.HandlerFunc{mw.Auth(service.ScopeFilesWrite)}
 }
 
 func _userfolderlistMw() []app.HandlerFunc {
-	// your code...
-	return nil
+	return []app.HandlerFunc{mw.Auth(service.ScopeFilesRead)}
 }
 
 func _repositoryMw() []app.HandlerFunc {
@@ -76,6 +73,18 @@ func _repositoryMw() []app.HandlerFunc {
 }
 
 func _userrepositorysaveMw() []app.HandlerFunc {
+	return []app.HandlerFunc{mw.Auth(service.ScopeFilesWrite)}
+}
+
+func _userfilesearchMw() []app.HandlerFunc {
+	return []app.HandlerFunc{mw.Auth(service.ScopeFilesRead)}
+}
+
+func _contentMw() []app.HandlerFunc {
 	// your code...
 	return nil
 }
+
+func _userfilecontentsearchMw() []app.HandlerFunc {
+	return []app.HandlerFunc{mw.Auth(service.ScopeFilesRead)}
+}
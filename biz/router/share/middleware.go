@@ -3,6 +3,11 @@
 package share
 
 import (
+	"cloud-storage/biz/model/share"
+	"cloud-storage/biz/mw"
+	"cloud-storage/biz/ratelimit"
+	"cloud-storage/biz/service"
+
 	"github.com/cloudwego/hertz/pkg/app"
 )
 
@@ -22,16 +27,22 @@ func _basicMw() []app.HandlerFunc {
 }
 
 func _sharebasiccreateMw() []app.HandlerFunc {
-	// your code...
-	return nil
+	return []app.HandlerFunc{mw.Auth(service.ScopeSharesManage)}
 }
 
 func _sharebasicdetailMw() []app.HandlerFunc {
-	// your code...
-	return nil
+	return []app.HandlerFunc{
+		mw.RateLimit(ratelimit.ShareIP, mw.ClientIP),
+		mw.RateLimit(ratelimit.Share, mw.Field(func(req *share.ShareBasicDetailRequest) string {
+			return req.Identity
+		})),
+	}
 }
 
 func _sharebasicsaveMw() []app.HandlerFunc {
-	// your code...
-	return nil
+	return []app.HandlerFunc{mw.Auth(service.ScopeFilesWrite)}
+}
+
+func _sharebasicrevokeMw() []app.HandlerFunc {
+	return []app.HandlerFunc{mw.Auth(service.ScopeSharesManage)}
 }
@@ -23,6 +23,7 @@ func Register(r *server.Hertz) {
 			_basic := _share.Group("/basic", _basicMw()...)
 			_basic.POST("/create", append(_sharebasiccreateMw(), share.ShareBasicCreate)...)
 			_basic.GET("/detail", append(_sharebasicdetailMw(), share.ShareBasicDetail)...)
+			_basic.POST("/revoke", append(_sharebasicrevokeMw(), share.ShareBasicRevoke)...)
 			_basic.POST("/save", append(_sharebasicsaveMw(), share.ShareBasicSave)...)
 		}
 	}
@@ -3,6 +3,11 @@
 package user
 
 import (
+	"cloud-storage/biz/model/user"
+	"cloud-storage/biz/mw"
+	"cloud-storage/biz/ratelimit"
+	"strings"
+
 	"github.com/cloudwego/hertz/pkg/app"
 )
 
@@ -27,8 +32,12 @@ func _sendMw() []app.HandlerFunc {
 }
 
 func _mailcodesendregisterMw() []app.HandlerFunc {
-	// your code...
-	return nil
+	return []app.HandlerFunc{
+		mw.RateLimit(ratelimit.MailCodeIP, mw.ClientIP),
+		mw.RateLimit(ratelimit.MailCodeEmail, mw.Field(func(req *user.MailCodeSendRequest) string {
+			return strings.ToLower(strings.TrimSpace(req.Email))
+		})),
+	}
 }
 
 func _refreshMw() []app.HandlerFunc {
@@ -52,11 +61,161 @@ func _userdetailMw() []app.HandlerFunc {
 }
 
 func _userloginMw() []app.HandlerFunc {
+	return []app.HandlerFunc{
+		mw.RateLimit(ratelimit.LoginIP, mw.ClientIP),
+		mw.RateLimit(ratelimit.LoginUser, mw.Field(func(req *user.LoginRequest) string {
+			return strings.ToLower(req.Name)
+		})),
+	}
+}
+
+func _userregisterMw() []app.HandlerFunc {
 	// your code...
 	return nil
 }
 
-func _userregisterMw() []app.HandlerFunc {
+func _accessMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _keyMw() []app.HandlerFunc {
+	return []app.HandlerFunc{mw.Auth("")}
+}
+
+func _useraccesskeycreateMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _useraccesskeydeleteMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _useraccesskeylistMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _sshMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _key0Mw() []app.HandlerFunc {
+	return []app.HandlerFunc{mw.Auth("")}
+}
+
+func _usersshkeycreateMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _usersshkeydeleteMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _usersshkeylistMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _loginMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _userlogintwofactorMw() []app.HandlerFunc {
+	return []app.HandlerFunc{mw.RateLimit(ratelimit.LoginIP, mw.ClientIP)}
+}
+
+func _2faMw() []app.HandlerFunc {
+	return []app.HandlerFunc{mw.Auth("")}
+}
+
+func _usertwofactordisableMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _usertwofactorenrollMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _usertwofactorverifyMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _recoveryMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _userrecoverycoderegenerateMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _tokenMw() []app.HandlerFunc {
+	return []app.HandlerFunc{mw.Auth("")}
+}
+
+func _usertokencreateMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _usertokenlistMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _usertokenrevokeMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _passwordMw() []app.HandlerFunc {
+	return []app.HandlerFunc{mw.RateLimit(ratelimit.PasswordResetIP, mw.ClientIP)}
+}
+
+func _userpasswordforgotMw() []app.HandlerFunc {
+	return []app.HandlerFunc{
+		mw.RateLimit(ratelimit.PasswordResetEmail, mw.Field(func(req *user.UserPasswordForgotRequest) string {
+			return strings.ToLower(strings.TrimSpace(req.Email))
+		})),
+	}
+}
+
+func _userpasswordresetMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _sessionMw() []app.HandlerFunc {
+	return []app.HandlerFunc{mw.Auth("")}
+}
+
+func _usersessionlistMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _usersessionrevokeMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _revokeMw() []app.HandlerFunc {
+	// your code...
+	return nil
+}
+
+func _usersessionrevokeothersMw() []app.HandlerFunc {
 	// your code...
 	return nil
 }
@@ -36,5 +36,57 @@ func Register(r *server.Hertz) {
 		_user.POST("/detail", append(_userdetailMw(), user.UserDetail)...)
 		_user.POST("/login", append(_userloginMw(), user.UserLogin)...)
 		_user.POST("/register", append(_userregisterMw(), user.UserRegister)...)
+		{
+			_2fa := _user.Group("/2fa", _2faMw()...)
+			_2fa.POST("/disable", append(_usertwofactordisableMw(), user.UserTwoFactorDisable)...)
+			_2fa.POST("/enroll", append(_usertwofactorenrollMw(), user.UserTwoFactorEnroll)...)
+			_2fa.POST("/verify", append(_usertwofactorverifyMw(), user.UserTwoFactorVerify)...)
+			{
+				_recovery := _2fa.Group("/recovery", _recoveryMw()...)
+				_recovery.POST("/codes", append(_userrecoverycoderegenerateMw(), user.UserRecoveryCodeRegenerate)...)
+			}
+		}
+		{
+			_access := _user.Group("/access", _accessMw()...)
+			{
+				_key := _access.Group("/key", _keyMw()...)
+				_key.POST("/create", append(_useraccesskeycreateMw(), user.UserAccessKeyCreate)...)
+				_key.DELETE("/delete", append(_useraccesskeydeleteMw(), user.UserAccessKeyDelete)...)
+				_key.POST("/list", append(_useraccesskeylistMw(), user.UserAccessKeyList)...)
+			}
+		}
+		{
+			_login := _user.Group("/login", _loginMw()...)
+			_login.POST("/2fa", append(_userlogintwofactorMw(), user.UserLoginTwoFactor)...)
+		}
+		{
+			_password := _user.Group("/password", _passwordMw()...)
+			_password.POST("/forgot", append(_userpasswordforgotMw(), user.UserPasswordForgot)...)
+			_password.POST("/reset", append(_userpasswordresetMw(), user.UserPasswordReset)...)
+		}
+		{
+			_session := _user.Group("/session", _sessionMw()...)
+			_session.POST("/list", append(_usersessionlistMw(), user.UserSessionList)...)
+			_session.POST("/revoke", append(_usersessionrevokeMw(), user.UserSessionRevoke)...)
+			{
+				_revoke := _session.Group("/revoke", _revokeMw()...)
+				_revoke.POST("/others", append(_usersessionrevokeothersMw(), user.UserSessionRevokeOthers)...)
+			}
+		}
+		{
+			_ssh := _user.Group("/ssh", _sshMw()...)
+			{
+				_key0 := _ssh.Group("/key", _key0Mw()...)
+				_key0.POST("/create", append(_usersshkeycreateMw(), user.UserSshKeyCreate)...)
+				_key0.DELETE("/delete", append(_usersshkeydeleteMw(), user.UserSshKeyDelete)...)
+				_key0.POST("/list", append(_usersshkeylistMw(), user.UserSshKeyList)...)
+			}
+		}
+		{
+			_token := _user.Group("/token", _tokenMw()...)
+			_token.POST("/create", append(_usertokencreateMw(), user.UserTokenCreate)...)
+			_token.POST("/list", append(_usertokenlistMw(), user.UserTokenList)...)
+			_token.POST("/revoke", append(_usertokenrevokeMw(), user.UserTokenRevoke)...)
+		}
 	}
 }
@@ -0,0 +1,26 @@
+package rpc
+
+import (
+	"cloud-storage/biz/model/chunk"
+	"context"
+)
+
+type chunkServer struct {
+	chunk.UnimplementedChunkServer
+	s *Server
+}
+
+func (x *chunkServer) FileUploadPrepare(ctx context.Context, req *chunk.FileUploadPrepareRequest) (*chunk.FileUploadPrepareReply, error) {
+	reply := &chunk.FileUploadPrepareReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *chunkServer) FileUploadChunk(ctx context.Context, req *chunk.FileUploadChunkRequest) (*chunk.FileUploadChunkReply, error) {
+	reply := &chunk.FileUploadChunkReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *chunkServer) FileUploadChunkComplete(ctx context.Context, req *chunk.FileUploadChunkCompleteRequest) (*chunk.FileUploadChunkCompleteReply, error) {
+	reply := &chunk.FileUploadChunkCompleteReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
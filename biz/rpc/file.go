@@ -0,0 +1,156 @@
+package rpc
+
+import (
+	"cloud-storage/biz/model/file"
+	"cloud-storage/biz/service"
+	"context"
+	"errors"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// downloadChunkSize is the content size of each download message.
+const downloadChunkSize = 64 << 10
+
+type fileServer struct {
+	file.UnimplementedFileServer
+	s *Server
+}
+
+func (x *fileServer) FileUpload(ctx context.Context, req *file.FileUploadRequest) (*file.FileUploadReply, error) {
+	reply := &file.FileUploadReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *fileServer) UserRepositorySave(ctx context.Context, req *file.UserRepositorySaveRequest) (*file.UserRepositorySaveReply, error) {
+	reply := &file.UserRepositorySaveReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *fileServer) UserFileList(ctx context.Context, req *file.UserFileListRequest) (*file.UserFileListReply, error) {
+	reply := &file.UserFileListReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *fileServer) UserFolderList(ctx context.Context, req *file.UserFolderListRequest) (*file.UserFolderListReply, error) {
+	reply := &file.UserFolderListReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *fileServer) UserFileNameUpdate(ctx context.Context, req *file.UserFileNameUpdateRequest) (*file.UserFileNameUpdateReply, error) {
+	reply := &file.UserFileNameUpdateReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *fileServer) UserFolderCreate(ctx context.Context, req *file.UserFolderCreateRequest) (*file.UserFolderCreateReply, error) {
+	reply := &file.UserFolderCreateReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *fileServer) UserFileDelete(ctx context.Context, req *file.UserFileDeleteRequest) (*file.UserFileDeleteReply, error) {
+	reply := &file.UserFileDeleteReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *fileServer) UserFileMove(ctx context.Context, req *file.UserFileMoveRequest) (*file.UserFileMoveReply, error) {
+	reply := &file.UserFileMoveReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *fileServer) UserFileSearch(ctx context.Context, req *file.UserFileSearchRequest) (*file.UserFileSearchReply, error) {
+	reply := &file.UserFileSearchReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *fileServer) UserFileContentSearch(ctx context.Context, req *file.UserFileContentSearchRequest) (*file.UserFileContentSearchReply, error) {
+	reply := &file.UserFileContentSearchReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+// FileUploadStream stores the content sent over the stream in the repository
+// pool. It stands in for FileUpload, whose HTTP handler takes a multipart
+// form, and does not buffer the content in memory. Content already in the
+// pool is reused.
+func (x *fileServer) FileUploadStream(stream grpc.ClientStreamingServer[file.FileUploadStreamRequest, file.FileUploadReply]) error {
+	if _, err := userIdentity(stream.Context(), service.ScopeFilesWrite); err != nil {
+		return err
+	}
+	first, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return status.Error(codes.InvalidArgument, "empty upload")
+	}
+	if err != nil {
+		return err
+	}
+	if first.Name == "" {
+		return status.Error(codes.InvalidArgument, "name is required in the first message")
+	}
+
+	rp, err := service.Uploads.Upload(stream.Context(), first.Name, &uploadReader{stream: stream, buf: first.Content})
+	if err != nil {
+		return serviceError(stream.Context(), err)
+	}
+	return stream.SendAndClose(&file.FileUploadReply{
+		Identity: rp.Identity,
+		Ext:      rp.Ext,
+		Name:     rp.Name,
+	})
+}
+
+// UserFileDownloadStream streams the content of one of the caller's files.
+func (x *fileServer) UserFileDownloadStream(req *file.UserFileDownloadStreamRequest, stream grpc.ServerStreamingServer[file.UserFileDownloadStreamReply]) error {
+	caller, err := userIdentity(stream.Context(), service.ScopeFilesRead)
+	if err != nil {
+		return err
+	}
+	download, err := service.Files.Open(stream.Context(), caller, req.Identity)
+	if err != nil {
+		return serviceError(stream.Context(), err)
+	}
+	defer download.Content.Close()
+	reply := &file.UserFileDownloadStreamReply{Name: download.Name, Size: download.Size}
+	buf := make([]byte, downloadChunkSize)
+	for {
+		n, err := download.Content.Read(buf)
+		if n > 0 {
+			reply.Content = buf[:n]
+			if err := stream.Send(reply); err != nil {
+				return err
+			}
+			reply = &file.UserFileDownloadStreamReply{}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to read file: %v", err)
+		}
+	}
+	// Empty files still get the message carrying name and size
+	if reply.Name != "" {
+		return stream.Send(reply)
+	}
+	return nil
+}
+
+// uploadReader reads the content of an upload stream, message by message.
+type uploadReader struct {
+	stream grpc.ClientStreamingServer[file.FileUploadStreamRequest, file.FileUploadReply]
+	buf    []byte
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		msg, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = msg.Content
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
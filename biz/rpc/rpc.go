@@ -0,0 +1,302 @@
+package rpc
+
+import (
+	"cloud-storage/biz/errno"
+	"cloud-storage/biz/logging"
+	"cloud-storage/biz/model/api"
+	"cloud-storage/biz/model/chunk"
+	"cloud-storage/biz/model/file"
+	"cloud-storage/biz/model/share"
+	"cloud-storage/biz/model/user"
+	"cloud-storage/biz/mw"
+	"cloud-storage/biz/service"
+	"context"
+	"encoding/json"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/network"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/cloudwego/hertz/pkg/route"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var log = logging.Logger("rpc")
+
+const authorizationHeader = "authorization"
+
+// errorDomain is the domain of the ErrorInfo detail of status errors.
+const errorDomain = "cloud-storage"
+
+// httpRoute is the HTTP endpoint a unary method is bound to by its api option.
+type httpRoute struct {
+	method string
+	path   string
+}
+
+// Server serves the file, chunk, share and user services over gRPC. Unary
+// calls run the HTTP handler of the same method in-process, so both
+// transports share their logic, authentication included: the JWT or
+// personal access token is read from the "authorization" metadata exactly
+// as from the HTTP header.
+type Server struct {
+	engine *route.Engine
+	routes map[string]httpRoute
+	gs     *grpc.Server
+}
+
+// New returns a server dispatching unary calls to the routes of engine.
+func New(engine *route.Engine) *Server {
+	s := &Server{engine: engine, routes: map[string]httpRoute{}}
+	for _, fd := range []protoreflect.FileDescriptor{
+		chunk.File_chunk_proto,
+		file.File_file_proto,
+		share.File_share_proto,
+		user.File_user_proto,
+	} {
+		services := fd.Services()
+		for i := 0; i < services.Len(); i++ {
+			s.addRoutes(services.Get(i))
+		}
+	}
+	s.gs = grpc.NewServer()
+	chunk.RegisterChunkServer(s.gs, &chunkServer{s: s})
+	file.RegisterFileServer(s.gs, &fileServer{s: s})
+	share.RegisterShareServer(s.gs, &shareServer{s: s})
+	user.RegisterUserServer(s.gs, &userServer{s: s})
+	return s
+}
+
+func (s *Server) addRoutes(sd protoreflect.ServiceDescriptor) {
+	methods := sd.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		md := methods.Get(i)
+		opts := md.Options()
+		for method, xt := range map[string]protoreflect.ExtensionType{
+			consts.MethodGet:    api.E_Get,
+			consts.MethodPost:   api.E_Post,
+			consts.MethodPut:    api.E_Put,
+			consts.MethodDelete: api.E_Delete,
+			consts.MethodPatch:  api.E_Patch,
+		} {
+			if path, _ := proto.GetExtension(opts, xt).(string); path != "" {
+				s.routes["/"+string(sd.FullName())+"/"+string(md.Name())] = httpRoute{method: method, path: path}
+			}
+		}
+	}
+}
+
+// ListenAndServe accepts gRPC connections on addr until Shutdown, when it
+// returns nil.
+func (s *Server) ListenAndServe(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	log.Info("gRPC server listening", "addr", addr)
+	return s.gs.Serve(l)
+}
+
+// Shutdown stops accepting calls and waits for those in progress, such as
+// streaming uploads, until ctx is done, when it cancels the rest.
+func (s *Server) Shutdown(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		s.gs.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.gs.Stop()
+	}
+}
+
+// invoke runs the HTTP handler bound to the method being called, with req
+// as a protobuf body, and decodes its JSON response into reply.
+func (s *Server) invoke(ctx context.Context, req, reply proto.Message) error {
+	fullMethod, _ := grpc.Method(ctx)
+	r, ok := s.routes[fullMethod]
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "method %s has no HTTP route", fullMethod)
+	}
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to marshal request: %v", err)
+	}
+
+	c := s.engine.NewContext()
+	c.Request.SetMethod(r.method)
+	c.Request.SetRequestURI(r.path)
+	c.Request.SetHost("grpc")
+	c.Request.Header.SetContentTypeBytes([]byte("application/x-protobuf"))
+	c.Request.SetBody(body)
+	c.Request.Header.SetContentLength(len(body))
+	// Audit and rate limit the caller as the client of the request. No
+	// forwarding headers come from the metadata and no peer is trusted as a
+	// proxy, so ClientIP is the address of the peer.
+	c.SetClientIPFunc(mw.ClientIPFunc(nil))
+	if p, ok := peer.FromContext(ctx); ok {
+		c.SetConn(peerConn{addr: p.Addr})
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(authorizationHeader); len(v) > 0 {
+			c.Request.Header.Set(consts.HeaderAuthorization, v[0])
+		}
+		if v := md.Get("user-agent"); len(v) > 0 {
+			c.Request.Header.SetUserAgentBytes([]byte(v[0]))
+		}
+		// Continue the trace of the caller, like over HTTP
+		for _, field := range otel.GetTextMapPropagator().Fields() {
+			if v := md.Get(field); len(v) > 0 {
+				c.Request.Header.Set(field, v[0])
+			}
+		}
+	}
+	s.engine.ServeHTTP(ctx, c)
+
+	code := c.Response.StatusCode()
+	if code != consts.StatusOK {
+		var body errno.Body
+		if err := json.Unmarshal(c.Response.Body(), &body); err == nil && body.Code != "" {
+			retryAfter, _ := strconv.Atoi(string(c.Response.Header.Peek("Retry-After")))
+			return statusError(&body, time.Duration(retryAfter)*time.Second)
+		}
+		return status.Error(httpCode(code), strings.TrimSpace(string(c.Response.Body())))
+	}
+	if err := json.Unmarshal(c.Response.Body(), reply); err != nil {
+		return status.Errorf(codes.Internal, "failed to decode response: %v", err)
+	}
+	return nil
+}
+
+// peerConn stands in for the connection of the request a unary call is
+// served as, which has none of its own, so that RemoteAddr is the address
+// of the gRPC peer. Handlers never read or write it.
+type peerConn struct {
+	network.Conn
+	addr net.Addr
+}
+
+func (c peerConn) RemoteAddr() net.Addr {
+	return c.addr
+}
+
+// userIdentity authenticates a streaming call, which has no HTTP
+// counterpart, by the JWT or the personal access token in its metadata,
+// which must grant scope.
+func userIdentity(ctx context.Context, scope string) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	v := md.Get(authorizationHeader)
+	if len(v) == 0 {
+		return "", status.Error(codes.Unauthenticated, "missing authorization metadata")
+	}
+	if token := strings.TrimPrefix(v[0], "Bearer "); service.IsAPIToken(token) {
+		var ip string
+		if p, ok := peer.FromContext(ctx); ok {
+			ip, _, _ = net.SplitHostPort(p.Addr.String())
+		}
+		auth, err := service.Users.AuthenticateAPIToken(ctx, token, ip)
+		if err != nil {
+			return "", serviceError(ctx, err)
+		}
+		if !auth.Allows(scope) {
+			return "", status.Errorf(codes.PermissionDenied, "access token lacks the %s scope", scope)
+		}
+		return auth.UserIdentity, nil
+	}
+	login, err := mw.TokenLogin(v[0])
+	if err != nil {
+		return "", status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+	if err := service.Users.CheckLogin(ctx, login.UserIdentity, login.Session, login.IssuedAt); err != nil {
+		return "", serviceError(ctx, err)
+	}
+	return login.UserIdentity, nil
+}
+
+// serviceError converts an error returned by a service to a status error.
+// Like over HTTP, the cause of the error is logged and not sent.
+func serviceError(ctx context.Context, err error) error {
+	e := errno.From(err)
+	if e.Code == errno.Internal {
+		method, _ := grpc.Method(ctx)
+		log.ErrorContext(ctx, "call failed", "method", method, "error", err)
+	}
+	return statusError(&errno.Body{Code: e.Code, Message: e.Message}, e.RetryAfter)
+}
+
+// statusError converts an error response to a status error, carrying the
+// errno code as the reason of its ErrorInfo detail and, if set, how long to
+// wait before retrying as a RetryInfo detail.
+func statusError(body *errno.Body, retryAfter time.Duration) error {
+	st := status.New(grpcCode(body.Code), body.Message)
+	info := &errdetails.ErrorInfo{Reason: string(body.Code), Domain: errorDomain}
+	if body.RequestID != "" {
+		info.Metadata = map[string]string{"request_id": body.RequestID}
+	}
+	if withDetails, err := st.WithDetails(info); err == nil {
+		st = withDetails
+	}
+	if retryAfter > 0 {
+		if withDetails, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+			st = withDetails
+		}
+	}
+	return st.Err()
+}
+
+func grpcCode(code errno.Code) codes.Code {
+	switch code {
+	case errno.InvalidArgument, errno.InvalidResetToken:
+		return codes.InvalidArgument
+	case errno.Unauthenticated, errno.InvalidCredentials, errno.InvalidTwoFactor:
+		return codes.Unauthenticated
+	case errno.PermissionDenied, errno.AccountDisabled, errno.TwoFactorRequired:
+		return codes.PermissionDenied
+	case errno.FileNotFound, errno.FolderNotFound, errno.ShareNotFound, errno.JobNotFound, errno.UserNotFound, errno.TokenNotFound, errno.SessionNotFound:
+		return codes.NotFound
+	case errno.NameConflict, errno.SSHKeyConflict:
+		return codes.AlreadyExists
+	case errno.ShareExpired, errno.JobNotFailed, errno.TwoFactorEnabled, errno.TwoFactorDisabled:
+		return codes.FailedPrecondition
+	case errno.QuotaExceeded, errno.RateLimited, errno.AccountLocked:
+		return codes.ResourceExhausted
+	}
+	return codes.Internal
+}
+
+func httpCode(code int) codes.Code {
+	switch code {
+	case consts.StatusBadRequest:
+		return codes.InvalidArgument
+	case consts.StatusUnauthorized:
+		return codes.Unauthenticated
+	case consts.StatusForbidden:
+		return codes.PermissionDenied
+	case consts.StatusNotFound:
+		return codes.NotFound
+	case consts.StatusConflict:
+		return codes.AlreadyExists
+	case consts.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case consts.StatusNotImplemented:
+		return codes.Unimplemented
+	case consts.StatusServiceUnavailable:
+		return codes.Unavailable
+	case consts.StatusInternalServerError:
+		return codes.Internal
+	}
+	return codes.Unknown
+}
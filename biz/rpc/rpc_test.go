@@ -0,0 +1,48 @@
+package rpc
+
+import (
+	"cloud-storage/biz/model/user"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/cloudwego/hertz/pkg/route"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// TestInvokeClientIP checks that unary calls reach their HTTP handler with
+// the address of the gRPC peer as the client, whatever forwarding headers
+// the caller sends as metadata.
+func TestInvokeClientIP(t *testing.T) {
+	engine := route.NewEngine(config.NewOptions(nil))
+	engine.POST("/user/login", func(ctx context.Context, c *app.RequestContext) {
+		c.JSON(consts.StatusOK, map[string]string{"token": c.ClientIP()})
+	})
+	s := New(engine)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go s.gs.Serve(l)
+	defer s.gs.Stop()
+
+	conn, err := grpc.NewClient(l.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		"x-real-ip", "203.0.113.1", "x-forwarded-for", "203.0.113.2")
+	reply, err := user.NewUserClient(conn).UserLogin(ctx, &user.LoginRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Token != "127.0.0.1" {
+		t.Errorf("ClientIP = %q, want 127.0.0.1", reply.Token)
+	}
+}
@@ -0,0 +1,31 @@
+package rpc
+
+import (
+	"cloud-storage/biz/model/share"
+	"context"
+)
+
+type shareServer struct {
+	share.UnimplementedShareServer
+	s *Server
+}
+
+func (x *shareServer) ShareBasicDetail(ctx context.Context, req *share.ShareBasicDetailRequest) (*share.ShareBasicDetailReply, error) {
+	reply := &share.ShareBasicDetailReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *shareServer) ShareBasicCreate(ctx context.Context, req *share.ShareBasicCreateRequest) (*share.ShareBasicCreateReply, error) {
+	reply := &share.ShareBasicCreateReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *shareServer) ShareBasicSave(ctx context.Context, req *share.ShareBasicSaveRequest) (*share.ShareBasicSaveReply, error) {
+	reply := &share.ShareBasicSaveReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *shareServer) ShareBasicRevoke(ctx context.Context, req *share.ShareBasicRevokeRequest) (*share.ShareBasicRevokeReply, error) {
+	reply := &share.ShareBasicRevokeReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
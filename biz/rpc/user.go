@@ -0,0 +1,131 @@
+package rpc
+
+import (
+	"cloud-storage/biz/model/user"
+	"context"
+)
+
+type userServer struct {
+	user.UnimplementedUserServer
+	s *Server
+}
+
+func (x *userServer) UserLogin(ctx context.Context, req *user.LoginRequest) (*user.LoginReply, error) {
+	reply := &user.LoginReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) UserLoginTwoFactor(ctx context.Context, req *user.LoginTwoFactorRequest) (*user.LoginReply, error) {
+	reply := &user.LoginReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) UserDetail(ctx context.Context, req *user.UserDetailRequest) (*user.UserDetailReply, error) {
+	reply := &user.UserDetailReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) MailCodeSendRegister(ctx context.Context, req *user.MailCodeSendRequest) (*user.MailCodeSendReply, error) {
+	reply := &user.MailCodeSendReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) UserRegister(ctx context.Context, req *user.UserRegisterRequest) (*user.UserRegisterReply, error) {
+	reply := &user.UserRegisterReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) UserPasswordForgot(ctx context.Context, req *user.UserPasswordForgotRequest) (*user.UserPasswordForgotReply, error) {
+	reply := &user.UserPasswordForgotReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) UserPasswordReset(ctx context.Context, req *user.UserPasswordResetRequest) (*user.UserPasswordResetReply, error) {
+	reply := &user.UserPasswordResetReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) RefreshAuthorization(ctx context.Context, req *user.RefreshAuthorizationRequest) (*user.RefreshAuthorizationReply, error) {
+	reply := &user.RefreshAuthorizationReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) UserAccessKeyCreate(ctx context.Context, req *user.UserAccessKeyCreateRequest) (*user.UserAccessKeyCreateReply, error) {
+	reply := &user.UserAccessKeyCreateReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) UserAccessKeyList(ctx context.Context, req *user.UserAccessKeyListRequest) (*user.UserAccessKeyListReply, error) {
+	reply := &user.UserAccessKeyListReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) UserAccessKeyDelete(ctx context.Context, req *user.UserAccessKeyDeleteRequest) (*user.UserAccessKeyDeleteReply, error) {
+	reply := &user.UserAccessKeyDeleteReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) UserTokenCreate(ctx context.Context, req *user.UserTokenCreateRequest) (*user.UserTokenCreateReply, error) {
+	reply := &user.UserTokenCreateReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) UserTokenList(ctx context.Context, req *user.UserTokenListRequest) (*user.UserTokenListReply, error) {
+	reply := &user.UserTokenListReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) UserTokenRevoke(ctx context.Context, req *user.UserTokenRevokeRequest) (*user.UserTokenRevokeReply, error) {
+	reply := &user.UserTokenRevokeReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) UserSessionList(ctx context.Context, req *user.UserSessionListRequest) (*user.UserSessionListReply, error) {
+	reply := &user.UserSessionListReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) UserSessionRevoke(ctx context.Context, req *user.UserSessionRevokeRequest) (*user.UserSessionRevokeReply, error) {
+	reply := &user.UserSessionRevokeReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) UserSessionRevokeOthers(ctx context.Context, req *user.UserSessionRevokeOthersRequest) (*user.UserSessionRevokeOthersReply, error) {
+	reply := &user.UserSessionRevokeOthersReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) UserSshKeyCreate(ctx context.Context, req *user.UserSshKeyCreateRequest) (*user.UserSshKeyCreateReply, error) {
+	reply := &user.UserSshKeyCreateReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) UserSshKeyList(ctx context.Context, req *user.UserSshKeyListRequest) (*user.UserSshKeyListReply, error) {
+	reply := &user.UserSshKeyListReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) UserSshKeyDelete(ctx context.Context, req *user.UserSshKeyDeleteRequest) (*user.UserSshKeyDeleteReply, error) {
+	reply := &user.UserSshKeyDeleteReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) UserTwoFactorEnroll(ctx context.Context, req *user.UserTwoFactorEnrollRequest) (*user.UserTwoFactorEnrollReply, error) {
+	reply := &user.UserTwoFactorEnrollReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) UserTwoFactorVerify(ctx context.Context, req *user.UserTwoFactorVerifyRequest) (*user.UserTwoFactorVerifyReply, error) {
+	reply := &user.UserTwoFactorVerifyReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) UserTwoFactorDisable(ctx context.Context, req *user.UserTwoFactorDisableRequest) (*user.UserTwoFactorDisableReply, error) {
+	reply := &user.UserTwoFactorDisableReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
+
+func (x *userServer) UserRecoveryCodeRegenerate(ctx context.Context, req *user.UserRecoveryCodeRegenerateRequest) (*user.UserRecoveryCodeRegenerateReply, error) {
+	reply := &user.UserRecoveryCodeRegenerateReply{}
+	return reply, x.s.invoke(ctx, req, reply)
+}
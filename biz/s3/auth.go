@@ -0,0 +1,240 @@
+package s3
+
+import (
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/errno"
+	"cloud-storage/biz/service"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+const (
+	algorithm     = "AWS4-HMAC-SHA256"
+	amzDateFormat = "20060102T150405Z"
+	maxClockSkew  = 15 * time.Minute
+
+	unsignedPayload         = "UNSIGNED-PAYLOAD"
+	streamingPayload        = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+	streamingUnsignedFooter = "STREAMING-UNSIGNED-PAYLOAD-TRAILER"
+	emptySHA256             = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// signature holds what a verified request was signed with. Streaming uploads
+// need it to verify the signature of each chunk.
+type signature struct {
+	key         *entity.UserAccessKey
+	signingKey  []byte
+	amzDate     string
+	scope       string
+	seed        string
+	payloadHash string
+}
+
+// authenticate verifies the AWS Signature Version 4 of the request, given
+// either in the Authorization header or as presigned query parameters.
+func authenticate(ctx context.Context, c *app.RequestContext) (*signature, *apiError) {
+	if len(c.Query("X-Amz-Algorithm")) > 0 {
+		return authenticatePresigned(ctx, c)
+	}
+
+	auth := string(c.GetHeader("Authorization"))
+	if auth == "" {
+		return nil, errAccessDenied
+	}
+	rest, ok := strings.CutPrefix(auth, algorithm+" ")
+	if !ok {
+		return nil, errSignatureVersion
+	}
+	params := map[string]string{}
+	for _, part := range strings.Split(rest, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+		params[k] = v
+	}
+
+	amzDate := string(c.GetHeader("X-Amz-Date"))
+	if amzDate == "" {
+		return nil, errAccessDenied
+	}
+	t, err := time.Parse(amzDateFormat, amzDate)
+	if err != nil {
+		return nil, errAccessDenied
+	}
+	if d := time.Since(t); d > maxClockSkew || d < -maxClockSkew {
+		return nil, errRequestTimeTooSkewed
+	}
+
+	payloadHash := string(c.GetHeader("X-Amz-Content-Sha256"))
+	if payloadHash == "" {
+		return nil, errInvalidContentSHA256
+	}
+	return verify(ctx, c, params["Credential"], params["SignedHeaders"], params["Signature"], amzDate, payloadHash, nil)
+}
+
+func authenticatePresigned(ctx context.Context, c *app.RequestContext) (*signature, *apiError) {
+	if c.Query("X-Amz-Algorithm") != algorithm {
+		return nil, errSignatureVersion
+	}
+	amzDate := c.Query("X-Amz-Date")
+	t, err := time.Parse(amzDateFormat, amzDate)
+	if err != nil {
+		return nil, errAccessDenied
+	}
+	expires, err := strconv.Atoi(c.Query("X-Amz-Expires"))
+	if err != nil || expires < 0 || expires > 7*24*60*60 {
+		return nil, errAccessDenied
+	}
+	if time.Now().After(t.Add(time.Duration(expires) * time.Second)) {
+		return nil, errExpiredPresignRequest
+	}
+	if time.Until(t) > maxClockSkew {
+		return nil, errRequestTimeTooSkewed
+	}
+	skip := map[string]bool{"X-Amz-Signature": true}
+	return verify(ctx, c, c.Query("X-Amz-Credential"), c.Query("X-Amz-SignedHeaders"), c.Query("X-Amz-Signature"),
+		amzDate, unsignedPayload, skip)
+}
+
+// verify recomputes the request signature with the secret of the access key
+// named in credential and compares it with the one sent by the client.
+func verify(ctx context.Context, c *app.RequestContext, credential, signedHeaders, sig, amzDate, payloadHash string, skipQuery map[string]bool) (*signature, *apiError) {
+	// <access key id>/<date>/<region>/<service>/aws4_request
+	parts := strings.Split(credential, "/")
+	if len(parts) != 5 || parts[4] != "aws4_request" || parts[1] != amzDate[:8] {
+		return nil, errAuthorizationHeaderMalformed
+	}
+	if signedHeaders == "" || sig == "" {
+		return nil, errAuthorizationHeaderMalformed
+	}
+
+	key, err := service.Users.AccessKey(ctx, parts[0])
+	switch errno.CodeOf(err) {
+	case errno.InvalidCredentials:
+		return nil, errInvalidAccessKeyID
+	case errno.AccountDisabled:
+		return nil, errAccessDenied
+	}
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	var headers []string
+	for _, name := range strings.Split(signedHeaders, ";") {
+		value := string(c.Request.Header.Peek(name))
+		if name == "host" {
+			value = string(c.Request.Host())
+		}
+		headers = append(headers, name+":"+value)
+	}
+	canonical := canonicalRequest(
+		string(c.Method()),
+		string(c.Request.URI().PathOriginal()),
+		canonicalQuery(string(c.Request.URI().QueryString()), skipQuery),
+		headers,
+		signedHeaders,
+		payloadHash,
+	)
+
+	scope := strings.Join(parts[1:], "/")
+	signingKey := deriveSigningKey(key.SecretAccessKey, parts[1:])
+	expected := sign(signingKey, amzDate, scope, canonical)
+	if !hmac.Equal([]byte(expected), []byte(sig)) {
+		return nil, errSignatureDoesNotMatch
+	}
+
+	return &signature{
+		key:         key,
+		signingKey:  signingKey,
+		amzDate:     amzDate,
+		scope:       scope,
+		seed:        sig,
+		payloadHash: payloadHash,
+	}, nil
+}
+
+// canonicalRequest returns the canonical form of a request, the one its
+// signature is computed over. Each header is given as "name:value", in the
+// order of signedHeaders.
+func canonicalRequest(method, path, query string, headers []string, signedHeaders, payloadHash string) string {
+	var b strings.Builder
+	for _, h := range headers {
+		name, value, _ := strings.Cut(h, ":")
+		b.WriteString(name + ":" + strings.Join(strings.Fields(value), " ") + "\n")
+	}
+	return strings.Join([]string{method, path, query, b.String(), signedHeaders, payloadHash}, "\n")
+}
+
+// deriveSigningKey returns the key a secret signs with in a scope, given as
+// its date, region, service and "aws4_request".
+func deriveSigningKey(secret string, scope []string) []byte {
+	key := []byte("AWS4" + secret)
+	for _, p := range scope {
+		key = hmacSHA256(key, p)
+	}
+	return key
+}
+
+// sign returns the signature of a canonical request made at amzDate.
+func sign(signingKey []byte, amzDate, scope, canonicalRequest string) string {
+	stringToSign := strings.Join([]string{algorithm, amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+	return hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+}
+
+// canonicalQuery returns the query string sorted by name and value, with
+// every component percent-encoded the way SigV4 expects.
+func canonicalQuery(rawQuery string, skip map[string]bool) string {
+	values, _ := url.ParseQuery(rawQuery)
+	var pairs []string
+	for k, vs := range values {
+		if skip[k] {
+			continue
+		}
+		for _, v := range vs {
+			pairs = append(pairs, uriEncode(k)+"="+uriEncode(v))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// chunkSignature returns the signature of one chunk of a streaming upload,
+// chained to the signature of the previous chunk.
+func (s *signature) chunkSignature(prev string, chunk []byte) string {
+	stringToSign := strings.Join([]string{
+		algorithm + "-PAYLOAD", s.amzDate, s.scope, prev, emptySHA256, sha256Hex(chunk),
+	}, "\n")
+	return hex.EncodeToString(hmacSHA256(s.signingKey, stringToSign))
+}
+
+func uriEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if 'A' <= ch && ch <= 'Z' || 'a' <= ch && ch <= 'z' || '0' <= ch && ch <= '9' ||
+			ch == '-' || ch == '_' || ch == '.' || ch == '~' {
+			b.WriteByte(ch)
+			continue
+		}
+		b.WriteString("%" + strings.ToUpper(hex.EncodeToString([]byte{ch})))
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
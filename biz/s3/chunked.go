@@ -0,0 +1,163 @@
+package s3
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const maxChunkSize = 16 << 20
+
+var (
+	errChunkFormat    = errors.New("malformed aws-chunked body")
+	errChunkSignature = errors.New("chunk signature does not match")
+	errContentSHA256  = errors.New("payload SHA-256 does not match x-amz-content-sha256")
+	errContentMD5     = errors.New("payload MD5 does not match Content-MD5")
+)
+
+// chunkedReader decodes an aws-chunked request body. When sig is set every
+// chunk must carry a valid chunk signature; otherwise the chunks are unsigned
+// and may be followed by trailing headers, which are ignored.
+type chunkedReader struct {
+	r    *bufio.Reader
+	sig  *signature
+	prev string
+	buf  []byte
+	done bool
+}
+
+func newChunkedReader(r io.Reader, sig *signature) *chunkedReader {
+	cr := &chunkedReader{r: bufio.NewReader(r), sig: sig}
+	if sig != nil {
+		cr.prev = sig.seed
+	}
+	return cr
+}
+
+func (cr *chunkedReader) Read(p []byte) (int, error) {
+	for len(cr.buf) == 0 {
+		if cr.done {
+			return 0, io.EOF
+		}
+		if err := cr.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, cr.buf)
+	cr.buf = cr.buf[n:]
+	return n, nil
+}
+
+// next reads the following chunk into buf.
+func (cr *chunkedReader) next() error {
+	line, err := cr.readLine()
+	if err != nil {
+		return err
+	}
+	// <hex size>[;chunk-signature=<signature>]
+	sizeHex, ext, _ := strings.Cut(line, ";")
+	size, err := strconv.ParseInt(sizeHex, 16, 64)
+	if err != nil || size < 0 || size > maxChunkSize {
+		return errChunkFormat
+	}
+	chunk := make([]byte, size)
+	if _, err := io.ReadFull(cr.r, chunk); err != nil {
+		return errChunkFormat
+	}
+
+	if cr.sig != nil {
+		got, ok := strings.CutPrefix(ext, "chunk-signature=")
+		if !ok || got != cr.sig.chunkSignature(cr.prev, chunk) {
+			return errChunkSignature
+		}
+		cr.prev = got
+	}
+
+	if size == 0 {
+		cr.done = true
+		// Drain the trailing headers, up to the empty line ending the body
+		for {
+			line, err := cr.readLine()
+			if err != nil || line == "" {
+				return nil
+			}
+		}
+	}
+	if crlf, err := cr.readLine(); err != nil || crlf != "" {
+		return errChunkFormat
+	}
+	cr.buf = chunk
+	return nil
+}
+
+func (cr *chunkedReader) readLine() (string, error) {
+	line, err := cr.r.ReadString('\n')
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return "", errChunkFormat
+		}
+		return "", err
+	}
+	return strings.TrimSuffix(line, "\r\n"), nil
+}
+
+// verifyingReader checks the digests announced by the client once the whole
+// body has been read, failing the final read on a mismatch so that nothing
+// gets stored.
+type verifyingReader struct {
+	r         io.Reader
+	sha256    hash.Hash
+	sha256Hex string
+	md5       hash.Hash
+	md5Sum    []byte
+}
+
+func (vr *verifyingReader) Read(p []byte) (int, error) {
+	n, err := vr.r.Read(p)
+	if vr.sha256 != nil {
+		vr.sha256.Write(p[:n])
+	}
+	if vr.md5 != nil {
+		vr.md5.Write(p[:n])
+	}
+	if errors.Is(err, io.EOF) {
+		if vr.sha256 != nil && hex.EncodeToString(vr.sha256.Sum(nil)) != vr.sha256Hex {
+			return n, errContentSHA256
+		}
+		if vr.md5 != nil && !bytes.Equal(vr.md5.Sum(nil), vr.md5Sum) {
+			return n, errContentMD5
+		}
+	}
+	return n, err
+}
+
+// body decodes the request payload r according to the payload hash it was
+// signed with, verifying it along the way.
+func body(r io.Reader, sig *signature, contentMD5 []byte) (io.Reader, *apiError) {
+	vr := &verifyingReader{r: r}
+	switch sig.payloadHash {
+	case unsignedPayload:
+	case streamingPayload:
+		vr.r = newChunkedReader(r, sig)
+	case streamingUnsignedFooter:
+		vr.r = newChunkedReader(r, nil)
+	case streamingPayload + "-TRAILER":
+		return nil, errNotImplemented
+	default:
+		if _, err := hex.DecodeString(sig.payloadHash); err != nil || len(sig.payloadHash) != sha256.Size*2 {
+			return nil, errInvalidContentSHA256
+		}
+		vr.sha256, vr.sha256Hex = sha256.New(), sig.payloadHash
+	}
+	if contentMD5 != nil {
+		vr.md5, vr.md5Sum = md5.New(), contentMD5
+	}
+	return vr, nil
+}
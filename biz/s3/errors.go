@@ -0,0 +1,111 @@
+package s3
+
+import (
+	"cloud-storage/biz/vfs"
+	"encoding/xml"
+	"errors"
+	"io/fs"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+)
+
+// apiError is an error reported to the client in the S3 XML error format.
+type apiError struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+func (e *apiError) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+var (
+	errAccessDenied                 = &apiError{consts.StatusForbidden, "AccessDenied", "Access Denied."}
+	errAuthorizationHeaderMalformed = &apiError{consts.StatusBadRequest, "AuthorizationHeaderMalformed", "The authorization header is malformed."}
+	errBadDigest                    = &apiError{consts.StatusBadRequest, "BadDigest", "The Content-MD5 you specified did not match what we received."}
+	errBucketAlreadyOwnedByYou      = &apiError{consts.StatusConflict, "BucketAlreadyOwnedByYou", "Your previous request to create the named bucket succeeded and you already own it."}
+	errBucketNotEmpty               = &apiError{consts.StatusConflict, "BucketNotEmpty", "The bucket you tried to delete is not empty."}
+	errContentSHA256Mismatch        = &apiError{consts.StatusBadRequest, "XAmzContentSHA256Mismatch", "The provided 'x-amz-content-sha256' header does not match what was computed."}
+	errEntityTooLarge               = &apiError{consts.StatusBadRequest, "EntityTooLarge", "Your proposed upload exceeds your storage quota."}
+	errEntityTooSmall               = &apiError{consts.StatusBadRequest, "EntityTooSmall", "Your proposed upload is smaller than the minimum allowed object size."}
+	errExpiredPresignRequest        = &apiError{consts.StatusForbidden, "AccessDenied", "Request has expired."}
+	errIncompleteBody               = &apiError{consts.StatusBadRequest, "IncompleteBody", "You did not provide the number of bytes specified by the Content-Length HTTP header."}
+	errInvalidAccessKeyID           = &apiError{consts.StatusForbidden, "InvalidAccessKeyId", "The access key ID you provided does not exist in our records."}
+	errInvalidArgument              = &apiError{consts.StatusBadRequest, "InvalidArgument", "Invalid argument."}
+	errInvalidBucketName            = &apiError{consts.StatusBadRequest, "InvalidBucketName", "The specified bucket is not valid."}
+	errInvalidContentSHA256         = &apiError{consts.StatusBadRequest, "InvalidArgument", "x-amz-content-sha256 must be UNSIGNED-PAYLOAD, STREAMING-AWS4-HMAC-SHA256-PAYLOAD, STREAMING-UNSIGNED-PAYLOAD-TRAILER or a valid sha256 value."}
+	errInvalidDigest                = &apiError{consts.StatusBadRequest, "InvalidDigest", "The Content-MD5 you specified is not valid."}
+	errInvalidPart                  = &apiError{consts.StatusBadRequest, "InvalidPart", "One or more of the specified parts could not be found."}
+	errInvalidPartOrder             = &apiError{consts.StatusBadRequest, "InvalidPartOrder", "The list of parts was not in ascending order."}
+	errInvalidRange                 = &apiError{consts.StatusRequestedRangeNotSatisfiable, "InvalidRange", "The requested range is not satisfiable."}
+	errKeyConflict                  = &apiError{consts.StatusConflict, "InvalidRequest", "The object key conflicts with an existing file or folder."}
+	errMalformedXML                 = &apiError{consts.StatusBadRequest, "MalformedXML", "The XML you provided was not well-formed or did not validate against our published schema."}
+	errMethodNotAllowed             = &apiError{consts.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed against this resource."}
+	errNoSuchBucket                 = &apiError{consts.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist."}
+	errNoSuchKey                    = &apiError{consts.StatusNotFound, "NoSuchKey", "The specified key does not exist."}
+	errNoSuchUpload                 = &apiError{consts.StatusNotFound, "NoSuchUpload", "The specified multipart upload does not exist."}
+	errNotImplemented               = &apiError{consts.StatusNotImplemented, "NotImplemented", "A header or query you provided implies functionality that is not implemented."}
+	errRequestTimeTooSkewed         = &apiError{consts.StatusForbidden, "RequestTimeTooSkewed", "The difference between the request time and the server's time is too large."}
+	errSignatureDoesNotMatch        = &apiError{consts.StatusForbidden, "SignatureDoesNotMatch", "The request signature we calculated does not match the signature you provided."}
+	errSignatureVersion             = &apiError{consts.StatusBadRequest, "InvalidRequest", "Only AWS Signature Version 4 is supported."}
+)
+
+func internalError(err error) *apiError {
+	log.Error("request failed", "error", err)
+	return &apiError{consts.StatusInternalServerError, "InternalError", "We encountered an internal error. Please try again."}
+}
+
+// toAPIError maps errors of the storage layer to S3 errors. notExist is
+// reported when the addressed path does not exist.
+func toAPIError(err error, notExist *apiError) *apiError {
+	var apiErr *apiError
+	switch {
+	case errors.As(err, &apiErr):
+		return apiErr
+	case errors.Is(err, fs.ErrNotExist):
+		return notExist
+	case errors.Is(err, vfs.ErrNotDir), errors.Is(err, vfs.ErrIsDir), errors.Is(err, fs.ErrExist):
+		return errKeyConflict
+	case errors.Is(err, vfs.ErrQuotaExceeded):
+		return errEntityTooLarge
+	case errors.Is(err, errContentSHA256):
+		return errContentSHA256Mismatch
+	case errors.Is(err, errContentMD5):
+		return errBadDigest
+	case errors.Is(err, errChunkSignature):
+		return errSignatureDoesNotMatch
+	case errors.Is(err, errChunkFormat):
+		return errIncompleteBody
+	}
+	return internalError(err)
+}
+
+type errorResponse struct {
+	XMLName  xml.Name `xml:"Error"`
+	Code     string   `xml:"Code"`
+	Message  string   `xml:"Message"`
+	Resource string   `xml:"Resource"`
+}
+
+func writeError(c *app.RequestContext, err *apiError) {
+	if c.IsHead() {
+		c.Status(err.Status)
+		return
+	}
+	writeXML(c, err.Status, &errorResponse{
+		Code:     err.Code,
+		Message:  err.Message,
+		Resource: string(c.Request.URI().PathOriginal()),
+	})
+}
+
+func writeXML(c *app.RequestContext, status int, v interface{}) {
+	out, err := xml.Marshal(v)
+	if err != nil {
+		c.String(consts.StatusInternalServerError, "failed to marshal response: %v", err)
+		return
+	}
+	c.Data(status, "application/xml", append([]byte(xml.Header), out...))
+}
@@ -0,0 +1,346 @@
+package s3
+
+import (
+	"cloud-storage/biz/jobs"
+	"cloud-storage/biz/metrics"
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	minPartSize   = 5 << 20
+	maxPartNumber = 10000
+)
+
+const (
+	// UploadExpiry is how long a multipart upload can stay incomplete
+	// before it is aborted, like the AbortIncompleteMultipartUpload rule
+	// of S3 lifecycles.
+	UploadExpiry = 7 * 24 * time.Hour
+	// partExpiry is how long a part being uploaded can go unwritten before
+	// it is taken for one cut short, e.g. by the server being killed.
+	partExpiry = 24 * time.Hour
+)
+
+var expireJob = jobs.Define("s3.multipart.expire", jobs.Options{Timeout: time.Hour}, func(ctx context.Context, _ struct{}) error {
+	uploads, parts, err := ExpireUploads(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	log.InfoContext(ctx, "expired multipart uploads", "uploads", uploads, "parts", parts)
+	return nil
+})
+
+func init() {
+	jobs.Schedule("s3.multipart.expire", "@daily", expireJob, struct{}{})
+}
+
+// MultipartDir holds one directory per multipart upload in progress, with
+// the upload description and one file per uploaded part. It is set from the
+// configuration at startup.
+var MultipartDir string
+
+func init() {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "s3",
+		Name:      "multipart_uploads",
+		Help:      "Multipart uploads in progress.",
+	}, func() float64 {
+		entries, err := os.ReadDir(MultipartDir)
+		if err != nil {
+			return 0
+		}
+		var n int
+		for _, e := range entries {
+			if e.IsDir() {
+				n++
+			}
+		}
+		return float64(n)
+	})
+}
+
+type upload struct {
+	UserIdentity string    `json:"user_identity"`
+	Bucket       string    `json:"bucket"`
+	Key          string    `json:"key"`
+	Initiated    time.Time `json:"initiated"`
+}
+
+func (g *gateway) createMultipartUpload(c *app.RequestContext) *apiError {
+	if _, apiErr := g.bucketEntry(); apiErr != nil {
+		return apiErr
+	}
+	if strings.HasSuffix(g.key, "/") {
+		return errInvalidArgument
+	}
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return internalError(err)
+	}
+	uploadID := hex.EncodeToString(b)
+	dir := filepath.Join(MultipartDir, uploadID)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return internalError(err)
+	}
+	data, err := json.Marshal(&upload{
+		UserIdentity: g.fs.UserIdentity(),
+		Bucket:       g.bucket,
+		Key:          g.key,
+		Initiated:    time.Now(),
+	})
+	if err != nil {
+		return internalError(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "upload.json"), data, 0o644); err != nil {
+		return internalError(err)
+	}
+
+	writeXML(c, consts.StatusOK, &initiateMultipartUploadResult{
+		Bucket:   g.bucket,
+		Key:      g.key,
+		UploadID: uploadID,
+	})
+	return nil
+}
+
+func (g *gateway) uploadPart(c *app.RequestContext, uploadID string) *apiError {
+	dir, apiErr := g.upload(uploadID)
+	if apiErr != nil {
+		return apiErr
+	}
+	partNumber, err := strconv.Atoi(c.Query("partNumber"))
+	if err != nil || partNumber < 1 || partNumber > maxPartNumber {
+		return errInvalidArgument
+	}
+	if len(c.GetHeader("X-Amz-Copy-Source")) > 0 {
+		return errNotImplemented
+	}
+	r, apiErr := g.body(c)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	tmp, err := os.CreateTemp(dir, ".part-*")
+	if err != nil {
+		return internalError(err)
+	}
+	defer os.Remove(tmp.Name())
+	h := md5.New()
+	_, err = io.Copy(io.MultiWriter(tmp, h), r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return toAPIError(err, errNoSuchUpload)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, strconv.Itoa(partNumber))); err != nil {
+		return internalError(err)
+	}
+
+	c.Header("ETag", `"`+hex.EncodeToString(h.Sum(nil))+`"`)
+	c.Status(consts.StatusOK)
+	return nil
+}
+
+// completeMultipartUpload stores the listed parts, in order, as the object.
+// The object is pooled like any other file, so its ETag is the MD5 of the
+// whole content rather than the composite ETag S3 reports.
+func (g *gateway) completeMultipartUpload(c *app.RequestContext, uploadID string) *apiError {
+	dir, apiErr := g.upload(uploadID)
+	if apiErr != nil {
+		return apiErr
+	}
+	var req completeMultipartUpload
+	if apiErr := g.readXML(c, &req); apiErr != nil {
+		return apiErr
+	}
+	if len(req.Parts) == 0 {
+		return errMalformedXML
+	}
+
+	var readers []io.Reader
+	for i, part := range req.Parts {
+		if i > 0 && part.PartNumber <= req.Parts[i-1].PartNumber {
+			return errInvalidPartOrder
+		}
+		etag, err := hex.DecodeString(strings.Trim(part.ETag, `"`))
+		if err != nil || len(etag) != md5.Size {
+			return errInvalidPart
+		}
+		file, err := os.Open(filepath.Join(dir, strconv.Itoa(part.PartNumber)))
+		if errors.Is(err, fs.ErrNotExist) {
+			return errInvalidPart
+		}
+		if err != nil {
+			return internalError(err)
+		}
+		defer file.Close()
+		info, err := file.Stat()
+		if err != nil {
+			return internalError(err)
+		}
+		if info.Size() < minPartSize && i < len(req.Parts)-1 {
+			return errEntityTooSmall
+		}
+		readers = append(readers, &verifyingReader{r: file, md5: md5.New(), md5Sum: etag})
+	}
+
+	if _, err := g.fs.MkdirAll(path.Dir(g.path())); err != nil {
+		return toAPIError(err, errNoSuchBucket)
+	}
+	e, err := g.fs.Put(g.path(), io.MultiReader(readers...))
+	if errors.Is(err, errContentMD5) {
+		return errInvalidPart
+	}
+	if err != nil {
+		return toAPIError(err, errNoSuchBucket)
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return internalError(err)
+	}
+
+	writeXML(c, consts.StatusOK, &completeMultipartUploadResult{
+		Location: Prefix + "/" + g.bucket + "/" + g.key,
+		Bucket:   g.bucket,
+		Key:      g.key,
+		ETag:     `"` + e.Pool.Hash + `"`,
+	})
+	return nil
+}
+
+func (g *gateway) abortMultipartUpload(c *app.RequestContext, uploadID string) *apiError {
+	dir, apiErr := g.upload(uploadID)
+	if apiErr != nil {
+		return apiErr
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return internalError(err)
+	}
+	c.Status(consts.StatusNoContent)
+	return nil
+}
+
+// ExpireUploads aborts the multipart uploads initiated UploadExpiry before
+// now and removes the parts left half-written in the others, returning how
+// many of each were removed. Uploads being completed are not disturbed:
+// their parts are open already and read to the end.
+func ExpireUploads(ctx context.Context, now time.Time) (uploads, parts int, err error) {
+	entries, err := os.ReadDir(MultipartDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, e := range entries {
+		if ctx.Err() != nil {
+			return uploads, parts, ctx.Err()
+		}
+		if !e.IsDir() {
+			continue
+		}
+		dir := filepath.Join(MultipartDir, e.Name())
+		initiated, err := initiatedAt(dir)
+		if err != nil {
+			log.WarnContext(ctx, "failed to read multipart upload", "upload_id", e.Name(), "error", err)
+			continue
+		}
+		if now.Sub(initiated) >= UploadExpiry {
+			if err := os.RemoveAll(dir); err != nil {
+				log.WarnContext(ctx, "failed to abort multipart upload", "upload_id", e.Name(), "error", err)
+				continue
+			}
+			uploads++
+			continue
+		}
+		parts += removeStaleParts(ctx, dir, now)
+	}
+	return uploads, parts, nil
+}
+
+// initiatedAt returns when the upload in dir was initiated, or when dir
+// was created if its description was never written.
+func initiatedAt(dir string) (time.Time, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "upload.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return info.ModTime(), nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	var u upload
+	if err := json.Unmarshal(data, &u); err != nil {
+		return time.Time{}, err
+	}
+	return u.Initiated, nil
+}
+
+// removeStaleParts removes the temporary files of parts whose upload was
+// cut short, and returns how many it removed.
+func removeStaleParts(ctx context.Context, dir string, now time.Time) int {
+	tmps, err := filepath.Glob(filepath.Join(dir, ".part-*"))
+	if err != nil {
+		return 0
+	}
+	var n int
+	for _, tmp := range tmps {
+		info, err := os.Stat(tmp)
+		if err != nil || now.Sub(info.ModTime()) < partExpiry {
+			continue
+		}
+		if err := os.Remove(tmp); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			log.WarnContext(ctx, "failed to remove stale part", "path", tmp, "error", err)
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+// upload returns the directory of the upload, which must have been created
+// by the same user for the same object.
+func (g *gateway) upload(uploadID string) (string, *apiError) {
+	if _, err := hex.DecodeString(uploadID); err != nil || len(uploadID) != 32 {
+		return "", errNoSuchUpload
+	}
+	dir := filepath.Join(MultipartDir, uploadID)
+	data, err := os.ReadFile(filepath.Join(dir, "upload.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", errNoSuchUpload
+	}
+	if err != nil {
+		return "", internalError(err)
+	}
+	var u upload
+	if err := json.Unmarshal(data, &u); err != nil {
+		return "", internalError(err)
+	}
+	if u.UserIdentity != g.fs.UserIdentity() || u.Bucket != g.bucket || u.Key != g.key {
+		return "", errNoSuchUpload
+	}
+	return dir, nil
+}
@@ -0,0 +1,723 @@
+package s3
+
+import (
+	"cloud-storage/biz/blob"
+	"cloud-storage/biz/logging"
+	"cloud-storage/biz/vfs"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+)
+
+// Prefix is the URL path under which the S3 API is served. Clients address
+// buckets path-style, e.g. http://localhost:8888/s3/<bucket>/<key>.
+const Prefix = "/s3"
+
+// emptyETag is the ETag of zero-length content, reported for folder markers.
+const emptyETag = `"d41d8cd98f00b204e9800998ecf8427e"`
+
+const maxXMLSize = 1 << 20
+
+var log = logging.Logger("s3")
+
+var methods = []string{
+	consts.MethodGet, consts.MethodHead, consts.MethodPut, consts.MethodPost, consts.MethodDelete,
+}
+
+// Register mounts the S3 API on r.
+func Register(r *server.Hertz) {
+	for _, method := range methods {
+		r.Handle(method, Prefix, Handler)
+		r.Handle(method, Prefix+"/*path", Handler)
+	}
+}
+
+// gateway serves one S3 request against the tree of the user owning the
+// access key it was signed with. Buckets are the top-level folders of the
+// tree and object keys are paths below them.
+type gateway struct {
+	fs     *vfs.FS
+	sig    *signature
+	bucket string
+	key    string
+}
+
+// Handler authenticates the request and dispatches it to the S3 operation
+// it addresses.
+func Handler(ctx context.Context, c *app.RequestContext) {
+	sig, apiErr := authenticate(ctx, c)
+	if apiErr != nil {
+		writeError(c, apiErr)
+		return
+	}
+	logging.SetUser(ctx, sig.key.UserIdentity)
+	p, err := url.PathUnescape(strings.TrimPrefix(string(c.Request.URI().PathOriginal()), Prefix))
+	if err != nil {
+		writeError(c, errInvalidArgument)
+		return
+	}
+	g := &gateway{fs: vfs.New(ctx, sig.key.UserIdentity), sig: sig}
+	g.bucket, g.key, _ = strings.Cut(strings.TrimPrefix(p, "/"), "/")
+
+	switch {
+	case g.bucket == "":
+		apiErr = g.serviceOperation(c)
+	case g.key == "":
+		apiErr = g.bucketOperation(c)
+	default:
+		apiErr = g.objectOperation(c)
+	}
+	if apiErr != nil {
+		writeError(c, apiErr)
+	}
+}
+
+func (g *gateway) serviceOperation(c *app.RequestContext) *apiError {
+	if !c.IsGet() {
+		return errMethodNotAllowed
+	}
+	return g.listBuckets(c)
+}
+
+func (g *gateway) bucketOperation(c *app.RequestContext) *apiError {
+	if !validName(g.bucket) {
+		return errInvalidBucketName
+	}
+	method, args := string(c.Method()), c.Request.URI().QueryArgs()
+	for _, sub := range []string{"acl", "cors", "lifecycle", "policy", "tagging", "uploads", "versioning", "versions"} {
+		if args.Has(sub) {
+			return errNotImplemented
+		}
+	}
+	switch {
+	case method == consts.MethodGet && args.Has("location"):
+		return g.getBucketLocation(c)
+	case method == consts.MethodGet:
+		return g.listObjects(c)
+	case method == consts.MethodHead:
+		return g.headBucket(c)
+	case method == consts.MethodPut:
+		return g.createBucket(c)
+	case method == consts.MethodDelete:
+		return g.deleteBucket(c)
+	case method == consts.MethodPost && args.Has("delete"):
+		return g.deleteObjects(c)
+	}
+	return errMethodNotAllowed
+}
+
+func (g *gateway) objectOperation(c *app.RequestContext) *apiError {
+	if !validName(g.bucket) {
+		return errInvalidBucketName
+	}
+	if !validKey(g.key) {
+		return errInvalidArgument
+	}
+	method, args := string(c.Method()), c.Request.URI().QueryArgs()
+	for _, sub := range []string{"acl", "tagging", "retention", "legal-hold"} {
+		if args.Has(sub) {
+			return errNotImplemented
+		}
+	}
+	uploadID := c.Query("uploadId")
+	switch {
+	case method == consts.MethodPut && uploadID != "":
+		return g.uploadPart(c, uploadID)
+	case method == consts.MethodPost && uploadID != "":
+		return g.completeMultipartUpload(c, uploadID)
+	case method == consts.MethodDelete && uploadID != "":
+		return g.abortMultipartUpload(c, uploadID)
+	case uploadID != "":
+		return errNotImplemented
+	case method == consts.MethodPost && args.Has("uploads"):
+		return g.createMultipartUpload(c)
+	case method == consts.MethodGet || method == consts.MethodHead:
+		return g.getObject(c)
+	case method == consts.MethodPut && len(c.GetHeader("X-Amz-Copy-Source")) > 0:
+		return g.copyObject(c)
+	case method == consts.MethodPut:
+		return g.putObject(c)
+	case method == consts.MethodDelete:
+		return g.deleteObject(c)
+	}
+	return errMethodNotAllowed
+}
+
+func (g *gateway) listBuckets(c *app.RequestContext) *apiError {
+	entries, err := g.fs.ReadDir(g.fs.Root())
+	if err != nil {
+		return internalError(err)
+	}
+	result := &listAllMyBucketsResult{Owner: owner{ID: g.fs.UserIdentity()}}
+	for _, e := range entries {
+		if e.IsDir() {
+			result.Buckets = append(result.Buckets, bucket{Name: e.Name, CreationDate: timestamp(e.CreatedAt)})
+		}
+	}
+	writeXML(c, consts.StatusOK, result)
+	return nil
+}
+
+func (g *gateway) getBucketLocation(c *app.RequestContext) *apiError {
+	if _, apiErr := g.bucketEntry(); apiErr != nil {
+		return apiErr
+	}
+	writeXML(c, consts.StatusOK, &locationConstraint{})
+	return nil
+}
+
+func (g *gateway) headBucket(c *app.RequestContext) *apiError {
+	if _, apiErr := g.bucketEntry(); apiErr != nil {
+		return apiErr
+	}
+	c.Status(consts.StatusOK)
+	return nil
+}
+
+func (g *gateway) createBucket(c *app.RequestContext) *apiError {
+	if _, err := g.fs.Mkdir("/" + g.bucket); err != nil {
+		if _, apiErr := g.bucketEntry(); apiErr == nil {
+			return errBucketAlreadyOwnedByYou
+		}
+		return toAPIError(err, errNoSuchBucket)
+	}
+	c.Header("Location", "/"+g.bucket)
+	c.Status(consts.StatusOK)
+	return nil
+}
+
+func (g *gateway) deleteBucket(c *app.RequestContext) *apiError {
+	dir, apiErr := g.bucketEntry()
+	if apiErr != nil {
+		return apiErr
+	}
+	entries, err := g.fs.ReadDir(dir)
+	if err != nil {
+		return internalError(err)
+	}
+	if len(entries) > 0 {
+		return errBucketNotEmpty
+	}
+	if err := g.fs.Remove("/" + g.bucket); err != nil {
+		return toAPIError(err, errNoSuchBucket)
+	}
+	c.Status(consts.StatusNoContent)
+	return nil
+}
+
+// listObjects implements both ListObjects and ListObjectsV2, which differ
+// only in how the client pages through the results.
+func (g *gateway) listObjects(c *app.RequestContext) *apiError {
+	dir, apiErr := g.bucketEntry()
+	if apiErr != nil {
+		return apiErr
+	}
+	v2 := c.Query("list-type") == "2"
+	prefix, delimiter := c.Query("prefix"), c.Query("delimiter")
+	maxKeys := 1000
+	if s := c.Query("max-keys"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return errInvalidArgument
+		}
+		maxKeys = min(n, 1000)
+	}
+	marker := c.Query("marker")
+	if v2 {
+		marker = c.Query("start-after")
+		if token := c.Query("continuation-token"); token != "" {
+			b, err := base64.RawURLEncoding.DecodeString(token)
+			if err != nil {
+				return errInvalidArgument
+			}
+			marker = string(b)
+		}
+	}
+
+	objects, err := g.objects(dir, prefix, delimiter)
+	if err != nil {
+		return internalError(err)
+	}
+	contents, prefixes, next, truncated := paginate(objects, prefix, delimiter, marker, maxKeys)
+
+	encode := func(s string) string { return s }
+	if c.Query("encoding-type") == "url" {
+		encode = url.QueryEscape
+	}
+	result := &listBucketResult{
+		Name:         g.bucket,
+		Prefix:       encode(prefix),
+		Delimiter:    encode(delimiter),
+		MaxKeys:      maxKeys,
+		IsTruncated:  truncated,
+		EncodingType: c.Query("encoding-type"),
+	}
+	for _, o := range contents {
+		result.Contents = append(result.Contents, o.content(encode))
+	}
+	for _, p := range prefixes {
+		result.CommonPrefixes = append(result.CommonPrefixes, commonPrefix{Prefix: encode(p)})
+	}
+	if v2 {
+		result.KeyCount = len(contents) + len(prefixes)
+		result.ContinuationToken = c.Query("continuation-token")
+		result.StartAfter = encode(c.Query("start-after"))
+		if truncated {
+			result.NextContinuationToken = base64.RawURLEncoding.EncodeToString([]byte(next))
+		}
+	} else {
+		result.Marker = encode(marker)
+		if truncated {
+			result.NextMarker = encode(next)
+		}
+	}
+	writeXML(c, consts.StatusOK, result)
+	return nil
+}
+
+// object is a listed key. Folders are listed with a trailing slash.
+type object struct {
+	key   string
+	entry *vfs.Entry
+}
+
+func (o object) content(encode func(string) string) content {
+	etag := emptyETag
+	if o.entry.Pool != nil {
+		etag = `"` + o.entry.Pool.Hash + `"`
+	}
+	return content{
+		Key:          encode(o.key),
+		LastModified: timestamp(o.entry.UpdatedAt),
+		ETag:         etag,
+		Size:         o.entry.Size(),
+		StorageClass: "STANDARD",
+	}
+}
+
+// objects returns the keys starting with prefix, sorted. Files are listed
+// with their path and empty folders as folder markers. With the "/"
+// delimiter only the folder containing prefix is read, as deeper entries
+// are rolled up into common prefixes anyway.
+func (g *gateway) objects(bucket *vfs.Entry, prefix, delimiter string) ([]object, error) {
+	dirPart := prefix[:strings.LastIndex(prefix, "/")+1]
+	if dirPart != "" && !validKey(dirPart) {
+		return nil, nil
+	}
+	dir := bucket
+	if dirPart != "" {
+		e, err := g.fs.Stat(path.Join("/", g.bucket, dirPart))
+		if err != nil || !e.IsDir() {
+			return nil, nil
+		}
+		dir = e
+	}
+
+	var objects []object
+	if delimiter == "/" {
+		entries, err := g.fs.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			objects = append(objects, object{key: dirPart + keyName(e.Name, e), entry: e})
+		}
+	} else {
+		var folders []object
+		nonEmpty := map[int32]bool{}
+		err := g.fs.Walk(dir, func(name string, e *vfs.Entry) error {
+			nonEmpty[e.ParentID] = true
+			o := object{key: dirPart + keyName(name, e), entry: e}
+			if e.IsDir() {
+				folders = append(folders, o)
+			} else {
+				objects = append(objects, o)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range folders {
+			if !nonEmpty[int32(o.entry.ID)] {
+				objects = append(objects, o)
+			}
+		}
+	}
+
+	filtered := objects[:0]
+	for _, o := range objects {
+		if strings.HasPrefix(o.key, prefix) {
+			filtered = append(filtered, o)
+		}
+	}
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i].key < filtered[j].key })
+	return filtered, nil
+}
+
+// paginate returns up to maxKeys objects and common prefixes following
+// marker, and the last of them to continue from when the list is truncated.
+func paginate(objects []object, prefix, delimiter, marker string, maxKeys int) ([]object, []string, string, bool) {
+	var (
+		contents []object
+		prefixes []string
+		next     string
+		skip     string
+	)
+	// A marker ending in the delimiter was a common prefix on the previous
+	// page, so the keys it rolled up must not be listed again
+	if delimiter != "" && strings.HasSuffix(marker, delimiter) {
+		skip = marker
+	}
+	for _, o := range objects {
+		if o.key <= marker || skip != "" && strings.HasPrefix(o.key, skip) {
+			continue
+		}
+		if len(contents)+len(prefixes) == maxKeys {
+			return contents, prefixes, next, true
+		}
+		if i := strings.Index(o.key[len(prefix):], delimiter); delimiter != "" && i >= 0 {
+			next = o.key[:len(prefix)+i+len(delimiter)]
+			prefixes = append(prefixes, next)
+			skip = next
+			continue
+		}
+		contents = append(contents, o)
+		next = o.key
+	}
+	return contents, prefixes, next, false
+}
+
+func (g *gateway) getObject(c *app.RequestContext) *apiError {
+	e, apiErr := g.objectEntry()
+	if apiErr != nil {
+		return apiErr
+	}
+
+	c.Header("Last-Modified", e.UpdatedAt.UTC().Format(http.TimeFormat))
+	c.Header("Accept-Ranges", "bytes")
+	if e.IsDir() {
+		c.Header("ETag", emptyETag)
+		c.Response.Header.SetContentType("application/x-directory")
+		c.Response.Header.SetContentLength(0)
+		c.Status(consts.StatusOK)
+		return nil
+	}
+	c.Header("ETag", `"`+e.Pool.Hash+`"`)
+	ctype := mime.TypeByExtension(filepath.Ext(e.Name))
+	if ctype == "" {
+		ctype = "application/octet-stream"
+	}
+	c.Response.Header.SetContentType(ctype)
+
+	size := e.Size()
+	start, length, partial, apiErr := parseRange(string(c.GetHeader("Range")), size)
+	if apiErr != nil {
+		c.Header("Content-Range", "bytes */"+strconv.FormatInt(size, 10))
+		return apiErr
+	}
+	status := consts.StatusOK
+	if partial {
+		status = consts.StatusPartialContent
+		c.Header("Content-Range", "bytes "+strconv.FormatInt(start, 10)+"-"+
+			strconv.FormatInt(start+length-1, 10)+"/"+strconv.FormatInt(size, 10))
+	}
+	if c.IsHead() {
+		c.Response.Header.SetContentLength(int(length))
+		c.Status(status)
+		return nil
+	}
+
+	file, err := blob.Open(g.fs.Context(), e.Pool)
+	if err != nil {
+		return internalError(err)
+	}
+	c.Status(status)
+	c.SetBodyStream(readCloser{io.NewSectionReader(file, start, length), file}, int(length))
+	return nil
+}
+
+func (g *gateway) putObject(c *app.RequestContext) *apiError {
+	if _, apiErr := g.bucketEntry(); apiErr != nil {
+		return apiErr
+	}
+	r, apiErr := g.body(c)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	// Keys ending in a slash are folder markers
+	if strings.HasSuffix(g.key, "/") {
+		if _, err := io.Copy(io.Discard, r); err != nil {
+			return toAPIError(err, errNoSuchBucket)
+		}
+		if _, err := g.fs.MkdirAll(g.path()); err != nil {
+			return toAPIError(err, errNoSuchBucket)
+		}
+		c.Header("ETag", emptyETag)
+		c.Status(consts.StatusOK)
+		return nil
+	}
+
+	if _, err := g.fs.MkdirAll(path.Dir(g.path())); err != nil {
+		return toAPIError(err, errNoSuchBucket)
+	}
+	e, err := g.fs.Put(g.path(), r)
+	if err != nil {
+		return toAPIError(err, errNoSuchBucket)
+	}
+	c.Header("ETag", `"`+e.Pool.Hash+`"`)
+	c.Status(consts.StatusOK)
+	return nil
+}
+
+// copyObject links the destination to the content of the source object,
+// which must belong to the same user.
+func (g *gateway) copyObject(c *app.RequestContext) *apiError {
+	source, _, _ := strings.Cut(string(c.GetHeader("X-Amz-Copy-Source")), "?")
+	source, err := url.PathUnescape(source)
+	if err != nil {
+		return errInvalidArgument
+	}
+	srcBucket, srcKey, _ := strings.Cut(strings.TrimPrefix(source, "/"), "/")
+	if !validName(srcBucket) || !validKey(srcKey) || strings.HasSuffix(srcKey, "/") {
+		return errInvalidArgument
+	}
+	src := &gateway{fs: g.fs, bucket: srcBucket, key: srcKey}
+	e, apiErr := src.objectEntry()
+	if apiErr != nil {
+		return apiErr
+	}
+	if _, apiErr := g.bucketEntry(); apiErr != nil {
+		return apiErr
+	}
+	if strings.HasSuffix(g.key, "/") {
+		return errInvalidArgument
+	}
+
+	if _, err := g.fs.MkdirAll(path.Dir(g.path())); err != nil {
+		return toAPIError(err, errNoSuchBucket)
+	}
+	dst, err := g.fs.Link(g.path(), e.Pool)
+	if err != nil {
+		return toAPIError(err, errNoSuchBucket)
+	}
+	writeXML(c, consts.StatusOK, &copyObjectResult{
+		LastModified: timestamp(dst.UpdatedAt),
+		ETag:         `"` + e.Pool.Hash + `"`,
+	})
+	return nil
+}
+
+func (g *gateway) deleteObject(c *app.RequestContext) *apiError {
+	if _, apiErr := g.bucketEntry(); apiErr != nil {
+		return apiErr
+	}
+	if apiErr := g.delete(); apiErr != nil {
+		return apiErr
+	}
+	c.Status(consts.StatusNoContent)
+	return nil
+}
+
+func (g *gateway) deleteObjects(c *app.RequestContext) *apiError {
+	if _, apiErr := g.bucketEntry(); apiErr != nil {
+		return apiErr
+	}
+	var req deleteRequest
+	if apiErr := g.readXML(c, &req); apiErr != nil {
+		return apiErr
+	}
+	if len(req.Objects) > 1000 {
+		return errMalformedXML
+	}
+
+	result := &deleteResult{}
+	for _, o := range req.Objects {
+		target := &gateway{fs: g.fs, bucket: g.bucket, key: o.Key}
+		apiErr := errInvalidArgument
+		if validKey(o.Key) {
+			apiErr = target.delete()
+		}
+		if apiErr != nil {
+			result.Errors = append(result.Errors, deleteError{Key: o.Key, Code: apiErr.Code, Message: apiErr.Message})
+		} else if !req.Quiet {
+			result.Deleted = append(result.Deleted, deleted{Key: o.Key})
+		}
+	}
+	writeXML(c, consts.StatusOK, result)
+	return nil
+}
+
+// delete removes the object. Deleting a key that does not exist succeeds,
+// and a folder marker is only removed together with the folder once the
+// folder is empty.
+func (g *gateway) delete() *apiError {
+	e, err := g.fs.Stat(g.path())
+	if err != nil {
+		return toAPIError(err, nil)
+	}
+	if e.IsDir() != strings.HasSuffix(g.key, "/") {
+		return nil
+	}
+	if e.IsDir() {
+		entries, err := g.fs.ReadDir(e)
+		if err != nil {
+			return internalError(err)
+		}
+		if len(entries) > 0 {
+			return nil
+		}
+	}
+	if err := g.fs.Remove(g.path()); err != nil {
+		return toAPIError(err, nil)
+	}
+	return nil
+}
+
+// bucketEntry returns the folder backing the bucket.
+func (g *gateway) bucketEntry() (*vfs.Entry, *apiError) {
+	e, err := g.fs.Stat("/" + g.bucket)
+	if err != nil {
+		return nil, toAPIError(err, errNoSuchBucket)
+	}
+	if !e.IsDir() {
+		return nil, errNoSuchBucket
+	}
+	return e, nil
+}
+
+// objectEntry returns the file backing the object, or the folder for a key
+// ending in a slash.
+func (g *gateway) objectEntry() (*vfs.Entry, *apiError) {
+	if _, apiErr := g.bucketEntry(); apiErr != nil {
+		return nil, apiErr
+	}
+	e, err := g.fs.Stat(g.path())
+	if err != nil {
+		return nil, toAPIError(err, errNoSuchKey)
+	}
+	if e.IsDir() != strings.HasSuffix(g.key, "/") {
+		return nil, errNoSuchKey
+	}
+	return e, nil
+}
+
+// body returns the request payload, verified against the signature and the
+// Content-MD5 header.
+func (g *gateway) body(c *app.RequestContext) (io.Reader, *apiError) {
+	var contentMD5 []byte
+	if s := string(c.GetHeader("Content-MD5")); s != "" {
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil || len(b) != md5.Size {
+			return nil, errInvalidDigest
+		}
+		contentMD5 = b
+	}
+	return body(c.RequestBodyStream(), g.sig, contentMD5)
+}
+
+// readXML decodes a verified XML request body into v.
+func (g *gateway) readXML(c *app.RequestContext, v interface{}) *apiError {
+	r, apiErr := g.body(c)
+	if apiErr != nil {
+		return apiErr
+	}
+	data, err := io.ReadAll(io.LimitReader(r, maxXMLSize))
+	if err != nil {
+		return toAPIError(err, nil)
+	}
+	if err := xml.Unmarshal(data, v); err != nil {
+		return errMalformedXML
+	}
+	return nil
+}
+
+// path returns the file system path of the object.
+func (g *gateway) path() string {
+	return "/" + g.bucket + "/" + strings.TrimSuffix(g.key, "/")
+}
+
+// parseRange parses a single byte range of a Range header. Other headers,
+// including multiple ranges, select the whole content.
+func parseRange(header string, size int64) (start, length int64, partial bool, apiErr *apiError) {
+	spec, ok := strings.CutPrefix(header, "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return 0, size, false, nil
+	}
+	first, last, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, size, false, nil
+	}
+	end := size - 1
+	if first == "" {
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n <= 0 {
+			return 0, 0, false, errInvalidRange
+		}
+		start = max(size-n, 0)
+	} else {
+		var err error
+		if start, err = strconv.ParseInt(first, 10, 64); err != nil {
+			return 0, size, false, nil
+		}
+		if last != "" {
+			n, err := strconv.ParseInt(last, 10, 64)
+			if err != nil || n < start {
+				return 0, size, false, nil
+			}
+			end = min(n, size-1)
+		}
+	}
+	if start >= size {
+		return 0, 0, false, errInvalidRange
+	}
+	return start, end - start + 1, true, nil
+}
+
+// validName reports whether s can be a bucket name, which is a single path
+// element.
+func validName(s string) bool {
+	return s != "" && s != "." && s != ".." && !strings.Contains(s, "/")
+}
+
+// validKey reports whether every element of the key can be a file or folder
+// name. Only folder markers may end in a slash.
+func validKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, elem := range strings.Split(strings.TrimSuffix(key, "/"), "/") {
+		if !validName(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+// keyName returns the key suffix of a listed entry.
+func keyName(name string, e *vfs.Entry) string {
+	if e.IsDir() {
+		return name + "/"
+	}
+	return name
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
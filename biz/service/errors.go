@@ -0,0 +1,40 @@
+package service
+
+import (
+	"cloud-storage/biz/errno"
+	"errors"
+)
+
+// internal wraps an unexpected error, typically from a repository, with a
+// description of what failed.
+func internal(err error, format string, args ...interface{}) *errno.Error {
+	return errno.Wrap(errno.Internal, err, format, args...)
+}
+
+// notFoundOr reports ErrRecordNotFound as code with message and any other
+// error as internal.
+func notFoundOr(err error, code errno.Code, message, format string, args ...interface{}) *errno.Error {
+	if errors.Is(err, ErrRecordNotFound) {
+		return errno.New(code, "%s", message)
+	}
+	return internal(err, format, args...)
+}
+
+// nameConflictOr reports ErrDuplicate as a name conflict and any other
+// error as internal.
+func nameConflictOr(err error, format string, args ...interface{}) *errno.Error {
+	if errors.Is(err, ErrDuplicate) {
+		return errno.New(errno.NameConflict, "file name already exists in the same folder")
+	}
+	return internal(err, format, args...)
+}
+
+// txError returns the error of a transaction. Its steps already report
+// *errno.Error, so anything else, such as a failed commit, is internal.
+func txError(err error, format string, args ...interface{}) error {
+	var e *errno.Error
+	if err == nil || errors.As(err, &e) {
+		return err
+	}
+	return internal(err, format, args...)
+}
@@ -0,0 +1,606 @@
+package service
+
+import (
+	"cloud-storage/biz/audit"
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/errno"
+	"cloud-storage/biz/fulltext"
+	"context"
+	"errors"
+	"io"
+	"math"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/duke-git/lancet/v2/random"
+)
+
+// FileService manages the file trees of users.
+type FileService struct {
+	files      FileRepository
+	blobs      BlobRepository
+	properties PropertyRepository
+	content    ContentStore
+	tx         Transactor
+	quota      *quota
+}
+
+func NewFileService(files FileRepository, blobs BlobRepository, users UserRepository, properties PropertyRepository, content ContentStore, tx Transactor, userQuota int64) *FileService {
+	return &FileService{files: files, blobs: blobs, properties: properties, content: content, tx: tx, quota: &quota{users: users, files: files, blobs: blobs, userBytes: userQuota}}
+}
+
+type SaveRequest struct {
+	ParentID           int64
+	RepositoryIdentity string
+	Ext                string
+	Name               string
+}
+
+// Save links a blob of the repository pool into one of the user's folders.
+func (s *FileService) Save(ctx context.Context, userIdentity string, req *SaveRequest) error {
+	if err := s.quota.check(ctx, userIdentity, req.RepositoryIdentity, ""); err != nil {
+		return err
+	}
+	uuid, err := random.UUIdV4()
+	if err != nil {
+		return internal(err, "failed to generate UUID")
+	}
+	err = s.tx.Transaction(func(r Repositories) error {
+		if err := checkParent(ctx, r.Files, userIdentity, req.ParentID); err != nil {
+			return err
+		}
+		err := r.Files.Create(ctx, &entity.UserRepository{
+			Identity:           uuid,
+			UserIdentity:       userIdentity,
+			ParentID:           int32(req.ParentID),
+			RepositoryIdentity: req.RepositoryIdentity,
+			Ext:                req.Ext,
+			Name:               req.Name,
+		})
+		if err != nil {
+			return nameConflictOr(err, "failed to create repository")
+		}
+		return nil
+	})
+	return txError(err, "failed to create repository")
+}
+
+// Link points the file called name in one of the user's folders, 0 for the
+// root, at a blob of the repository pool: it creates the file, or replaces
+// the content of the one already there, and returns it.
+func (s *FileService) Link(ctx context.Context, userIdentity string, parentID int64, name, repositoryIdentity string) (*entity.UserRepository, error) {
+	var replaced string
+	if parentID >= 0 && parentID <= math.MaxInt32 {
+		existing, err := s.files.FindChild(ctx, userIdentity, int32(parentID), name)
+		if err != nil && !errors.Is(err, ErrRecordNotFound) {
+			return nil, internal(err, "failed to query user repository")
+		}
+		if existing != nil {
+			replaced = existing.RepositoryIdentity
+		}
+	}
+	if err := s.quota.check(ctx, userIdentity, repositoryIdentity, replaced); err != nil {
+		return nil, err
+	}
+	uuid, err := random.UUIdV4()
+	if err != nil {
+		return nil, internal(err, "failed to generate UUID")
+	}
+	var ur *entity.UserRepository
+	err = s.tx.Transaction(func(r Repositories) error {
+		if err := checkParent(ctx, r.Files, userIdentity, parentID); err != nil {
+			return err
+		}
+		existing, err := r.Files.FindChild(ctx, userIdentity, int32(parentID), name)
+		if err == nil {
+			if existing.RepositoryIdentity == "" {
+				return errno.New(errno.NameConflict, "a folder of the same name already exists")
+			}
+			if err := r.Files.UpdateContent(ctx, userIdentity, existing.Identity, repositoryIdentity); err != nil {
+				return internal(err, "failed to update user repository")
+			}
+			existing.RepositoryIdentity = repositoryIdentity
+			ur = existing
+			return nil
+		}
+		if !errors.Is(err, ErrRecordNotFound) {
+			return internal(err, "failed to query user repository")
+		}
+		ur = &entity.UserRepository{
+			Identity:           uuid,
+			UserIdentity:       userIdentity,
+			ParentID:           int32(parentID),
+			RepositoryIdentity: repositoryIdentity,
+			Ext:                path.Ext(name),
+			Name:               name,
+		}
+		if err := r.Files.Create(ctx, ur); err != nil {
+			return nameConflictOr(err, "failed to create repository")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, txError(err, "failed to link repository")
+	}
+	return ur, nil
+}
+
+// Child returns the entry called name in one of the user's folders, 0 for
+// the root.
+func (s *FileService) Child(ctx context.Context, userIdentity string, parentID int32, name string) (*entity.UserRepository, error) {
+	ur, err := s.files.FindChild(ctx, userIdentity, parentID, name)
+	if err != nil {
+		return nil, notFoundOr(err, errno.FileNotFound, "file does not exist", "failed to query user repository")
+	}
+	return ur, nil
+}
+
+// Children returns the entries of the user's folders of the given IDs,
+// ordered by name.
+func (s *FileService) Children(ctx context.Context, userIdentity string, parentIDs []int32) ([]*entity.UserRepository, error) {
+	urs, err := s.files.ListByParents(ctx, userIdentity, parentIDs)
+	if err != nil {
+		return nil, internal(err, "failed to query user repository")
+	}
+	return urs, nil
+}
+
+// Blobs returns the records of the repository pool of the given identities.
+func (s *FileService) Blobs(ctx context.Context, identities []string) ([]*entity.RepositoryPool, error) {
+	rps, err := s.blobs.FindByIdentities(ctx, identities)
+	if err != nil {
+		return nil, internal(err, "failed to query repository pool")
+	}
+	return rps, nil
+}
+
+type ListRequest struct {
+	// ParentID is the decimal ID of the folder, 0 for the root
+	ParentID string
+	Page     int32
+	Size     int32
+}
+
+// List returns a page of the entries of a folder and their total number.
+func (s *FileService) List(ctx context.Context, userIdentity string, req *ListRequest) ([]*FileRow, int64, error) {
+	parentID, err := strconv.Atoi(req.ParentID)
+	if err != nil {
+		return nil, 0, errno.New(errno.InvalidArgument, "invalid identity: %v", err)
+	}
+	offset, limit := page(req.Page, req.Size)
+	rows, count, err := s.files.ListChildren(ctx, userIdentity, int32(parentID), offset, limit)
+	if err != nil {
+		return nil, 0, internal(err, "failed to query user repository")
+	}
+	return rows, count, nil
+}
+
+// Rename renames an entry, keeping names unique within its folder.
+func (s *FileService) Rename(ctx context.Context, userIdentity, identity, name string) error {
+	var oldName string
+	err := s.tx.Transaction(func(r Repositories) error {
+		ur, err := r.Files.Find(ctx, userIdentity, identity)
+		if err != nil {
+			return notFoundOr(err, errno.FileNotFound, "file does not exist", "failed to query user repository")
+		}
+		oldName = ur.Name
+		if err := r.Files.UpdateName(ctx, userIdentity, identity, name); err != nil {
+			return nameConflictOr(err, "failed to update user repository name")
+		}
+		return nil
+	})
+	if err != nil {
+		return txError(err, "failed to rename user repository")
+	}
+	audit.Record(ctx, &audit.Event{
+		Action: audit.FileRename,
+		Actor:  userIdentity,
+		Target: identity,
+		Before: map[string]string{"name": oldName},
+		After:  map[string]string{"name": name},
+	})
+	return nil
+}
+
+// CreateFolder creates a folder in one of the user's folders and returns
+// its identity.
+func (s *FileService) CreateFolder(ctx context.Context, userIdentity string, parentID int64, name string) (string, error) {
+	uuid, err := random.UUIdV4()
+	if err != nil {
+		return "", internal(err, "failed to generate UUID")
+	}
+	err = s.tx.Transaction(func(r Repositories) error {
+		if err := checkParent(ctx, r.Files, userIdentity, parentID); err != nil {
+			return err
+		}
+		err := r.Files.Create(ctx, &entity.UserRepository{
+			Identity:     uuid,
+			UserIdentity: userIdentity,
+			ParentID:     int32(parentID),
+			Name:         name,
+		})
+		if err != nil {
+			return nameConflictOr(err, "failed to create repository")
+		}
+		return nil
+	})
+	if err != nil {
+		return "", txError(err, "failed to create repository")
+	}
+	return uuid, nil
+}
+
+// Delete deletes an entry and, if it is a folder, everything below it.
+func (s *FileService) Delete(ctx context.Context, userIdentity, identity string) error {
+	var ur *entity.UserRepository
+	var descendants int64
+	err := s.tx.Transaction(func(r Repositories) error {
+		var err error
+		ur, err = r.Files.Find(ctx, userIdentity, identity)
+		if err != nil {
+			return notFoundOr(err, errno.FileNotFound, "file does not exist", "failed to query user repository")
+		}
+		if ur.RepositoryIdentity == "" {
+			folders, err := r.Files.Folders(ctx, userIdentity)
+			if err != nil {
+				return internal(err, "failed to query user folders")
+			}
+			descendants, err = r.Files.DeleteChildren(ctx, userIdentity, newFolderTree(folders).subtree(int32(ur.ID)))
+			if err != nil {
+				return internal(err, "failed to delete folder contents")
+			}
+		}
+		if err := r.Files.Delete(ctx, userIdentity, identity); err != nil {
+			return internal(err, "failed to delete user repository")
+		}
+		return nil
+	})
+	if err != nil {
+		return txError(err, "failed to delete user repository")
+	}
+	audit.Record(ctx, &audit.Event{
+		Action: audit.FileDelete,
+		Actor:  userIdentity,
+		Target: identity,
+		Before: map[string]interface{}{"name": ur.Name, "parent_id": ur.ParentID, "repository_identity": ur.RepositoryIdentity, "descendants": descendants},
+	})
+	return nil
+}
+
+// checkParent checks that the folder of the given ID, 0 for the root, is
+// one of the user's.
+func checkParent(ctx context.Context, files FileRepository, userIdentity string, parentID int64) error {
+	if parentID == 0 {
+		return nil
+	}
+	if parentID < 0 || parentID > math.MaxInt32 {
+		return errno.New(errno.FolderNotFound, "parent folder does not exist")
+	}
+	_, err := files.FindFolder(ctx, userIdentity, int32(parentID))
+	if err != nil {
+		return notFoundOr(err, errno.FolderNotFound, "parent folder does not exist", "failed to query parent folder")
+	}
+	return nil
+}
+
+// Move moves an entry into another folder, keeping names unique within it.
+// A folder cannot move into itself or a folder below it.
+func (s *FileService) Move(ctx context.Context, userIdentity, identity, parentIdentity string) error {
+	if identity == parentIdentity {
+		return errno.New(errno.InvalidArgument, "cannot move a folder into itself")
+	}
+	return s.move(ctx, userIdentity, identity, "", func(tree *folderTree) (int32, error) {
+		// The tree only holds folders, so files are no parents
+		parent, ok := tree.byIdentity[parentIdentity]
+		if !ok {
+			return 0, errno.New(errno.FolderNotFound, "parent folder does not exist")
+		}
+		return int32(parent.ID), nil
+	})
+}
+
+// MoveTo moves an entry into one of the user's folders, 0 for the root,
+// under a new name, keeping names unique within the folder. Files take the
+// extension of their new name. A folder cannot move into itself or a
+// folder below it.
+func (s *FileService) MoveTo(ctx context.Context, userIdentity, identity string, parentID int64, name string) error {
+	return s.move(ctx, userIdentity, identity, name, func(tree *folderTree) (int32, error) {
+		if parentID == 0 {
+			return 0, nil
+		}
+		if parentID < 0 || parentID > math.MaxInt32 || tree.byID[int32(parentID)] == nil {
+			return 0, errno.New(errno.FolderNotFound, "parent folder does not exist")
+		}
+		return int32(parentID), nil
+	})
+}
+
+// move moves an entry into the folder that parent picks from the user's
+// folders, and renames it to name unless empty.
+func (s *FileService) move(ctx context.Context, userIdentity, identity, name string, parent func(*folderTree) (int32, error)) error {
+	var ur *entity.UserRepository
+	var parentID int32
+	err := s.tx.Transaction(func(r Repositories) error {
+		var err error
+		ur, err = r.Files.Find(ctx, userIdentity, identity)
+		if err != nil {
+			return notFoundOr(err, errno.FileNotFound, "file does not exist", "failed to query user repository")
+		}
+		folders, err := r.Files.Folders(ctx, userIdentity)
+		if err != nil {
+			return internal(err, "failed to query user folders")
+		}
+		tree := newFolderTree(folders)
+		if parentID, err = parent(tree); err != nil {
+			return err
+		}
+		if ur.RepositoryIdentity == "" && slices.Contains(tree.subtree(int32(ur.ID)), parentID) {
+			return errno.New(errno.InvalidArgument, "cannot move a folder into itself or a folder below it")
+		}
+		if name == "" {
+			err = r.Files.UpdateParent(ctx, userIdentity, identity, parentID)
+		} else {
+			ext := ur.Ext
+			if ur.RepositoryIdentity != "" {
+				ext = path.Ext(name)
+			}
+			err = r.Files.UpdateLocation(ctx, userIdentity, identity, parentID, name, ext)
+		}
+		if err != nil {
+			return nameConflictOr(err, "failed to move user repository")
+		}
+		return nil
+	})
+	if err != nil {
+		return txError(err, "failed to move user repository")
+	}
+	action := audit.FileMove
+	before := map[string]interface{}{"parent_id": ur.ParentID}
+	after := map[string]interface{}{"parent_id": parentID}
+	if name != "" && name != ur.Name {
+		before["name"], after["name"] = ur.Name, name
+		if parentID == ur.ParentID {
+			action = audit.FileRename
+		}
+	}
+	audit.Record(ctx, &audit.Event{
+		Action: action,
+		Actor:  userIdentity,
+		Target: identity,
+		Before: before,
+		After:  after,
+	})
+	return nil
+}
+
+type SearchRequest struct {
+	Keyword string
+	// Match is "substring" (default) or "prefix"
+	Match string
+	Exts  []string
+	// Type is "file", "folder" or empty for both
+	Type           string
+	MinSize        int64
+	MaxSize        int64
+	ModifiedAfter  int64
+	ModifiedBefore int64
+	// ParentIdentity limits the search to the subtree of a folder
+	ParentIdentity string
+	// Sort is "name" (default), "size" or "updated_at"
+	Sort string
+	Desc bool
+	Page int32
+	Size int32
+}
+
+// SearchHit is a search result with the full path of the entry.
+type SearchHit struct {
+	*FileRow
+	FullPath string
+}
+
+// Search finds entries of the user's tree by name and attributes.
+func (s *FileService) Search(ctx context.Context, userIdentity string, req *SearchRequest) ([]*SearchHit, int64, error) {
+	if req.MinSize < 0 || req.MaxSize < 0 {
+		return nil, 0, errno.New(errno.InvalidArgument, "size bounds must not be negative")
+	}
+	if req.MaxSize > 0 && req.MinSize > req.MaxSize {
+		return nil, 0, errno.New(errno.InvalidArgument, "min_size must not exceed max_size")
+	}
+	offset, limit := page(req.Page, req.Size)
+
+	// Load the caller's folders once; they are needed for subtree scoping and for building full paths
+	tree, err := s.folderTree(ctx, userIdentity)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	filter := &FileFilter{
+		Keyword: req.Keyword,
+		Prefix:  req.Match == "prefix",
+		MinSize: req.MinSize,
+		MaxSize: req.MaxSize,
+		Sort:    req.Sort,
+		Desc:    req.Desc,
+	}
+	for _, ext := range req.Exts {
+		if ext != "" && !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		filter.Exts = append(filter.Exts, ext)
+	}
+	switch req.Type {
+	case "file":
+		filter.Files = true
+	case "folder":
+		filter.Folders = true
+	}
+	if req.ModifiedAfter > 0 {
+		filter.ModifiedAfter = time.Unix(req.ModifiedAfter, 0)
+	}
+	if req.ModifiedBefore > 0 {
+		filter.ModifiedBefore = time.Unix(req.ModifiedBefore, 0)
+	}
+	if req.ParentIdentity != "" {
+		root, ok := tree.byIdentity[req.ParentIdentity]
+		if !ok {
+			return nil, 0, errno.New(errno.FolderNotFound, "parent folder does not exist")
+		}
+		filter.ParentIDs = tree.subtree(int32(root.ID))
+	}
+
+	rows, count, err := s.files.Search(ctx, userIdentity, filter, offset, limit)
+	if err != nil {
+		return nil, 0, internal(err, "failed to search user repository")
+	}
+	hits := make([]*SearchHit, 0, len(rows))
+	for _, row := range rows {
+		hits = append(hits, &SearchHit{FileRow: row, FullPath: path.Join(tree.path(row.ParentID), row.Name)})
+	}
+	return hits, count, nil
+}
+
+type ContentSearchRequest struct {
+	Keyword string
+	Page    int32
+	Size    int32
+}
+
+// ContentSearchHit is a file whose content matched, with highlighted
+// fragments of the content.
+type ContentSearchHit struct {
+	*FileRow
+	FullPath string
+	Snippets []string
+}
+
+// ContentSearch finds the user's files by their content. The count is the
+// number of matching blobs.
+func (s *FileService) ContentSearch(ctx context.Context, userIdentity string, req *ContentSearchRequest) ([]*ContentSearchHit, int64, error) {
+	if req.Keyword == "" {
+		return nil, 0, errno.New(errno.InvalidArgument, "keyword is required")
+	}
+	offset, limit := page(req.Page, req.Size)
+
+	// Only blobs referenced by the caller's own files are searched
+	owned, err := s.files.ListFiles(ctx, userIdentity)
+	if err != nil {
+		return nil, 0, internal(err, "failed to query user repository")
+	}
+	byHash := make(map[string][]*FileRow)
+	hashes := make([]string, 0, len(owned))
+	for _, o := range owned {
+		if _, ok := byHash[o.Hash]; !ok {
+			hashes = append(hashes, o.Hash)
+		}
+		byHash[o.Hash] = append(byHash[o.Hash], o)
+	}
+
+	hits, total, err := fulltext.Search(req.Keyword, hashes, offset, limit)
+	if err != nil {
+		return nil, 0, internal(err, "failed to search file content")
+	}
+
+	tree, err := s.folderTree(ctx, userIdentity)
+	if err != nil {
+		return nil, 0, err
+	}
+	list := make([]*ContentSearchHit, 0, len(hits))
+	for _, hit := range hits {
+		for _, o := range byHash[hit.Hash] {
+			list = append(list, &ContentSearchHit{
+				FileRow:  o,
+				FullPath: path.Join(tree.path(o.ParentID), o.Name),
+				Snippets: hit.Fragments,
+			})
+		}
+	}
+	return list, int64(total), nil
+}
+
+// Download is the content of a file being downloaded.
+type Download struct {
+	Name    string
+	Size    int64
+	Content io.ReadCloser
+}
+
+// Open opens one of the user's files for reading. The caller closes the
+// content.
+func (s *FileService) Open(ctx context.Context, userIdentity, identity string) (*Download, error) {
+	ur, err := s.files.Find(ctx, userIdentity, identity)
+	if err != nil {
+		return nil, notFoundOr(err, errno.FileNotFound, "file does not exist", "failed to query user repository")
+	}
+	if ur.RepositoryIdentity == "" {
+		return nil, errno.New(errno.InvalidArgument, "not a file")
+	}
+	rp, err := s.blobs.FindByIdentity(ctx, ur.RepositoryIdentity)
+	if err != nil {
+		return nil, internal(err, "failed to query repository pool")
+	}
+	content, err := s.content.Open(ctx, rp)
+	if err != nil {
+		return nil, internal(err, "failed to open file")
+	}
+	return &Download{Name: ur.Name, Size: rp.Size, Content: content}, nil
+}
+
+func (s *FileService) folderTree(ctx context.Context, userIdentity string) (*folderTree, error) {
+	folders, err := s.files.Folders(ctx, userIdentity)
+	if err != nil {
+		return nil, internal(err, "failed to query user folders")
+	}
+	return newFolderTree(folders), nil
+}
+
+// folderTree indexes a user's folders so that paths and subtrees can be resolved without further queries.
+type folderTree struct {
+	byID       map[int32]*entity.UserRepository
+	byIdentity map[string]*entity.UserRepository
+	children   map[int32][]int32
+	paths      map[int32]string
+}
+
+func newFolderTree(folders []*entity.UserRepository) *folderTree {
+	t := &folderTree{
+		byID:       make(map[int32]*entity.UserRepository, len(folders)),
+		byIdentity: make(map[string]*entity.UserRepository, len(folders)),
+		children:   make(map[int32][]int32),
+		paths:      map[int32]string{0: "/"},
+	}
+	for _, f := range folders {
+		t.byID[int32(f.ID)] = f
+		t.byIdentity[f.Identity] = f
+		t.children[f.ParentID] = append(t.children[f.ParentID], int32(f.ID))
+	}
+	return t
+}
+
+// path returns the absolute path of the folder with the given ID; 0 is the root.
+func (t *folderTree) path(id int32) string {
+	if p, ok := t.paths[id]; ok {
+		return p
+	}
+	f, ok := t.byID[id]
+	if !ok {
+		return "/"
+	}
+	// Guard against cycles in corrupted data before recursing
+	t.paths[id] = "/"
+	p := path.Join(t.path(f.ParentID), f.Name)
+	t.paths[id] = p
+	return p
+}
+
+// subtree returns the ID of the folder and of all folders below it.
+func (t *folderTree) subtree(id int32) []int32 {
+	ids := []int32{id}
+	for i := 0; i < len(ids); i++ {
+		ids = append(ids, t.children[ids[i]]...)
+	}
+	return ids
+}
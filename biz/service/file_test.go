@@ -0,0 +1,174 @@
+package service_test
+
+import (
+	"cloud-storage/biz/config"
+	"cloud-storage/biz/dal"
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/dal/migrate"
+	"cloud-storage/biz/dal/query"
+	"cloud-storage/biz/dal/repository"
+	"cloud-storage/biz/errno"
+	"cloud-storage/biz/service"
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"gorm.io/gorm/logger"
+)
+
+const testUser = "user-1"
+
+// newRepositories returns the repositories of a new, migrated SQLite
+// database, which also backs the audit trail.
+func newRepositories(t *testing.T) service.Repositories {
+	t.Helper()
+	db, err := dal.Open(&config.Database{Driver: "sqlite", DSN: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Logger = logger.Discard
+	m, err := migrate.New(db, "sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Up(); err != nil {
+		t.Fatal(err)
+	}
+	query.SetDefault(db)
+	return repository.New(query.Q)
+}
+
+// createEntry adds an entry to the tree of testUser, a folder unless blob
+// is set, and returns it.
+func createEntry(t *testing.T, r service.Repositories, parent *entity.UserRepository, name, blob string) *entity.UserRepository {
+	t.Helper()
+	ur := &entity.UserRepository{
+		Identity:           name,
+		UserIdentity:       testUser,
+		RepositoryIdentity: blob,
+		Name:               name,
+	}
+	if parent != nil {
+		ur.ParentID = int32(parent.ID)
+	}
+	if err := r.Files.Create(context.Background(), ur); err != nil {
+		t.Fatal(err)
+	}
+	return ur
+}
+
+func TestFileServiceMove(t *testing.T) {
+	// a/b/c, a/file.txt, d/ and d/b
+	tests := []struct {
+		name, identity, parent string
+		want                   errno.Code
+	}{
+		{"file into folder", "file.txt", "d", ""},
+		{"folder into sibling", "b", "d-b", ""},
+		{"folder into itself", "a", "a", errno.InvalidArgument},
+		{"folder into child", "a", "b", errno.InvalidArgument},
+		{"folder into grandchild", "a", "c", errno.InvalidArgument},
+		{"into a file", "d", "file.txt", errno.FolderNotFound},
+		{"into nothing", "d", "missing", errno.FolderNotFound},
+		{"missing entry", "missing", "d", errno.FileNotFound},
+		{"name taken", "d-b", "a", errno.NameConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRepositories(t)
+			a := createEntry(t, r, nil, "a", "")
+			b := createEntry(t, r, a, "b", "")
+			createEntry(t, r, b, "c", "")
+			createEntry(t, r, a, "file.txt", "blob-1")
+			d := createEntry(t, r, nil, "d", "")
+			db := &entity.UserRepository{Identity: "d-b", UserIdentity: testUser, ParentID: int32(d.ID), Name: "b"}
+			if err := r.Files.Create(context.Background(), db); err != nil {
+				t.Fatal(err)
+			}
+			files := service.NewFileService(r.Files, r.Blobs, r.Users, r.Properties, r.Content, r.Tx, 0)
+			before, _ := r.Files.Find(context.Background(), testUser, tt.identity)
+
+			err := files.Move(context.Background(), testUser, tt.identity, tt.parent)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("Move: %v", err)
+				}
+				moved, err := r.Files.Find(context.Background(), testUser, tt.identity)
+				if err != nil {
+					t.Fatal(err)
+				}
+				parent, err := r.Files.Find(context.Background(), testUser, tt.parent)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if moved.ParentID != int32(parent.ID) {
+					t.Errorf("parent ID = %d, want %d", moved.ParentID, parent.ID)
+				}
+				return
+			}
+			if got := errno.CodeOf(err); err == nil || got != tt.want {
+				t.Fatalf("Move error = %v, want code %s", err, tt.want)
+			}
+			if before == nil {
+				return
+			}
+			after, err := r.Files.Find(context.Background(), testUser, tt.identity)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if after.ParentID != before.ParentID {
+				t.Errorf("%s moved to parent %d despite the error", tt.identity, after.ParentID)
+			}
+		})
+	}
+}
+
+func TestFileServiceLinkQuota(t *testing.T) {
+	// testUser has a quota of 100 bytes and uses 40 of them for a.txt
+	tests := []struct {
+		name, file, blob string
+		want             errno.Code
+	}{
+		{"new file within quota", "b.txt", "blob-60", ""},
+		{"new file over quota", "b.txt", "blob-70", errno.QuotaExceeded},
+		{"replacement frees the old content", "a.txt", "blob-70", ""},
+		{"replacement over quota", "a.txt", "blob-110", errno.QuotaExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRepositories(t)
+			ctx := context.Background()
+			if err := r.Users.Create(ctx, &entity.UserBasic{Identity: testUser, Name: testUser, Quota: 100}); err != nil {
+				t.Fatal(err)
+			}
+			for _, size := range []int64{40, 60, 70, 110} {
+				identity := fmt.Sprintf("blob-%d", size)
+				rp := &entity.RepositoryPool{Identity: identity, Hash: identity, Size: size}
+				if err := query.RepositoryPool.WithContext(ctx).Create(rp); err != nil {
+					t.Fatal(err)
+				}
+			}
+			createEntry(t, r, nil, "a.txt", "blob-40")
+			files := service.NewFileService(r.Files, r.Blobs, r.Users, r.Properties, r.Content, r.Tx, 0)
+
+			ur, err := files.Link(ctx, testUser, 0, tt.file, tt.blob)
+			if tt.want != "" {
+				if got := errno.CodeOf(err); err == nil || got != tt.want {
+					t.Fatalf("Link error = %v, want code %s", err, tt.want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Link: %v", err)
+			}
+			got, err := r.Files.FindChild(ctx, testUser, 0, tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Identity != ur.Identity || got.RepositoryIdentity != tt.blob {
+				t.Errorf("%s is %s with %s, want %s with %s", tt.file, got.Identity, got.RepositoryIdentity, ur.Identity, tt.blob)
+			}
+		})
+	}
+}
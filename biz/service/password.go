@@ -0,0 +1,175 @@
+package service
+
+import (
+	"cloud-storage/biz/audit"
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/errno"
+	"cloud-storage/biz/mail"
+	"context"
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// resetTokenLength is the length of the tokens of password reset
+	// links, drawn from the alphabet of personal access tokens.
+	resetTokenLength = 40
+	// mailTimeout bounds the sending of a mail.
+	mailTimeout = time.Minute
+)
+
+// PasswordReset tunes the links resetting forgotten passwords.
+type PasswordReset struct {
+	// URL is the page setting the new password, given the token in its
+	// token query parameter; the mail carries the bare token if empty
+	URL string
+	// TTL is how long a link works
+	TTL time.Duration
+}
+
+// RequestPasswordReset mails a link setting a new password to every active
+// account of an email address, in place of the links sent before. It tells
+// nothing of whether there are any: the links are made and sent in the
+// background, so that the answer is the same and as quick either way.
+func (s *UserService) RequestPasswordReset(ctx context.Context, email string) error {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return errno.New(errno.InvalidArgument, "email is required")
+	}
+	users, err := s.users.ListByEmail(ctx, email)
+	if err != nil {
+		return internal(err, "failed to query users")
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		sent := false
+		for _, userBasic := range users {
+			if userBasic.Disabled {
+				continue
+			}
+			sent = true
+			if err := s.sendPasswordReset(ctx, userBasic); err != nil {
+				log.ErrorContext(ctx, "failed to send password reset", "user", userBasic.Identity, "error", err)
+			}
+		}
+		if !sent {
+			audit.Record(ctx, &audit.Event{Action: audit.UserPasswordResetRequest, After: map[string]string{"email": email}})
+		}
+	}()
+	return nil
+}
+
+// sendPasswordReset makes a new password reset link of a user, dropping
+// those sent before, and mails it.
+func (s *UserService) sendPasswordReset(ctx context.Context, userBasic *entity.UserBasic) error {
+	token, err := randomString(apiTokenAlphabet, resetTokenLength)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	expiresAt := now.Add(s.passwordReset.TTL)
+	err = s.tx.Transaction(func(r Repositories) error {
+		if err := r.PasswordResets.DeleteByUser(ctx, userBasic.Identity); err != nil {
+			return err
+		}
+		return r.PasswordResets.Create(ctx, &entity.UserPasswordReset{
+			UserIdentity: userBasic.Identity,
+			TokenHash:    hashToken(token),
+			ExpiresAt:    expiresAt,
+			CreatedAt:    now,
+		})
+	})
+	if err != nil {
+		return err
+	}
+	audit.Record(ctx, &audit.Event{Action: audit.UserPasswordResetRequest, Target: userBasic.Identity, After: map[string]string{"email": userBasic.Email}})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Hello %s,\n\n", userBasic.Name)
+	b.WriteString("Someone, hopefully you, asked to reset the password of your account. ")
+	deadline := expiresAt.UTC().Format("2006-01-02 15:04 MST")
+	if s.passwordReset.URL == "" {
+		fmt.Fprintf(&b, "To choose a new one, give this token before %s:\n\n%s\n\n", deadline, token)
+	} else {
+		link, err := url.Parse(s.passwordReset.URL)
+		if err != nil {
+			return err
+		}
+		q := link.Query()
+		q.Set("token", token)
+		link.RawQuery = q.Encode()
+		fmt.Fprintf(&b, "To choose a new one, open this link before %s:\n\n%s\n\n", deadline, link)
+	}
+	b.WriteString("The new password signs you out everywhere and revokes your access tokens, S3 access keys and SSH keys. If you did not ask for it, ignore this mail and your password stays as it is.\n")
+
+	ctx, cancel := context.WithTimeout(ctx, mailTimeout)
+	defer cancel()
+	return s.mailer.Send(ctx, &mail.Message{To: userBasic.Email, Subject: "Reset your password", Body: b.String()})
+}
+
+// ResetPasswordWithToken sets a new password with the token of a reset
+// link, which works once and until it expires. Like any change of password,
+// it signs the user out everywhere. As whoever made the reset necessary
+// may have made credentials of their own, it also revokes the personal
+// access tokens, S3 access keys and SSH keys of the user.
+func (s *UserService) ResetPasswordWithToken(ctx context.Context, token, password string) error {
+	if password == "" {
+		return errno.New(errno.InvalidArgument, "password is required")
+	}
+	reset, err := s.passwordResets.FindByHash(ctx, hashToken(token))
+	if errors.Is(err, ErrRecordNotFound) {
+		return invalidResetToken()
+	}
+	if err != nil {
+		return internal(err, "failed to query password reset")
+	}
+	if !reset.UsedAt.IsZero() || !time.Now().Before(reset.ExpiresAt) {
+		return invalidResetToken()
+	}
+	userBasic, err := s.users.FindByIdentity(ctx, reset.UserIdentity)
+	if errors.Is(err, ErrRecordNotFound) {
+		return invalidResetToken()
+	}
+	if err != nil {
+		return internal(err, "failed to query user")
+	}
+	if userBasic.Disabled {
+		return errno.New(errno.AccountDisabled, "account is disabled")
+	}
+	err = s.tx.Transaction(func(r Repositories) error {
+		used, err := r.PasswordResets.Use(ctx, reset.ID)
+		if err != nil {
+			return err
+		}
+		// Used by a request racing this one
+		if !used {
+			return invalidResetToken()
+		}
+		if err := r.Users.UpdatePassword(ctx, userBasic.Identity, fmt.Sprintf("%x", md5.Sum([]byte(password)))); err != nil {
+			return err
+		}
+		if err := r.Sessions.DeleteByUser(ctx, userBasic.Identity); err != nil {
+			return err
+		}
+		if err := r.APITokens.RevokeByUser(ctx, userBasic.Identity); err != nil {
+			return err
+		}
+		if err := r.AccessKeys.DeleteByUser(ctx, userBasic.Identity); err != nil {
+			return err
+		}
+		return r.SSHKeys.DeleteByUser(ctx, userBasic.Identity)
+	})
+	if err != nil {
+		return txError(err, "failed to reset password")
+	}
+	audit.Record(ctx, &audit.Event{Action: audit.UserPasswordReset, Actor: userBasic.Identity, Target: userBasic.Identity})
+	return nil
+}
+
+func invalidResetToken() error {
+	return errno.New(errno.InvalidResetToken, "password reset link is invalid or has expired")
+}
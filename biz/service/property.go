@@ -0,0 +1,55 @@
+package service
+
+import (
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/errno"
+	"context"
+)
+
+// PropertyPatch sets a WebDAV dead property of an entry, or removes it.
+type PropertyPatch struct {
+	Space    string
+	Name     string
+	Lang     string
+	InnerXML string
+	Remove   bool
+}
+
+// Properties returns the WebDAV dead properties of an entry of the user.
+func (s *FileService) Properties(ctx context.Context, userIdentity, identity string) ([]*entity.UserRepositoryProperty, error) {
+	props, err := s.properties.ListByEntry(ctx, userIdentity, identity)
+	if err != nil {
+		return nil, internal(err, "failed to query properties")
+	}
+	return props, nil
+}
+
+// PatchProperties applies patches to the WebDAV dead properties of an entry
+// of the user, all of them or none.
+func (s *FileService) PatchProperties(ctx context.Context, userIdentity, identity string, patches []*PropertyPatch) error {
+	err := s.tx.Transaction(func(r Repositories) error {
+		if _, err := r.Files.Find(ctx, userIdentity, identity); err != nil {
+			return notFoundOr(err, errno.FileNotFound, "file does not exist", "failed to query user repository")
+		}
+		for _, p := range patches {
+			if err := r.Properties.Delete(ctx, identity, p.Space, p.Name); err != nil {
+				return internal(err, "failed to delete property")
+			}
+			if p.Remove {
+				continue
+			}
+			err := r.Properties.Create(ctx, &entity.UserRepositoryProperty{
+				UserRepositoryIdentity: identity,
+				Space:                  p.Space,
+				Name:                   p.Name,
+				Lang:                   p.Lang,
+				InnerXML:               p.InnerXML,
+			})
+			if err != nil {
+				return internal(err, "failed to create property")
+			}
+		}
+		return nil
+	})
+	return txError(err, "failed to update properties")
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"cloud-storage/biz/errno"
+	"context"
+)
+
+// quota limits the total size of the files of every user.
+type quota struct {
+	users UserRepository
+	files FileRepository
+	blobs BlobRepository
+	// userBytes is the limit of users without one of their own, 0 for none
+	userBytes int64
+}
+
+// check returns an error if linking the blob into the user's tree would
+// exceed the quota. replaced is the blob of a file the new one overwrites,
+// whose size is freed, empty for none.
+func (q *quota) check(ctx context.Context, userIdentity, repositoryIdentity, replaced string) error {
+	limit, err := q.limit(ctx, userIdentity)
+	if err != nil || limit <= 0 {
+		return err
+	}
+	rp, err := q.blobs.FindByIdentity(ctx, repositoryIdentity)
+	if err != nil {
+		return notFoundOr(err, errno.FileNotFound, "file does not exist", "failed to query repository pool")
+	}
+	used, err := q.files.UsedBytes(ctx, userIdentity)
+	if err != nil {
+		return internal(err, "failed to query used space")
+	}
+	if replaced != "" {
+		old, err := q.blobs.FindByIdentity(ctx, replaced)
+		if err != nil {
+			return notFoundOr(err, errno.FileNotFound, "file does not exist", "failed to query repository pool")
+		}
+		used -= old.Size
+	}
+	if used+rp.Size > limit {
+		return errno.New(errno.QuotaExceeded, "storage quota of %d bytes exceeded", limit)
+	}
+	return nil
+}
+
+// limit returns the quota of the user, 0 or less for none.
+func (q *quota) limit(ctx context.Context, userIdentity string) (int64, error) {
+	userBasic, err := q.users.FindByIdentity(ctx, userIdentity)
+	if err != nil {
+		return 0, notFoundOr(err, errno.UserNotFound, "user does not exist", "failed to query user")
+	}
+	if userBasic.Quota != 0 {
+		return userBasic.Quota, nil
+	}
+	return q.userBytes, nil
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"cloud-storage/biz/dal/entity"
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	// ErrRecordNotFound is returned by repositories when no record matches.
+	ErrRecordNotFound = errors.New("record not found")
+	// ErrDuplicate is returned by repositories when a write would break a
+	// unique constraint, e.g. a second entry of the same name in a folder.
+	ErrDuplicate = errors.New("duplicate record")
+)
+
+// Repositories are the storage the services are built on.
+type Repositories struct {
+	Users          UserRepository
+	RecoveryCodes  RecoveryCodeRepository
+	PasswordResets PasswordResetRepository
+	Sessions       SessionRepository
+	AccessKeys     AccessKeyRepository
+	APITokens      APITokenRepository
+	SSHKeys        SSHKeyRepository
+	Files          FileRepository
+	Blobs          BlobRepository
+	Shares         ShareRepository
+	Properties     PropertyRepository
+	Content        ContentStore
+	Tx             Transactor
+}
+
+// Transactor runs multi-step changes atomically.
+type Transactor interface {
+	// Transaction calls fn with repositories bound to a new transaction,
+	// which is committed if fn returns nil and rolled back otherwise.
+	Transaction(fn func(r Repositories) error) error
+}
+
+type UserRepository interface {
+	Create(ctx context.Context, u *entity.UserBasic) error
+	FindByIdentity(ctx context.Context, identity string) (*entity.UserBasic, error)
+	FindByName(ctx context.Context, name string) (*entity.UserBasic, error)
+	ListByEmail(ctx context.Context, email string) ([]*entity.UserBasic, error)
+	// UpdatePassword replaces the password of a user and records when, so
+	// that the logins made before stop working.
+	UpdatePassword(ctx context.Context, identity, passwordMD5 string) error
+	UpdateAdmin(ctx context.Context, identity string, admin bool) error
+	UpdateDisabled(ctx context.Context, identity string, disabled bool) error
+	UpdateQuota(ctx context.Context, identity string, quota int64) error
+	// IncrementFailedLogins counts a failed login and returns the number
+	// of failures in a row.
+	IncrementFailedLogins(ctx context.Context, identity string) (int32, error)
+	// Lock refuses password logins until the given time and resets the
+	// count of failures.
+	Lock(ctx context.Context, identity string, until time.Time) error
+	ResetFailedLogins(ctx context.Context, identity string) error
+	// UpdateTOTP sets the TOTP secret of a user, empty to remove it, and
+	// whether logins need a code of it.
+	UpdateTOTP(ctx context.Context, identity, secret string, enabled bool) error
+	// UseTOTPStep records that the code of a time step was used and
+	// reports false if that step, or a later one, already was, so that
+	// every code is accepted once.
+	UseTOTPStep(ctx context.Context, identity string, step int64) (bool, error)
+}
+
+// RecoveryCodeRepository stores the hashes of the codes that stand in for
+// TOTP codes when the authenticator is lost.
+type RecoveryCodeRepository interface {
+	// Replace drops the codes of a user for new ones.
+	Replace(ctx context.Context, userIdentity string, codeHashes []string) error
+	// Use marks a code as used and reports false if the user has no such
+	// unused code.
+	Use(ctx context.Context, userIdentity, codeHash string) (bool, error)
+	DeleteByUser(ctx context.Context, userIdentity string) error
+}
+
+// PasswordResetRepository stores the hashes of the tokens of password reset
+// links.
+type PasswordResetRepository interface {
+	Create(ctx context.Context, reset *entity.UserPasswordReset) error
+	FindByHash(ctx context.Context, tokenHash string) (*entity.UserPasswordReset, error)
+	// Use marks a token as used and reports false if it already was.
+	Use(ctx context.Context, id uint32) (bool, error)
+	DeleteByUser(ctx context.Context, userIdentity string) error
+}
+
+// SessionRepository stores the logins of users. Signing a session out
+// deletes it.
+type SessionRepository interface {
+	Create(ctx context.Context, session *entity.UserSession) error
+	Find(ctx context.Context, userIdentity, identity string) (*entity.UserSession, error)
+	// ListByUser returns the sessions of a user that did not expire by
+	// now, the last seen first.
+	ListByUser(ctx context.Context, userIdentity string, now time.Time) ([]*entity.UserSession, error)
+	// Touch records that a session was seen from ip at a time.
+	Touch(ctx context.Context, identity, ip string, at time.Time) error
+	// Delete reports false if the user has no such session.
+	Delete(ctx context.Context, userIdentity, identity string) (bool, error)
+	// DeleteOthers deletes the sessions of a user but one and returns how
+	// many there were.
+	DeleteOthers(ctx context.Context, userIdentity, keep string) (int64, error)
+	DeleteByUser(ctx context.Context, userIdentity string) error
+	// Extend moves the end of a session to expiresAt.
+	Extend(ctx context.Context, identity string, expiresAt time.Time) error
+	// DeleteExpired deletes the sessions of a user that expired by now.
+	DeleteExpired(ctx context.Context, userIdentity string, now time.Time) error
+}
+
+type AccessKeyRepository interface {
+	Create(ctx context.Context, key *entity.UserAccessKey) error
+	FindByID(ctx context.Context, accessKeyID string) (*entity.UserAccessKey, error)
+	ListByUser(ctx context.Context, userIdentity string) ([]*entity.UserAccessKey, error)
+	Delete(ctx context.Context, userIdentity, accessKeyID string) error
+	DeleteByUser(ctx context.Context, userIdentity string) error
+}
+
+// APITokenRepository stores personal access tokens, by the hash of the
+// token; the token itself is never stored.
+type APITokenRepository interface {
+	Create(ctx context.Context, token *entity.UserAPIToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*entity.UserAPIToken, error)
+	// ListByUser returns the tokens of a user that are not revoked,
+	// expired ones included.
+	ListByUser(ctx context.Context, userIdentity string) ([]*entity.UserAPIToken, error)
+	// Revoke revokes a token of a user and reports false if the user has
+	// no such token that is not revoked yet.
+	Revoke(ctx context.Context, userIdentity, identity string) (bool, error)
+	// RevokeByUser revokes every token of a user that is not revoked yet.
+	RevokeByUser(ctx context.Context, userIdentity string) error
+	// Touch records that a token was used at a time from a client IP.
+	Touch(ctx context.Context, identity, ip string, at time.Time) error
+}
+
+type SSHKeyRepository interface {
+	Create(ctx context.Context, key *entity.UserSSHKey) error
+	Find(ctx context.Context, userIdentity, fingerprint string) (*entity.UserSSHKey, error)
+	CountByFingerprint(ctx context.Context, fingerprint string) (int64, error)
+	ListByUser(ctx context.Context, userIdentity string) ([]*entity.UserSSHKey, error)
+	Delete(ctx context.Context, userIdentity, fingerprint string) error
+	DeleteByUser(ctx context.Context, userIdentity string) error
+}
+
+// FileRepository stores the files and folders of the users' trees. All
+// methods are scoped to the tree of one user. Names are unique within a
+// folder; Create, UpdateName, UpdateParent and UpdateLocation return
+// ErrDuplicate when they would repeat one.
+type FileRepository interface {
+	Create(ctx context.Context, ur *entity.UserRepository) error
+	Find(ctx context.Context, userIdentity, identity string) (*entity.UserRepository, error)
+	// FindFolder returns a folder by its ID.
+	FindFolder(ctx context.Context, userIdentity string, id int32) (*entity.UserRepository, error)
+	// FindChild returns the entry called name in a folder.
+	FindChild(ctx context.Context, userIdentity string, parentID int32, name string) (*entity.UserRepository, error)
+	// ListChildren returns a page of the entries of a folder and the total
+	// number of entries.
+	ListChildren(ctx context.Context, userIdentity string, parentID int32, offset, limit int) ([]*FileRow, int64, error)
+	// ListByParents returns the entries of the folders of the given IDs,
+	// ordered by name.
+	ListByParents(ctx context.Context, userIdentity string, parentIDs []int32) ([]*entity.UserRepository, error)
+	// ListFiles returns every file, but no folder, with its blob hash.
+	ListFiles(ctx context.Context, userIdentity string) ([]*FileRow, error)
+	Folders(ctx context.Context, userIdentity string) ([]*entity.UserRepository, error)
+	// Search returns a page of the entries matching filter and the total
+	// number of matches.
+	Search(ctx context.Context, userIdentity string, filter *FileFilter, offset, limit int) ([]*FileRow, int64, error)
+	UpdateName(ctx context.Context, userIdentity, identity, name string) error
+	UpdateParent(ctx context.Context, userIdentity, identity string, parentID int32) error
+	// UpdateLocation moves an entry into a folder under a new name at once.
+	UpdateLocation(ctx context.Context, userIdentity, identity string, parentID int32, name, ext string) error
+	// UpdateContent points a file at another blob.
+	UpdateContent(ctx context.Context, userIdentity, identity, repositoryIdentity string) error
+	Delete(ctx context.Context, userIdentity, identity string) error
+	// DeleteChildren deletes the entries of the folders of the given IDs
+	// and returns how many there were.
+	DeleteChildren(ctx context.Context, userIdentity string, parentIDs []int32) (int64, error)
+	// UsedBytes returns the total size of the user's files.
+	UsedBytes(ctx context.Context, userIdentity string) (int64, error)
+}
+
+// FileRow is an entry of a user's tree with the details of its blob, which
+// are zero for folders.
+type FileRow struct {
+	ID                 uint32
+	Identity           string
+	ParentID           int32
+	RepositoryIdentity string
+	Name               string
+	Ext                string
+	UpdatedAt          time.Time
+	Size               int64
+	Path               string
+	Hash               string
+}
+
+// FileFilter narrows a FileRepository search. Zero fields do not filter.
+type FileFilter struct {
+	// Keyword matches a substring of the name, or its start with Prefix
+	Keyword        string
+	Prefix         bool
+	Exts           []string
+	Folders        bool
+	Files          bool
+	MinSize        int64
+	MaxSize        int64
+	ModifiedAfter  time.Time
+	ModifiedBefore time.Time
+	ParentIDs      []int32
+	// Sort is one of "name", "size" and "updated_at"
+	Sort string
+	Desc bool
+}
+
+// BlobRepository stores the records of the repository pool.
+type BlobRepository interface {
+	FindByIdentity(ctx context.Context, identity string) (*entity.RepositoryPool, error)
+	FindByIdentities(ctx context.Context, identities []string) ([]*entity.RepositoryPool, error)
+	FindByHash(ctx context.Context, hash string) (*entity.RepositoryPool, error)
+	// Touch marks a blob as used now, which keeps it from being collected
+	// before the file referring to it is saved.
+	Touch(ctx context.Context, identity string) error
+}
+
+// ContentStore keeps the content of the repository pool.
+type ContentStore interface {
+	// Put stores content under a new pool record, or returns the existing
+	// record of identical content.
+	Put(ctx context.Context, name string, r io.Reader) (*entity.RepositoryPool, error)
+	Open(ctx context.Context, rp *entity.RepositoryPool) (io.ReadCloser, error)
+}
+
+type ShareRepository interface {
+	Create(ctx context.Context, sb *entity.ShareBasic) error
+	IncrementClickNum(ctx context.Context, identity string) error
+	Detail(ctx context.Context, identity string) (*ShareDetail, error)
+	// Delete reports false if the user has no such share.
+	Delete(ctx context.Context, userIdentity, identity string) (bool, error)
+}
+
+// PropertyRepository stores the WebDAV dead properties of the entries of
+// the users' trees, by entry identity.
+type PropertyRepository interface {
+	// ListByEntry returns the properties of an entry of the user's tree.
+	ListByEntry(ctx context.Context, userIdentity, entryIdentity string) ([]*entity.UserRepositoryProperty, error)
+	Create(ctx context.Context, prop *entity.UserRepositoryProperty) error
+	Delete(ctx context.Context, entryIdentity, space, name string) error
+}
+
+// ShareDetail is a share joined with the blob it shares.
+type ShareDetail struct {
+	CreatedAt          time.Time
+	ExpiredTime        int32
+	RepositoryIdentity string
+	Name               string
+	Ext                string
+	Size               int64
+	Path               string
+}
@@ -0,0 +1,75 @@
+// Package service holds the business logic behind the HTTP, gRPC and other
+// front ends. Services take plain request values and the identity of the
+// calling user, reach storage through repository interfaces and report
+// failures as *Error.
+package service
+
+import (
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/logging"
+	"cloud-storage/biz/mail"
+	"time"
+)
+
+var log = logging.Logger("service")
+
+// TokenIssuer issues a token of a user who logged in.
+type TokenIssuer func(u *entity.UserBasic) (string, error)
+
+// AccessTokenIssuer issues the access token of a user who logged in, which
+// stands for the session of the given identity, and returns when the
+// session ends unless the token is refreshed.
+type AccessTokenIssuer func(u *entity.UserBasic, session string) (string, time.Time, error)
+
+// Tokens issue the tokens of logins.
+type Tokens struct {
+	// Access issues the access token of a user who logged in
+	Access AccessTokenIssuer
+	// Challenge issues the short-lived token of a user who gave their
+	// password but still owes the second factor
+	Challenge TokenIssuer
+}
+
+// The default services, set up by Init.
+var (
+	Users   *UserService
+	Files   *FileService
+	Shares  *ShareService
+	Uploads *UploadService
+)
+
+// Options are the settings of the default services.
+type Options struct {
+	Tokens Tokens
+	// UserQuota limits the total size of the files of users without a
+	// quota of their own, 0 for no limit
+	UserQuota int64
+	// Lockout locks accounts after failed logins
+	Lockout Lockout
+	// TOTPIssuer names the server in authenticator apps
+	TOTPIssuer string
+	// Mailer sends mail to users
+	Mailer mail.Mailer
+	// PasswordReset tunes the links resetting forgotten passwords
+	PasswordReset PasswordReset
+}
+
+// Init sets up the default services on r.
+func Init(r Repositories, o Options) {
+	Users = NewUserService(r.Users, r.RecoveryCodes, r.PasswordResets, r.Sessions, r.AccessKeys, r.APITokens, r.SSHKeys, r.Tx, o.Tokens, o.Lockout, o.TOTPIssuer, o.Mailer, o.PasswordReset)
+	Files = NewFileService(r.Files, r.Blobs, r.Users, r.Properties, r.Content, r.Tx, o.UserQuota)
+	Shares = NewShareService(r.Shares, r.Files, r.Blobs, r.Users, r.Tx, o.UserQuota)
+	Uploads = NewUploadService(r.Blobs, r.Content)
+}
+
+// page turns a 1-based page number and page size into an offset and limit,
+// defaulting to the first page of 10.
+func page(page, size int32) (int, int) {
+	if size <= 0 {
+		size = 10
+	}
+	if page <= 0 {
+		page = 1
+	}
+	return int((page - 1) * size), int(size)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"cloud-storage/biz/audit"
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/errno"
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// deviceNameLength bounds the names users give their devices.
+	deviceNameLength = 60
+	// userAgentLength bounds the user agents recorded of sessions.
+	userAgentLength = 255
+	// sessionTouchInterval is how often the last use of a session used
+	// over and over from the same address is recorded.
+	sessionTouchInterval = time.Minute
+)
+
+// ListSessions returns the sessions of a user that have not expired, the
+// most recently seen first.
+func (s *UserService) ListSessions(ctx context.Context, userIdentity string) ([]*entity.UserSession, error) {
+	sessions, err := s.sessions.ListByUser(ctx, userIdentity, time.Now())
+	if err != nil {
+		return nil, internal(err, "failed to query sessions")
+	}
+	return sessions, nil
+}
+
+// RevokeSession signs a session of a user out: its access token stops
+// working at once.
+func (s *UserService) RevokeSession(ctx context.Context, userIdentity, identity string) error {
+	deleted, err := s.sessions.Delete(ctx, userIdentity, identity)
+	if err != nil {
+		return internal(err, "failed to revoke session")
+	}
+	if !deleted {
+		return errno.New(errno.SessionNotFound, "session does not exist")
+	}
+	audit.Record(ctx, &audit.Event{Action: audit.SessionRevoke, Actor: userIdentity, Target: identity})
+	return nil
+}
+
+// RevokeOtherSessions signs every session of a user out but current, the
+// one asking, and returns how many there were.
+func (s *UserService) RevokeOtherSessions(ctx context.Context, userIdentity, current string) (int64, error) {
+	count, err := s.sessions.DeleteOthers(ctx, userIdentity, current)
+	if err != nil {
+		return 0, internal(err, "failed to revoke sessions")
+	}
+	audit.Record(ctx, &audit.Event{Action: audit.SessionRevokeOthers, Actor: userIdentity, Target: userIdentity, After: map[string]int64{"count": count}})
+	return count, nil
+}
+
+// RefreshSession extends a session of a user, whose access token issued at
+// issuedAt was refreshed, to expiresAt, once CheckLogin finds the token
+// still grants access.
+func (s *UserService) RefreshSession(ctx context.Context, userIdentity, sessionIdentity string, issuedAt, expiresAt time.Time) error {
+	if err := s.CheckLogin(ctx, userIdentity, sessionIdentity, issuedAt); err != nil {
+		return err
+	}
+	if err := s.sessions.Extend(ctx, sessionIdentity, expiresAt); err != nil {
+		return internal(err, "failed to update session")
+	}
+	audit.Record(ctx, &audit.Event{Action: audit.UserTokenRefresh, Actor: userIdentity, Target: userIdentity, After: map[string]string{"session": sessionIdentity}})
+	return nil
+}
+
+// touchSession checks that a session of a user was not signed out and
+// records its use from the client of ctx.
+func (s *UserService) touchSession(ctx context.Context, userIdentity, identity string) error {
+	if identity == "" {
+		return errno.New(errno.Unauthenticated, "login predates sessions, sign in again")
+	}
+	session, err := s.sessions.Find(ctx, userIdentity, identity)
+	if errors.Is(err, ErrRecordNotFound) {
+		return errno.New(errno.Unauthenticated, "session was signed out")
+	}
+	if err != nil {
+		return internal(err, "failed to query session")
+	}
+	ip, _ := audit.Client(ctx)
+	if ip == "" {
+		ip = session.IP
+	}
+	now := time.Now()
+	if session.IP != ip || now.Sub(session.LastSeenAt) >= sessionTouchInterval {
+		if err := s.sessions.Touch(ctx, identity, ip, now); err != nil {
+			return internal(err, "failed to update session")
+		}
+	}
+	return nil
+}
+
+// checkDeviceName returns the trimmed name of a device logging in.
+func checkDeviceName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if len(name) > deviceNameLength || !utf8.ValidString(name) {
+		return "", errno.New(errno.InvalidArgument, "device name must be valid UTF-8 and at most %d bytes long", deviceNameLength)
+	}
+	return name, nil
+}
+
+// truncate returns the first n bytes at most of s, not splitting any
+// UTF-8 sequence.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"cloud-storage/biz/audit"
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/errno"
+	"context"
+	"time"
+
+	"github.com/duke-git/lancet/v2/random"
+)
+
+// ShareService manages links sharing files with other users.
+type ShareService struct {
+	shares ShareRepository
+	files  FileRepository
+	blobs  BlobRepository
+	tx     Transactor
+	quota  *quota
+}
+
+func NewShareService(shares ShareRepository, files FileRepository, blobs BlobRepository, users UserRepository, tx Transactor, userQuota int64) *ShareService {
+	return &ShareService{shares: shares, files: files, blobs: blobs, tx: tx, quota: &quota{users: users, files: files, blobs: blobs, userBytes: userQuota}}
+}
+
+// Detail returns what a share links to and counts the visit.
+func (s *ShareService) Detail(ctx context.Context, identity string) (*ShareDetail, error) {
+	var detail *ShareDetail
+	err := s.tx.Transaction(func(r Repositories) error {
+		var err error
+		detail, err = r.Shares.Detail(ctx, identity)
+		if err != nil {
+			return notFoundOr(err, errno.ShareNotFound, "share does not exist", "failed to query share")
+		}
+		if detail.ExpiredTime > 0 && time.Since(detail.CreatedAt) > time.Duration(detail.ExpiredTime)*time.Second {
+			return errno.New(errno.ShareExpired, "share has expired")
+		}
+		if err := r.Shares.IncrementClickNum(ctx, identity); err != nil {
+			return internal(err, "failed to update share")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, txError(err, "failed to query share")
+	}
+	return detail, nil
+}
+
+type ShareCreateRequest struct {
+	UserRepositoryIdentity string
+	// ExpiredTime is in seconds, 0 for never
+	ExpiredTime int32
+}
+
+// Create shares one of the user's files and returns the share identity.
+func (s *ShareService) Create(ctx context.Context, userIdentity string, req *ShareCreateRequest) (string, error) {
+	ur, err := s.files.Find(ctx, userIdentity, req.UserRepositoryIdentity)
+	if err != nil {
+		return "", notFoundOr(err, errno.FileNotFound, "file does not exist", "failed to query user repository")
+	}
+	uuid, err := random.UUIdV4()
+	if err != nil {
+		return "", internal(err, "failed to generate UUID")
+	}
+	err = s.shares.Create(ctx, &entity.ShareBasic{
+		Identity:               uuid,
+		UserIdentity:           userIdentity,
+		RepositoryIdentity:     ur.RepositoryIdentity,
+		UserRepositoryIdentity: req.UserRepositoryIdentity,
+		ExpiredTime:            req.ExpiredTime,
+		ClickNum:               0,
+	})
+	if err != nil {
+		return "", internal(err, "failed to create share")
+	}
+	audit.Record(ctx, &audit.Event{
+		Action: audit.ShareCreate,
+		Actor:  userIdentity,
+		Target: uuid,
+		After:  map[string]interface{}{"user_repository_identity": req.UserRepositoryIdentity, "expired_time": req.ExpiredTime},
+	})
+	return uuid, nil
+}
+
+// Revoke withdraws a share of the user; its link stops working at once.
+func (s *ShareService) Revoke(ctx context.Context, userIdentity, identity string) error {
+	deleted, err := s.shares.Delete(ctx, userIdentity, identity)
+	if err != nil {
+		return internal(err, "failed to revoke share")
+	}
+	if !deleted {
+		return errno.New(errno.ShareNotFound, "share does not exist")
+	}
+	audit.Record(ctx, &audit.Event{Action: audit.ShareRevoke, Actor: userIdentity, Target: identity})
+	return nil
+}
+
+type ShareSaveRequest struct {
+	RepositoryIdentity string
+	ParentID           int64
+}
+
+// Save copies a shared blob into the user's tree and returns the identity
+// of the new file.
+func (s *ShareService) Save(ctx context.Context, userIdentity string, req *ShareSaveRequest) (string, error) {
+	rp, err := s.blobs.FindByIdentity(ctx, req.RepositoryIdentity)
+	if err != nil {
+		return "", notFoundOr(err, errno.FileNotFound, "file does not exist", "failed to query repository pool")
+	}
+	if err := s.quota.check(ctx, userIdentity, req.RepositoryIdentity, ""); err != nil {
+		return "", err
+	}
+	uuid, err := random.UUIdV4()
+	if err != nil {
+		return "", internal(err, "failed to generate UUID")
+	}
+	err = s.tx.Transaction(func(r Repositories) error {
+		if err := checkParent(ctx, r.Files, userIdentity, req.ParentID); err != nil {
+			return err
+		}
+		err := r.Files.Create(ctx, &entity.UserRepository{
+			Identity:           uuid,
+			UserIdentity:       userIdentity,
+			ParentID:           int32(req.ParentID),
+			RepositoryIdentity: req.RepositoryIdentity,
+			Ext:                rp.Ext,
+			Name:               rp.Name,
+		})
+		if err != nil {
+			return nameConflictOr(err, "failed to create repository")
+		}
+		return nil
+	})
+	if err != nil {
+		return "", txError(err, "failed to create repository")
+	}
+	return uuid, nil
+}
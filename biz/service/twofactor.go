@@ -0,0 +1,256 @@
+package service
+
+import (
+	"cloud-storage/biz/audit"
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/errno"
+	"cloud-storage/biz/totp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// recoveryCodeCount is how many recovery codes a user gets at a time.
+	recoveryCodeCount = 10
+	// recoveryCodeAlphabet leaves out the letters and digits easily
+	// mistaken for one another.
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+)
+
+// TOTPEnrollment is the secret of an authenticator app being set up, as
+// text and as the otpauth URI of a QR code.
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// LoginSecondFactor completes the login of the user given by the challenge
+// token of Login with a TOTP or recovery code and returns a new access
+// token. Wrong codes count as failed logins.
+func (s *UserService) LoginSecondFactor(ctx context.Context, userIdentity, code, deviceName string) (string, error) {
+	deviceName, err := checkDeviceName(deviceName)
+	if err != nil {
+		return "", err
+	}
+	userBasic, err := s.users.FindByIdentity(ctx, userIdentity)
+	if errors.Is(err, ErrRecordNotFound) {
+		return "", errno.New(errno.Unauthenticated, "login challenge is no longer valid")
+	}
+	if err != nil {
+		return "", internal(err, "failed to query user")
+	}
+	if wait := time.Until(userBasic.LockedUntil); wait > 0 {
+		s.loginFailed(ctx, userBasic.Name, userBasic.Identity, "account locked")
+		e := errno.New(errno.AccountLocked, "account is locked after too many failed logins")
+		e.RetryAfter = wait
+		return "", e
+	}
+	if userBasic.Disabled {
+		s.loginFailed(ctx, userBasic.Name, userBasic.Identity, "account disabled")
+		return "", errno.New(errno.AccountDisabled, "account is disabled")
+	}
+	if !userBasic.TotpEnabled {
+		return "", errno.New(errno.Unauthenticated, "login challenge is no longer valid")
+	}
+	factor, err := s.checkCode(ctx, userBasic, code)
+	if err != nil {
+		return "", err
+	}
+	return s.completeLogin(ctx, userBasic, factor, deviceName)
+}
+
+// checkCode checks a TOTP or recovery code of a user with two-factor
+// authentication, using it up, and returns which of the two it was. Wrong
+// codes are audited and counted as failed logins.
+func (s *UserService) checkCode(ctx context.Context, userBasic *entity.UserBasic, code string) (string, error) {
+	if step, ok := totp.Validate(userBasic.TotpSecret, code, time.Now()); ok {
+		fresh, err := s.users.UseTOTPStep(ctx, userBasic.Identity, step)
+		if err != nil {
+			return "", internal(err, "failed to update user")
+		}
+		// A code seen before may have been seen by someone else too
+		if fresh {
+			return "totp", nil
+		}
+	} else if normalized := normalizeRecoveryCode(code); len(normalized) == 8 {
+		used, err := s.recoveryCodes.Use(ctx, userBasic.Identity, hashRecoveryCode(normalized))
+		if err != nil {
+			return "", internal(err, "failed to update recovery codes")
+		}
+		if used {
+			return "recovery_code", nil
+		}
+	}
+	s.loginFailed(ctx, userBasic.Name, userBasic.Identity, "invalid two-factor code")
+	if err := s.countFailure(ctx, userBasic); err != nil {
+		return "", err
+	}
+	return "", errno.New(errno.InvalidTwoFactor, "invalid two-factor code")
+}
+
+// EnrollTOTP starts turning on two-factor authentication with a new TOTP
+// secret for the user to add to an authenticator app. Logins only need
+// codes once one is confirmed with EnableTOTP.
+func (s *UserService) EnrollTOTP(ctx context.Context, userIdentity string) (*TOTPEnrollment, error) {
+	userBasic, err := s.users.FindByIdentity(ctx, userIdentity)
+	if err != nil {
+		return nil, notFoundOr(err, errno.UserNotFound, "user does not exist", "failed to query user")
+	}
+	if userBasic.TotpEnabled {
+		return nil, errno.New(errno.TwoFactorEnabled, "two-factor authentication is already enabled")
+	}
+	secret, err := totp.NewSecret()
+	if err != nil {
+		return nil, internal(err, "failed to generate TOTP secret")
+	}
+	if err := s.users.UpdateTOTP(ctx, userIdentity, secret, false); err != nil {
+		return nil, internal(err, "failed to update user")
+	}
+	return &TOTPEnrollment{Secret: secret, URI: totp.URI(s.totpIssuer, userBasic.Name, secret)}, nil
+}
+
+// EnableTOTP turns on two-factor authentication once the user proves with
+// a code that their authenticator app has the secret of EnrollTOTP, and
+// returns their recovery codes, which are only ever shown here and by
+// RegenerateRecoveryCodes.
+func (s *UserService) EnableTOTP(ctx context.Context, userIdentity, code string) ([]string, error) {
+	userBasic, err := s.users.FindByIdentity(ctx, userIdentity)
+	if err != nil {
+		return nil, notFoundOr(err, errno.UserNotFound, "user does not exist", "failed to query user")
+	}
+	if userBasic.TotpEnabled {
+		return nil, errno.New(errno.TwoFactorEnabled, "two-factor authentication is already enabled")
+	}
+	if userBasic.TotpSecret == "" {
+		return nil, errno.New(errno.TwoFactorDisabled, "no authenticator is being enrolled")
+	}
+	step, ok := totp.Validate(userBasic.TotpSecret, code, time.Now())
+	if !ok {
+		return nil, errno.New(errno.InvalidTwoFactor, "invalid two-factor code")
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, internal(err, "failed to generate recovery codes")
+	}
+	err = s.tx.Transaction(func(r Repositories) error {
+		if _, err := r.Users.UseTOTPStep(ctx, userIdentity, step); err != nil {
+			return err
+		}
+		if err := r.RecoveryCodes.Replace(ctx, userIdentity, hashes); err != nil {
+			return err
+		}
+		return r.Users.UpdateTOTP(ctx, userIdentity, userBasic.TotpSecret, true)
+	})
+	if err != nil {
+		return nil, internal(err, "failed to enable two-factor authentication")
+	}
+	audit.Record(ctx, &audit.Event{Action: audit.UserTwoFactorEnable, Actor: userIdentity, Target: userIdentity})
+	return codes, nil
+}
+
+// DisableTOTP turns off two-factor authentication, given a current TOTP or
+// recovery code, and drops the recovery codes.
+func (s *UserService) DisableTOTP(ctx context.Context, userIdentity, code string) error {
+	userBasic, err := s.enabledUser(ctx, userIdentity)
+	if err != nil {
+		return err
+	}
+	if _, err := s.checkCode(ctx, userBasic, code); err != nil {
+		return err
+	}
+	if err := s.removeTOTP(ctx, userIdentity); err != nil {
+		return err
+	}
+	audit.Record(ctx, &audit.Event{Action: audit.UserTwoFactorDisable, Actor: userIdentity, Target: userIdentity})
+	return nil
+}
+
+// ResetTwoFactor turns off the two-factor authentication of a user who
+// lost both their authenticator and their recovery codes.
+func (s *UserService) ResetTwoFactor(ctx context.Context, userIdentity string) error {
+	if _, err := s.enabledUser(ctx, userIdentity); err != nil {
+		return err
+	}
+	if err := s.removeTOTP(ctx, userIdentity); err != nil {
+		return err
+	}
+	audit.Record(ctx, &audit.Event{Action: audit.UserTwoFactorDisable, Target: userIdentity})
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of a user, given a
+// current TOTP or recovery code, and returns the new ones.
+func (s *UserService) RegenerateRecoveryCodes(ctx context.Context, userIdentity, code string) ([]string, error) {
+	userBasic, err := s.enabledUser(ctx, userIdentity)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.checkCode(ctx, userBasic, code); err != nil {
+		return nil, err
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, internal(err, "failed to generate recovery codes")
+	}
+	if err := s.recoveryCodes.Replace(ctx, userIdentity, hashes); err != nil {
+		return nil, internal(err, "failed to update recovery codes")
+	}
+	audit.Record(ctx, &audit.Event{Action: audit.UserRecoveryCodes, Actor: userIdentity, Target: userIdentity})
+	return codes, nil
+}
+
+// enabledUser returns a user with two-factor authentication.
+func (s *UserService) enabledUser(ctx context.Context, userIdentity string) (*entity.UserBasic, error) {
+	userBasic, err := s.users.FindByIdentity(ctx, userIdentity)
+	if err != nil {
+		return nil, notFoundOr(err, errno.UserNotFound, "user does not exist", "failed to query user")
+	}
+	if !userBasic.TotpEnabled {
+		return nil, errno.New(errno.TwoFactorDisabled, "two-factor authentication is not enabled")
+	}
+	return userBasic, nil
+}
+
+func (s *UserService) removeTOTP(ctx context.Context, userIdentity string) error {
+	err := s.tx.Transaction(func(r Repositories) error {
+		if err := r.RecoveryCodes.DeleteByUser(ctx, userIdentity); err != nil {
+			return err
+		}
+		return r.Users.UpdateTOTP(ctx, userIdentity, "", false)
+	})
+	if err != nil {
+		return internal(err, "failed to disable two-factor authentication")
+	}
+	return nil
+}
+
+// newRecoveryCodes returns a new set of recovery codes, formatted as
+// xxxx-xxxx, and their hashes to store.
+func newRecoveryCodes() (codes, hashes []string, err error) {
+	codes = make([]string, recoveryCodeCount)
+	hashes = make([]string, recoveryCodeCount)
+	for i := range codes {
+		code, err := randomString(recoveryCodeAlphabet, 8)
+		if err != nil {
+			return nil, nil, err
+		}
+		codes[i] = code[:4] + "-" + code[4:]
+		hashes[i] = hashRecoveryCode(code)
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode undoes the formatting of a recovery code and the
+// case and spacing users may type it in.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+func hashRecoveryCode(normalized string) string {
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
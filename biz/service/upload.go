@@ -0,0 +1,44 @@
+package service
+
+import (
+	"cloud-storage/biz/dal/entity"
+	"context"
+	"errors"
+	"io"
+)
+
+// UploadService stores uploaded content in the repository pool.
+type UploadService struct {
+	blobs   BlobRepository
+	content ContentStore
+}
+
+func NewUploadService(blobs BlobRepository, content ContentStore) *UploadService {
+	return &UploadService{blobs: blobs, content: content}
+}
+
+// Prepare returns the identity of the blob with the given MD5 hash, or an
+// empty string if the content has to be uploaded.
+func (s *UploadService) Prepare(ctx context.Context, md5 string) (string, error) {
+	rp, err := s.blobs.FindByHash(ctx, md5)
+	if errors.Is(err, ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", internal(err, "failed to query repository pool")
+	}
+	if err := s.blobs.Touch(ctx, rp.Identity); err != nil {
+		return "", internal(err, "failed to update repository pool")
+	}
+	return rp.Identity, nil
+}
+
+// Upload stores content read from r, which is reused if the pool already
+// holds it.
+func (s *UploadService) Upload(ctx context.Context, name string, r io.Reader) (*entity.RepositoryPool, error) {
+	rp, err := s.content.Put(ctx, name, r)
+	if err != nil {
+		return nil, internal(err, "failed to save file")
+	}
+	return rp, nil
+}
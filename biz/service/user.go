@@ -0,0 +1,552 @@
+package service
+
+import (
+	"cloud-storage/biz/audit"
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/errno"
+	"cloud-storage/biz/mail"
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/duke-git/lancet/v2/random"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	accessKeyIDAlphabet     = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	secretAccessKeyAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+)
+
+// UserService manages accounts and their credentials.
+type UserService struct {
+	users          UserRepository
+	recoveryCodes  RecoveryCodeRepository
+	passwordResets PasswordResetRepository
+	sessions       SessionRepository
+	accessKeys     AccessKeyRepository
+	apiTokens      APITokenRepository
+	sshKeys        SSHKeyRepository
+	tx             Transactor
+	tokens         Tokens
+	lockout        Lockout
+	totpIssuer     string
+	mailer         mail.Mailer
+	passwordReset  PasswordReset
+}
+
+// Lockout locks accounts after Threshold failed logins in a row for
+// Duration. A zero Lockout never locks them.
+type Lockout struct {
+	Threshold int
+	Duration  time.Duration
+}
+
+// NewUserService returns the service of accounts. totpIssuer names the
+// server in the authenticator apps of users turning on two-factor
+// authentication; mailer sends them the links of password resets.
+func NewUserService(users UserRepository, recoveryCodes RecoveryCodeRepository, passwordResets PasswordResetRepository, sessions SessionRepository, accessKeys AccessKeyRepository, apiTokens APITokenRepository, sshKeys SSHKeyRepository, tx Transactor, tokens Tokens, lockout Lockout, totpIssuer string, mailer mail.Mailer, passwordReset PasswordReset) *UserService {
+	return &UserService{
+		users:          users,
+		recoveryCodes:  recoveryCodes,
+		passwordResets: passwordResets,
+		sessions:       sessions,
+		accessKeys:     accessKeys,
+		apiTokens:      apiTokens,
+		sshKeys:        sshKeys,
+		tx:             tx,
+		tokens:         tokens,
+		lockout:        lockout,
+		totpIssuer:     totpIssuer,
+		mailer:         mailer,
+		passwordReset:  passwordReset,
+	}
+}
+
+// LoginResult is the outcome of a password login: an access token, or, for
+// users with two-factor authentication, a challenge token to complete the
+// login with LoginSecondFactor.
+type LoginResult struct {
+	Token     string
+	Challenge string
+}
+
+// Login checks a user's name and password and returns a new access token,
+// or a challenge if the user also needs to give a TOTP or recovery code.
+// deviceName, if any, names the device in the list of sessions.
+func (s *UserService) Login(ctx context.Context, name, password, deviceName string) (*LoginResult, error) {
+	deviceName, err := checkDeviceName(deviceName)
+	if err != nil {
+		return nil, err
+	}
+	userBasic, err := s.checkPassword(ctx, name, password)
+	if err != nil {
+		return nil, err
+	}
+	if userBasic.TotpEnabled {
+		// Failures are only forgiven once the second factor is given too
+		challenge, err := s.tokens.Challenge(userBasic)
+		if err != nil {
+			return nil, internal(err, "failed to generate token")
+		}
+		return &LoginResult{Challenge: challenge}, nil
+	}
+	token, err := s.completeLogin(ctx, userBasic, "password", deviceName)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResult{Token: token}, nil
+}
+
+// completeLogin starts a session of a user who gave every factor needed,
+// factor being the last one, and issues its access token.
+func (s *UserService) completeLogin(ctx context.Context, userBasic *entity.UserBasic, factor, deviceName string) (string, error) {
+	if err := s.resetFailures(ctx, userBasic); err != nil {
+		return "", err
+	}
+	sessionIdentity, err := random.UUIdV4()
+	if err != nil {
+		return "", internal(err, "failed to generate UUID")
+	}
+	token, expiresAt, err := s.tokens.Access(userBasic, sessionIdentity)
+	if err != nil {
+		return "", internal(err, "failed to generate token")
+	}
+	now := time.Now()
+	if err := s.sessions.DeleteExpired(ctx, userBasic.Identity, now); err != nil {
+		return "", internal(err, "failed to delete expired sessions")
+	}
+	ip, userAgent := audit.Client(ctx)
+	err = s.sessions.Create(ctx, &entity.UserSession{
+		Identity:     sessionIdentity,
+		UserIdentity: userBasic.Identity,
+		DeviceName:   deviceName,
+		UserAgent:    truncate(userAgent, userAgentLength),
+		IP:           ip,
+		LastSeenAt:   now,
+		ExpiresAt:    expiresAt,
+		CreatedAt:    now,
+	})
+	if err != nil {
+		return "", internal(err, "failed to create session")
+	}
+	audit.Record(ctx, &audit.Event{Action: audit.UserLogin, Actor: userBasic.Identity, Target: userBasic.Identity, After: map[string]string{"factor": factor, "session": sessionIdentity}})
+	return token, nil
+}
+
+// Authenticate checks a user's name and password for any front end that
+// logs users in with them and returns the user. Users with two-factor
+// authentication are refused, as these front ends cannot ask for a code.
+func (s *UserService) Authenticate(ctx context.Context, name, password string) (*entity.UserBasic, error) {
+	userBasic, err := s.checkPassword(ctx, name, password)
+	if err != nil {
+		return nil, err
+	}
+	if userBasic.TotpEnabled {
+		s.loginFailed(ctx, name, userBasic.Identity, "two-factor authentication required")
+		return nil, errno.New(errno.TwoFactorRequired, "account requires two-factor authentication")
+	}
+	if err := s.resetFailures(ctx, userBasic); err != nil {
+		return nil, err
+	}
+	return userBasic, nil
+}
+
+// checkPassword checks a user's name and password and returns the user.
+// Failures are audited and, with a lockout, counted: once too many are in
+// a row, the account is locked for a while, during which even the right
+// password is refused.
+func (s *UserService) checkPassword(ctx context.Context, name, password string) (*entity.UserBasic, error) {
+	userBasic, err := s.users.FindByName(ctx, name)
+	if errors.Is(err, ErrRecordNotFound) {
+		s.loginFailed(ctx, name, "", "invalid credentials")
+		return nil, errno.New(errno.InvalidCredentials, "username or password error")
+	}
+	if err != nil {
+		return nil, internal(err, "failed to query user")
+	}
+	if wait := time.Until(userBasic.LockedUntil); wait > 0 {
+		s.loginFailed(ctx, name, userBasic.Identity, "account locked")
+		e := errno.New(errno.AccountLocked, "account is locked after too many failed logins")
+		e.RetryAfter = wait
+		return nil, e
+	}
+	pwdMd5 := fmt.Sprintf("%x", md5.Sum([]byte(password)))
+	if subtle.ConstantTimeCompare([]byte(pwdMd5), []byte(userBasic.Password)) != 1 {
+		s.loginFailed(ctx, name, userBasic.Identity, "invalid credentials")
+		if err := s.countFailure(ctx, userBasic); err != nil {
+			return nil, err
+		}
+		return nil, errno.New(errno.InvalidCredentials, "username or password error")
+	}
+	if userBasic.Disabled {
+		s.loginFailed(ctx, name, userBasic.Identity, "account disabled")
+		return nil, errno.New(errno.AccountDisabled, "account is disabled")
+	}
+	return userBasic, nil
+}
+
+// resetFailures forgives the failed logins of a user who logged in.
+func (s *UserService) resetFailures(ctx context.Context, userBasic *entity.UserBasic) error {
+	if userBasic.FailedLogins == 0 {
+		return nil
+	}
+	if err := s.users.ResetFailedLogins(ctx, userBasic.Identity); err != nil {
+		return internal(err, "failed to update user")
+	}
+	return nil
+}
+
+// countFailure counts a failed login of a user, locking the account when
+// the failures in a row reach the threshold of the lockout.
+func (s *UserService) countFailure(ctx context.Context, userBasic *entity.UserBasic) error {
+	if s.lockout.Threshold <= 0 {
+		return nil
+	}
+	failures, err := s.users.IncrementFailedLogins(ctx, userBasic.Identity)
+	if err != nil {
+		return internal(err, "failed to update user")
+	}
+	if int(failures) < s.lockout.Threshold {
+		return nil
+	}
+	until := time.Now().Add(s.lockout.Duration)
+	if err := s.users.Lock(ctx, userBasic.Identity, until); err != nil {
+		return internal(err, "failed to lock user")
+	}
+	audit.Record(ctx, &audit.Event{
+		Action: audit.UserLocked,
+		Target: userBasic.Identity,
+		After:  map[string]interface{}{"failures": failures, "until": until.UTC()},
+	})
+	return nil
+}
+
+// loginFailed audits a failed login as an attempt on the user called name,
+// whose identity is empty if there is none.
+func (s *UserService) loginFailed(ctx context.Context, name, identity, reason string) {
+	audit.Record(ctx, &audit.Event{
+		Action: audit.UserLoginFailed,
+		Target: identity,
+		After:  map[string]string{"name": name, "reason": reason},
+	})
+}
+
+// IsAdmin reports whether the user is an administrator whose account is
+// not disabled.
+func (s *UserService) IsAdmin(ctx context.Context, userIdentity string) (bool, error) {
+	userBasic, err := s.users.FindByIdentity(ctx, userIdentity)
+	if errors.Is(err, ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, internal(err, "failed to query user")
+	}
+	return userBasic.Admin && !userBasic.Disabled, nil
+}
+
+// IsActive reports whether the user exists and is not disabled, so that
+// credentials issued before still grant access.
+func (s *UserService) IsActive(ctx context.Context, userIdentity string) (bool, error) {
+	userBasic, err := s.users.FindByIdentity(ctx, userIdentity)
+	if errors.Is(err, ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, internal(err, "failed to query user")
+	}
+	return !userBasic.Disabled, nil
+}
+
+// CheckLogin checks that the access token of a session of a user, issued
+// at issuedAt, still grants access: the account exists and is not disabled,
+// the session was not signed out and the password was not changed since,
+// which signs out every session. The session is seen to be in use.
+func (s *UserService) CheckLogin(ctx context.Context, userIdentity, sessionIdentity string, issuedAt time.Time) error {
+	userBasic, err := s.users.FindByIdentity(ctx, userIdentity)
+	if errors.Is(err, ErrRecordNotFound) {
+		return errno.New(errno.Unauthenticated, "login is no longer valid")
+	}
+	if err != nil {
+		return internal(err, "failed to query user")
+	}
+	if userBasic.Disabled {
+		return errno.New(errno.AccountDisabled, "account is disabled")
+	}
+	if issuedAt.Unix() < userBasic.PasswordChangedAt.Unix() {
+		return errno.New(errno.Unauthenticated, "login was signed out by a change of password")
+	}
+	return s.touchSession(ctx, userIdentity, sessionIdentity)
+}
+
+// Find returns the user with the given name.
+func (s *UserService) Find(ctx context.Context, name string) (*entity.UserBasic, error) {
+	userBasic, err := s.users.FindByName(ctx, name)
+	if err != nil {
+		return nil, notFoundOr(err, errno.UserNotFound, "user does not exist", "failed to query user")
+	}
+	return userBasic, nil
+}
+
+type UserCreateRequest struct {
+	Name     string
+	Password string
+	Email    string
+	Admin    bool
+}
+
+// Create adds a user. Names are unique.
+func (s *UserService) Create(ctx context.Context, req *UserCreateRequest) (*entity.UserBasic, error) {
+	if req.Name == "" || req.Password == "" {
+		return nil, errno.New(errno.InvalidArgument, "name and password are required")
+	}
+	_, err := s.users.FindByName(ctx, req.Name)
+	if err == nil {
+		return nil, errno.New(errno.NameConflict, "user name is taken")
+	}
+	if !errors.Is(err, ErrRecordNotFound) {
+		return nil, internal(err, "failed to query user")
+	}
+	uuid, err := random.UUIdV4()
+	if err != nil {
+		return nil, internal(err, "failed to generate UUID")
+	}
+	userBasic := &entity.UserBasic{
+		Identity: uuid,
+		Name:     req.Name,
+		Password: fmt.Sprintf("%x", md5.Sum([]byte(req.Password))),
+		Email:    req.Email,
+		Admin:    req.Admin,
+	}
+	if err := s.users.Create(ctx, userBasic); err != nil {
+		return nil, internal(err, "failed to create user")
+	}
+	audit.Record(ctx, &audit.Event{
+		Action: audit.UserCreate,
+		Target: userBasic.Identity,
+		After:  map[string]interface{}{"name": userBasic.Name, "email": userBasic.Email, "admin": userBasic.Admin},
+	})
+	return userBasic, nil
+}
+
+// ResetPassword replaces the password of a user, signing them out
+// everywhere.
+func (s *UserService) ResetPassword(ctx context.Context, userIdentity, password string) error {
+	if password == "" {
+		return errno.New(errno.InvalidArgument, "password is required")
+	}
+	err := s.tx.Transaction(func(r Repositories) error {
+		if err := r.Users.UpdatePassword(ctx, userIdentity, fmt.Sprintf("%x", md5.Sum([]byte(password)))); err != nil {
+			return err
+		}
+		return r.Sessions.DeleteByUser(ctx, userIdentity)
+	})
+	if err != nil {
+		return internal(err, "failed to update password")
+	}
+	audit.Record(ctx, &audit.Event{Action: audit.UserPassword, Target: userIdentity})
+	return nil
+}
+
+// SetAdmin grants or revokes administrator rights.
+func (s *UserService) SetAdmin(ctx context.Context, userIdentity string, admin bool) error {
+	userBasic, err := s.users.FindByIdentity(ctx, userIdentity)
+	if err != nil {
+		return notFoundOr(err, errno.UserNotFound, "user does not exist", "failed to query user")
+	}
+	if err := s.users.UpdateAdmin(ctx, userIdentity, admin); err != nil {
+		return internal(err, "failed to update user")
+	}
+	audit.Record(ctx, &audit.Event{Action: audit.UserAdmin, Target: userIdentity, Before: userBasic.Admin, After: admin})
+	return nil
+}
+
+// SetDisabled disables or re-enables an account. Disabled users can neither
+// log in nor use the tokens and keys they hold.
+func (s *UserService) SetDisabled(ctx context.Context, userIdentity string, disabled bool) error {
+	userBasic, err := s.users.FindByIdentity(ctx, userIdentity)
+	if err != nil {
+		return notFoundOr(err, errno.UserNotFound, "user does not exist", "failed to query user")
+	}
+	if err := s.users.UpdateDisabled(ctx, userIdentity, disabled); err != nil {
+		return internal(err, "failed to update user")
+	}
+	audit.Record(ctx, &audit.Event{Action: audit.UserDisabled, Target: userIdentity, Before: userBasic.Disabled, After: disabled})
+	return nil
+}
+
+// SetQuota sets the storage quota of a user in bytes, 0 for the default
+// quota and a negative value for none.
+func (s *UserService) SetQuota(ctx context.Context, userIdentity string, quota int64) error {
+	userBasic, err := s.users.FindByIdentity(ctx, userIdentity)
+	if err != nil {
+		return notFoundOr(err, errno.UserNotFound, "user does not exist", "failed to query user")
+	}
+	if err := s.users.UpdateQuota(ctx, userIdentity, quota); err != nil {
+		return internal(err, "failed to update user")
+	}
+	audit.Record(ctx, &audit.Event{Action: audit.UserQuota, Target: userIdentity, Before: userBasic.Quota, After: quota})
+	return nil
+}
+
+// AccessKey is a newly created S3 access key. The secret is only ever
+// returned on creation.
+type AccessKey struct {
+	ID     string
+	Secret string
+}
+
+func (s *UserService) CreateAccessKey(ctx context.Context, userIdentity string) (*AccessKey, error) {
+	accessKeyID, err := randomString(accessKeyIDAlphabet, 20)
+	if err != nil {
+		return nil, internal(err, "failed to generate access key")
+	}
+	secretAccessKey, err := randomString(secretAccessKeyAlphabet, 40)
+	if err != nil {
+		return nil, internal(err, "failed to generate access key")
+	}
+	uuid, err := random.UUIdV4()
+	if err != nil {
+		return nil, internal(err, "failed to generate UUID")
+	}
+	err = s.accessKeys.Create(ctx, &entity.UserAccessKey{
+		Identity:        uuid,
+		UserIdentity:    userIdentity,
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+	})
+	if err != nil {
+		return nil, internal(err, "failed to create access key")
+	}
+	audit.Record(ctx, &audit.Event{Action: audit.AccessKeyCreate, Actor: userIdentity, Target: accessKeyID})
+	return &AccessKey{ID: accessKeyID, Secret: secretAccessKey}, nil
+}
+
+// AccessKey returns the S3 access key of the given ID, as long as its user
+// may still use it.
+func (s *UserService) AccessKey(ctx context.Context, accessKeyID string) (*entity.UserAccessKey, error) {
+	key, err := s.accessKeys.FindByID(ctx, accessKeyID)
+	if err != nil {
+		return nil, notFoundOr(err, errno.InvalidCredentials, "access key does not exist", "failed to query access key")
+	}
+	active, err := s.IsActive(ctx, key.UserIdentity)
+	if err != nil {
+		return nil, err
+	}
+	if !active {
+		return nil, errno.New(errno.AccountDisabled, "account is disabled")
+	}
+	return key, nil
+}
+
+func (s *UserService) ListAccessKeys(ctx context.Context, userIdentity string) ([]*entity.UserAccessKey, error) {
+	keys, err := s.accessKeys.ListByUser(ctx, userIdentity)
+	if err != nil {
+		return nil, internal(err, "failed to query access keys")
+	}
+	return keys, nil
+}
+
+func (s *UserService) DeleteAccessKey(ctx context.Context, userIdentity, accessKeyID string) error {
+	if err := s.accessKeys.Delete(ctx, userIdentity, accessKeyID); err != nil {
+		return internal(err, "failed to delete access key")
+	}
+	audit.Record(ctx, &audit.Event{Action: audit.AccessKeyDelete, Actor: userIdentity, Target: accessKeyID})
+	return nil
+}
+
+type SSHKeyCreateRequest struct {
+	// Name defaults to the comment of the key
+	Name string
+	// PublicKey is in authorized_keys format
+	PublicKey string
+}
+
+// CreateSSHKey registers a public key for SFTP login and returns its
+// SHA256 fingerprint. A key can only belong to one user.
+func (s *UserService) CreateSSHKey(ctx context.Context, userIdentity string, req *SSHKeyCreateRequest) (string, error) {
+	publicKey, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(req.PublicKey))
+	if err != nil {
+		return "", errno.Wrap(errno.InvalidArgument, err, "invalid public key")
+	}
+	fingerprint := ssh.FingerprintSHA256(publicKey)
+	cnt, err := s.sshKeys.CountByFingerprint(ctx, fingerprint)
+	if err != nil {
+		return "", internal(err, "failed to query ssh keys")
+	}
+	if cnt > 0 {
+		return "", errno.New(errno.SSHKeyConflict, "ssh key already registered")
+	}
+	name := req.Name
+	if name == "" {
+		name = comment
+	}
+	uuid, err := random.UUIdV4()
+	if err != nil {
+		return "", internal(err, "failed to generate UUID")
+	}
+	err = s.sshKeys.Create(ctx, &entity.UserSSHKey{
+		Identity:     uuid,
+		UserIdentity: userIdentity,
+		Name:         name,
+		PublicKey:    strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey))),
+		Fingerprint:  fingerprint,
+	})
+	if err != nil {
+		return "", internal(err, "failed to create ssh key")
+	}
+	audit.Record(ctx, &audit.Event{Action: audit.SSHKeyCreate, Actor: userIdentity, Target: fingerprint, After: map[string]string{"name": name}})
+	return fingerprint, nil
+}
+
+// AuthenticateKey checks that the user called name registered the SSH
+// public key of the given fingerprint and returns the user.
+func (s *UserService) AuthenticateKey(ctx context.Context, name, fingerprint string) (*entity.UserBasic, error) {
+	userBasic, err := s.users.FindByName(ctx, name)
+	if err != nil {
+		return nil, notFoundOr(err, errno.InvalidCredentials, "unknown public key", "failed to query user")
+	}
+	if userBasic.Disabled {
+		return nil, errno.New(errno.AccountDisabled, "account is disabled")
+	}
+	if _, err := s.sshKeys.Find(ctx, userBasic.Identity, fingerprint); err != nil {
+		return nil, notFoundOr(err, errno.InvalidCredentials, "unknown public key", "failed to query ssh keys")
+	}
+	return userBasic, nil
+}
+
+func (s *UserService) ListSSHKeys(ctx context.Context, userIdentity string) ([]*entity.UserSSHKey, error) {
+	keys, err := s.sshKeys.ListByUser(ctx, userIdentity)
+	if err != nil {
+		return nil, internal(err, "failed to query ssh keys")
+	}
+	return keys, nil
+}
+
+func (s *UserService) DeleteSSHKey(ctx context.Context, userIdentity, fingerprint string) error {
+	if err := s.sshKeys.Delete(ctx, userIdentity, fingerprint); err != nil {
+		return internal(err, "failed to delete ssh key")
+	}
+	audit.Record(ctx, &audit.Event{Action: audit.SSHKeyDelete, Actor: userIdentity, Target: fingerprint})
+	return nil
+}
+
+// randomString returns n characters drawn uniformly from alphabet using crypto/rand.
+func randomString(alphabet string, n int) (string, error) {
+	b := make([]byte, n)
+	max := big.NewInt(int64(len(alphabet)))
+	for i := range b {
+		idx, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = alphabet[idx.Int64()]
+	}
+	return string(b), nil
+}
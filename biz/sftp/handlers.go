@@ -0,0 +1,215 @@
+package sftp
+
+import (
+	"cloud-storage/biz/blob"
+	"cloud-storage/biz/vfs"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/pkg/sftp"
+)
+
+// handlers maps SFTP requests onto a user's vfs.FS.
+type handlers struct {
+	fs *vfs.FS
+}
+
+func (h *handlers) Fileread(r *sftp.Request) (io.ReaderAt, error) {
+	e, err := h.fs.Stat(r.Filepath)
+	if err != nil {
+		return nil, err
+	}
+	if e.IsDir() {
+		return nil, vfs.ErrIsDir
+	}
+	return blob.Open(h.fs.Context(), e.Pool)
+}
+
+// Filewrite buffers written content in a temporary file and stores it once
+// the handle is closed. Unless the file is truncated, writes apply on top of
+// the current content.
+func (h *handlers) Filewrite(r *sftp.Request) (io.WriterAt, error) {
+	e, err := h.fs.Stat(r.Filepath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	flags := r.Pflags()
+	if e != nil && e.IsDir() {
+		return nil, vfs.ErrIsDir
+	}
+	if e != nil && flags.Creat && flags.Excl {
+		return nil, fs.ErrExist
+	}
+	if e == nil && !flags.Creat {
+		return nil, fs.ErrNotExist
+	}
+
+	tmp, err := os.CreateTemp("", "cloud-storage-sftp-*")
+	if err != nil {
+		return nil, err
+	}
+	w := &writeFile{fs: h.fs, name: r.Filepath, tmp: tmp}
+	if e != nil && !flags.Trunc {
+		if err := w.load(e); err != nil {
+			w.discard()
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+func (h *handlers) Filecmd(r *sftp.Request) error {
+	switch r.Method {
+	case "Setstat":
+		// Content is immutable once pooled and modes and times are not
+		// stored, so attribute changes are accepted and ignored
+		_, err := h.fs.Stat(r.Filepath)
+		return err
+	case "Rename":
+		return h.fs.Rename(r.Filepath, r.Target)
+	case "Mkdir":
+		_, err := h.fs.Mkdir(r.Filepath)
+		return err
+	case "Rmdir":
+		e, err := h.fs.Stat(r.Filepath)
+		if err != nil {
+			return err
+		}
+		if !e.IsDir() {
+			return vfs.ErrNotDir
+		}
+		entries, err := h.fs.ReadDir(e)
+		if err != nil {
+			return err
+		}
+		if len(entries) > 0 {
+			return sftp.ErrSSHFxFailure
+		}
+		return h.fs.Remove(r.Filepath)
+	case "Remove":
+		e, err := h.fs.Stat(r.Filepath)
+		if err != nil {
+			return err
+		}
+		if e.IsDir() {
+			return vfs.ErrIsDir
+		}
+		return h.fs.Remove(r.Filepath)
+	case "Link":
+		e, err := h.fs.Stat(r.Filepath)
+		if err != nil {
+			return err
+		}
+		if e.IsDir() {
+			return vfs.ErrIsDir
+		}
+		if _, err := h.fs.Stat(r.Target); err == nil {
+			return fs.ErrExist
+		}
+		_, err = h.fs.Link(r.Target, e.Pool)
+		return quotaError(err)
+	}
+	return sftp.ErrSSHFxOpUnsupported
+}
+
+// PosixRename renames like rename(2), replacing an existing file at the
+// destination.
+func (h *handlers) PosixRename(r *sftp.Request) error {
+	dst, err := h.fs.Stat(r.Target)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if dst != nil {
+		if dst.IsDir() {
+			return vfs.ErrIsDir
+		}
+		if err := h.fs.Remove(r.Target); err != nil {
+			return err
+		}
+	}
+	return h.fs.Rename(r.Filepath, r.Target)
+}
+
+func (h *handlers) Filelist(r *sftp.Request) (sftp.ListerAt, error) {
+	e, err := h.fs.Stat(r.Filepath)
+	if err != nil {
+		return nil, err
+	}
+	switch r.Method {
+	case "List":
+		entries, err := h.fs.ReadDir(e)
+		if err != nil {
+			return nil, err
+		}
+		infos := make(listerAt, 0, len(entries))
+		for _, e := range entries {
+			infos = append(infos, e.Info())
+		}
+		return infos, nil
+	case "Stat":
+		return listerAt{e.Info()}, nil
+	}
+	return nil, sftp.ErrSSHFxOpUnsupported
+}
+
+type listerAt []fs.FileInfo
+
+func (l listerAt) ListAt(ls []fs.FileInfo, offset int64) (int, error) {
+	if offset >= int64(len(l)) {
+		return 0, io.EOF
+	}
+	n := copy(ls, l[offset:])
+	if n < len(ls) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// writeFile collects the content of an upload in a temporary file.
+type writeFile struct {
+	fs   *vfs.FS
+	name string
+	tmp  *os.File
+}
+
+func (w *writeFile) WriteAt(p []byte, off int64) (int, error) {
+	return w.tmp.WriteAt(p, off)
+}
+
+// load copies the current content of e into the temporary file.
+func (w *writeFile) load(e *vfs.Entry) error {
+	file, err := blob.Open(w.fs.Context(), e.Pool)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	// Copied within the server, so not a download
+	_, err = io.Copy(w.tmp, file.File)
+	return err
+}
+
+func (w *writeFile) Close() error {
+	defer w.discard()
+	if _, err := w.tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	_, err := w.fs.Put(w.name, w.tmp)
+	return quotaError(err)
+}
+
+// quotaError reports running out of storage quota as SSH_FX_FAILURE, since
+// SFTP has no status for a full disk before version 6.
+func quotaError(err error) error {
+	if errors.Is(err, vfs.ErrQuotaExceeded) {
+		return fmt.Errorf("%w: %w", sftp.ErrSSHFxFailure, err)
+	}
+	return err
+}
+
+func (w *writeFile) discard() {
+	w.tmp.Close()
+	os.Remove(w.tmp.Name())
+}
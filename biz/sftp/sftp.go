@@ -0,0 +1,242 @@
+package sftp
+
+import (
+	"cloud-storage/biz/audit"
+	"cloud-storage/biz/errno"
+	"cloud-storage/biz/logging"
+	"cloud-storage/biz/ratelimit"
+	"cloud-storage/biz/service"
+	"cloud-storage/biz/vfs"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+// HostKeyPath is where the server's host key is kept. A new ed25519 key is
+// generated there on first start. It is set from the configuration at
+// startup.
+var HostKeyPath string
+
+const identityExtension = "identity"
+
+var log = logging.Logger("sftp")
+
+// The listener and connections of the server, kept for Shutdown.
+var (
+	mu       sync.Mutex
+	listener net.Listener
+	closing  bool
+	conns    = map[net.Conn]struct{}{}
+	sessions sync.WaitGroup
+)
+
+// ListenAndServe accepts SFTP connections on addr until Shutdown, when it
+// returns nil. Users log in with their account name and either their
+// password or one of their registered SSH public keys, and see their own
+// file tree.
+func ListenAndServe(addr string) error {
+	config := &ssh.ServerConfig{
+		PasswordCallback:  passwordCallback,
+		PublicKeyCallback: publicKeyCallback,
+	}
+	hostKey, err := loadHostKey()
+	if err != nil {
+		return err
+	}
+	config.AddHostKey(hostKey)
+
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	mu.Lock()
+	if closing {
+		mu.Unlock()
+		l.Close()
+		return nil
+	}
+	listener = l
+	mu.Unlock()
+	log.Info("SFTP server listening", "addr", addr)
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			mu.Lock()
+			defer mu.Unlock()
+			if closing {
+				return nil
+			}
+			return err
+		}
+		mu.Lock()
+		if closing {
+			mu.Unlock()
+			conn.Close()
+			continue
+		}
+		conns[conn] = struct{}{}
+		sessions.Add(1)
+		mu.Unlock()
+		go func() {
+			defer func() {
+				mu.Lock()
+				delete(conns, conn)
+				mu.Unlock()
+				sessions.Done()
+			}()
+			serveConn(conn, config)
+		}()
+	}
+}
+
+// Shutdown stops accepting connections and waits for the sessions in
+// progress to end until ctx is done, when it closes the rest.
+func Shutdown(ctx context.Context) {
+	mu.Lock()
+	closing = true
+	if listener != nil {
+		listener.Close()
+	}
+	mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		sessions.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		mu.Lock()
+		for conn := range conns {
+			conn.Close()
+		}
+		mu.Unlock()
+	}
+}
+
+func passwordCallback(meta ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+	ctx := clientContext(meta)
+	login := ratelimit.Login{IP: clientHost(meta), Name: meta.User()}
+	retryAfter, err := login.Check(ctx)
+	if err != nil {
+		log.WarnContext(ctx, "failed to check rate limit", "error", err)
+	}
+	if retryAfter > 0 {
+		return nil, errors.New("too many failed logins")
+	}
+	userBasic, err := service.Users.Authenticate(ctx, meta.User(), string(password))
+	if errno.CodeOf(err) == errno.InvalidCredentials {
+		if err := login.Fail(ctx); err != nil {
+			log.WarnContext(ctx, "failed to count failed login", "error", err)
+		}
+	}
+	if err != nil {
+		return nil, errors.New("invalid name or password")
+	}
+	return &ssh.Permissions{Extensions: map[string]string{identityExtension: userBasic.Identity}}, nil
+}
+
+func publicKeyCallback(meta ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+	userBasic, err := service.Users.AuthenticateKey(clientContext(meta), meta.User(), ssh.FingerprintSHA256(key))
+	if err != nil {
+		return nil, errors.New("unknown public key")
+	}
+	return &ssh.Permissions{Extensions: map[string]string{identityExtension: userBasic.Identity}}, nil
+}
+
+// clientContext returns a context whose audited actions are recorded as
+// made by the client of a connection.
+func clientContext(meta ssh.ConnMetadata) context.Context {
+	return audit.WithClient(context.Background(), clientHost(meta), string(meta.ClientVersion()))
+}
+
+// clientHost returns the IP address of the client of a connection.
+func clientHost(meta ssh.ConnMetadata) string {
+	host, _, _ := net.SplitHostPort(meta.RemoteAddr().String())
+	return host
+}
+
+func serveConn(conn net.Conn, config *ssh.ServerConfig) {
+	defer conn.Close()
+	sconn, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		log.Debug("handshake failed", "client", conn.RemoteAddr().String(), "error", err)
+		return
+	}
+	defer sconn.Close()
+	go ssh.DiscardRequests(reqs)
+
+	identity := sconn.Permissions.Extensions[identityExtension]
+	ctx := clientContext(sconn)
+	audit.Record(ctx, &audit.Event{Action: audit.UserLogin, Actor: identity, Target: identity})
+	userFS := vfs.New(ctx, identity)
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			log.Error("failed to accept channel", "user", userFS.UserIdentity(), "error", err)
+			return
+		}
+		go serveSession(channel, requests, userFS)
+	}
+}
+
+// serveSession runs the sftp subsystem on the channel. Shells and commands
+// are refused.
+func serveSession(channel ssh.Channel, requests <-chan *ssh.Request, userFS *vfs.FS) {
+	defer channel.Close()
+	for req := range requests {
+		if req.Type != "subsystem" || len(req.Payload) < 4 || string(req.Payload[4:]) != "sftp" {
+			req.Reply(false, nil)
+			continue
+		}
+		req.Reply(true, nil)
+
+		h := &handlers{fs: userFS}
+		server := sftp.NewRequestServer(channel, sftp.Handlers{FileGet: h, FilePut: h, FileCmd: h, FileList: h})
+		if err := server.Serve(); err != nil && !errors.Is(err, io.EOF) {
+			log.Error("session ended", "user", userFS.UserIdentity(), "error", err)
+		}
+		server.Close()
+		return
+	}
+}
+
+func loadHostKey() (ssh.Signer, error) {
+	data, err := os.ReadFile(HostKeyPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		block, err := ssh.MarshalPrivateKey(priv, "cloud-storage")
+		if err != nil {
+			return nil, err
+		}
+		data = pem.EncodeToMemory(block)
+		if err := os.MkdirAll(filepath.Dir(HostKeyPath), os.ModePerm); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(HostKeyPath, data, 0o600); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	return ssh.ParsePrivateKey(data)
+}
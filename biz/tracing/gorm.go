@@ -0,0 +1,97 @@
+package tracing
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const spanKey = "cloud-storage:tracing_span"
+
+// querySpan is the span of a query running.
+type querySpan struct {
+	trace.Span
+	operation string
+}
+
+var dbTracer = otel.Tracer("cloud-storage/biz/dal")
+
+// GormPlugin returns the gorm plugin recording a span for every query of a
+// database, as a child of the span in the context of the query.
+func GormPlugin() gorm.Plugin {
+	return gormPlugin{}
+}
+
+type gormPlugin struct{}
+
+func (gormPlugin) Name() string {
+	return "cloud-storage:tracing"
+}
+
+func (gormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Create().Before("gorm:create").Register("cloud-storage:tracing_start", start("create")),
+		cb.Create().After("gorm:create").Register("cloud-storage:tracing_end", end),
+		cb.Query().Before("gorm:query").Register("cloud-storage:tracing_start", start("query")),
+		cb.Query().After("gorm:query").Register("cloud-storage:tracing_end", end),
+		cb.Update().Before("gorm:update").Register("cloud-storage:tracing_start", start("update")),
+		cb.Update().After("gorm:update").Register("cloud-storage:tracing_end", end),
+		cb.Delete().Before("gorm:delete").Register("cloud-storage:tracing_start", start("delete")),
+		cb.Delete().After("gorm:delete").Register("cloud-storage:tracing_end", end),
+		cb.Row().Before("gorm:row").Register("cloud-storage:tracing_start", start("row")),
+		cb.Row().After("gorm:row").Register("cloud-storage:tracing_end", end),
+		cb.Raw().Before("gorm:raw").Register("cloud-storage:tracing_start", start("raw")),
+		cb.Raw().After("gorm:raw").Register("cloud-storage:tracing_end", end),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func start(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if ctx == nil || !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+			// Queries outside of a request or job would each start a trace
+			return
+		}
+		_, span := dbTracer.Start(ctx, "db."+operation, trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemNameKey.String(db.Dialector.Name()),
+				semconv.DBOperationName(operation),
+			))
+		db.InstanceSet(spanKey, &querySpan{Span: span, operation: operation})
+	}
+}
+
+func end(db *gorm.DB) {
+	v, ok := db.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+	span := v.(*querySpan)
+	defer span.End()
+
+	// The statement has placeholders in place of the values, which may be
+	// secrets
+	span.SetAttributes(
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		semconv.DBCollectionName(db.Statement.Table),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+	if db.Statement.Table != "" {
+		span.SetName("db." + span.operation + " " + db.Statement.Table)
+	}
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
@@ -0,0 +1,382 @@
+package vfs
+
+import (
+	"cloud-storage/biz/dal/entity"
+	"cloud-storage/biz/errno"
+	"cloud-storage/biz/service"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotDir        = errors.New("not a directory")
+	ErrIsDir         = errors.New("is a directory")
+	ErrRoot          = errors.New("operation not permitted on the root folder")
+	ErrRecursive     = errors.New("cannot move a folder into itself")
+	ErrQuotaExceeded = errors.New("storage quota exceeded")
+)
+
+// Entry is a file or folder in a user's tree. Files carry the pool record of
+// their content.
+type Entry struct {
+	*entity.UserRepository
+	Pool *entity.RepositoryPool
+}
+
+// IsDir reports whether the entry is a folder.
+func (e *Entry) IsDir() bool {
+	return e.RepositoryIdentity == ""
+}
+
+// Size returns the size of the file content, or 0 for folders.
+func (e *Entry) Size() int64 {
+	if e.Pool == nil {
+		return 0
+	}
+	return e.Pool.Size
+}
+
+// IsRoot reports whether the entry is the virtual root folder.
+func (e *Entry) IsRoot() bool {
+	return e.ID == 0
+}
+
+// Info returns the entry as an fs.FileInfo.
+func (e *Entry) Info() fs.FileInfo {
+	return fileInfo{e}
+}
+
+type fileInfo struct {
+	entry *Entry
+}
+
+func (fi fileInfo) Name() string {
+	if fi.entry.IsRoot() {
+		return "/"
+	}
+	return fi.entry.Name
+}
+
+func (fi fileInfo) Size() int64 {
+	return fi.entry.Size()
+}
+
+func (fi fileInfo) Mode() fs.FileMode {
+	if fi.entry.IsDir() {
+		return fs.ModeDir | 0o755
+	}
+	return 0o644
+}
+
+func (fi fileInfo) ModTime() time.Time {
+	return fi.entry.UpdatedAt
+}
+
+func (fi fileInfo) IsDir() bool {
+	return fi.entry.IsDir()
+}
+
+func (fi fileInfo) Sys() interface{} {
+	return nil
+}
+
+// FS exposes the files and folders of one user as a hierarchical file
+// system addressed by slash-separated paths, on top of service.Files.
+// Folders are entries without a repository identity; the root folder is
+// virtual and has ID 0.
+type FS struct {
+	userIdentity string
+	ctx          context.Context
+}
+
+// New returns the file system of the given user, running its queries with
+// ctx, e.g. that of the request the file system serves.
+func New(ctx context.Context, userIdentity string) *FS {
+	return &FS{userIdentity: userIdentity, ctx: ctx}
+}
+
+// UserIdentity returns the identity of the user owning the file system.
+func (f *FS) UserIdentity() string {
+	return f.userIdentity
+}
+
+// Context returns the context of the queries run on behalf of the user.
+func (f *FS) Context() context.Context {
+	return f.ctx
+}
+
+// Root returns the virtual root folder.
+func (f *FS) Root() *Entry {
+	return &Entry{UserRepository: &entity.UserRepository{UserIdentity: f.userIdentity}}
+}
+
+// Stat resolves name to an entry, returning fs.ErrNotExist if any component
+// of the path does not exist.
+func (f *FS) Stat(name string) (*Entry, error) {
+	e := f.Root()
+	for _, elem := range split(name) {
+		if !e.IsDir() {
+			return nil, fs.ErrNotExist
+		}
+		child, err := f.child(int32(e.ID), elem)
+		if err != nil {
+			return nil, err
+		}
+		e = child
+	}
+	return e, nil
+}
+
+// ReadDir returns the entries of the folder dir.
+func (f *FS) ReadDir(dir *Entry) ([]*Entry, error) {
+	if !dir.IsDir() {
+		return nil, ErrNotDir
+	}
+	urs, err := service.Files.Children(f.ctx, f.userIdentity, []int32{int32(dir.ID)})
+	if err != nil {
+		return nil, err
+	}
+	return f.withPools(urs)
+}
+
+// Mkdir creates the folder name. Its parent must already exist.
+func (f *FS) Mkdir(name string) (*Entry, error) {
+	parent, base, err := f.parent(name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := service.Files.CreateFolder(f.ctx, f.userIdentity, int64(parent.ID), base); err != nil {
+		return nil, fsError(err)
+	}
+	return f.child(int32(parent.ID), base)
+}
+
+// MkdirAll creates the folder name along with any missing parents. It
+// returns the existing folder if there is one.
+func (f *FS) MkdirAll(name string) (*Entry, error) {
+	e, cur := f.Root(), "/"
+	for _, elem := range split(name) {
+		if !e.IsDir() {
+			return nil, ErrNotDir
+		}
+		cur = path.Join(cur, elem)
+		child, err := f.child(int32(e.ID), elem)
+		if errors.Is(err, fs.ErrNotExist) {
+			child, err = f.Mkdir(cur)
+		}
+		if err != nil {
+			return nil, err
+		}
+		e = child
+	}
+	if !e.IsDir() {
+		return nil, ErrNotDir
+	}
+	return e, nil
+}
+
+// Put stores the content read from r as the file name, replacing the content
+// of an existing file. The content goes through the repository pool, so
+// identical content is stored only once. It fails with ErrQuotaExceeded if
+// the file would take the user over their storage quota.
+func (f *FS) Put(name string, r io.Reader) (*Entry, error) {
+	parent, base, err := f.target(name)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := service.Uploads.Upload(f.ctx, base, r)
+	if err != nil {
+		return nil, err
+	}
+	return f.link(parent, base, rp)
+}
+
+// Link makes name refer to the pooled content rp without copying it,
+// replacing the content of an existing file. Like Put, it counts against the
+// storage quota.
+func (f *FS) Link(name string, rp *entity.RepositoryPool) (*Entry, error) {
+	parent, base, err := f.target(name)
+	if err != nil {
+		return nil, err
+	}
+	return f.link(parent, base, rp)
+}
+
+// Walk calls fn for every entry below dir, level by level, with the path of
+// the entry relative to dir.
+func (f *FS) Walk(dir *Entry, fn func(name string, e *Entry) error) error {
+	if !dir.IsDir() {
+		return ErrNotDir
+	}
+	paths := map[uint32]string{dir.ID: ""}
+	for level := []int32{int32(dir.ID)}; len(level) > 0; {
+		urs, err := service.Files.Children(f.ctx, f.userIdentity, level)
+		if err != nil {
+			return err
+		}
+		entries, err := f.withPools(urs)
+		if err != nil {
+			return err
+		}
+		level = level[:0]
+		for _, e := range entries {
+			name := path.Join(paths[uint32(e.ParentID)], e.Name)
+			if err := fn(name, e); err != nil {
+				return err
+			}
+			if e.IsDir() {
+				paths[e.ID] = name
+				level = append(level, int32(e.ID))
+			}
+		}
+	}
+	return nil
+}
+
+// target resolves where the file name goes: its parent folder and its base
+// name. A folder must not be there already.
+func (f *FS) target(name string) (*Entry, string, error) {
+	parent, base, err := f.parent(name)
+	if err != nil {
+		return nil, "", err
+	}
+	existing, err := f.child(int32(parent.ID), base)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, "", err
+	}
+	if existing != nil && existing.IsDir() {
+		return nil, "", ErrIsDir
+	}
+	return parent, base, nil
+}
+
+// link points the file called base inside parent at rp, creating it if
+// there is none.
+func (f *FS) link(parent *Entry, base string, rp *entity.RepositoryPool) (*Entry, error) {
+	ur, err := service.Files.Link(f.ctx, f.userIdentity, int64(parent.ID), base, rp.Identity)
+	if err != nil {
+		return nil, fsError(err)
+	}
+	return &Entry{UserRepository: ur, Pool: rp}, nil
+}
+
+// Remove deletes name and, for folders, everything below it.
+func (f *FS) Remove(name string) error {
+	e, err := f.Stat(name)
+	if err != nil {
+		return err
+	}
+	if e.IsRoot() {
+		return ErrRoot
+	}
+	return fsError(service.Files.Delete(f.ctx, f.userIdentity, e.Identity))
+}
+
+// Rename moves oldName to newName. The destination must not exist and its
+// parent folder must.
+func (f *FS) Rename(oldName, newName string) error {
+	src, err := f.Stat(oldName)
+	if err != nil {
+		return err
+	}
+	if src.IsRoot() {
+		return ErrRoot
+	}
+	parent, base, err := f.parent(newName)
+	if err != nil {
+		return err
+	}
+	err = service.Files.MoveTo(f.ctx, f.userIdentity, src.Identity, int64(parent.ID), base)
+	if err != nil && errno.CodeOf(err) == errno.InvalidArgument {
+		// The only argument MoveTo refuses is a folder moving below itself
+		return ErrRecursive
+	}
+	return fsError(err)
+}
+
+// child returns the entry called name directly inside the folder parentID.
+func (f *FS) child(parentID int32, name string) (*Entry, error) {
+	ur, err := service.Files.Child(f.ctx, f.userIdentity, parentID, name)
+	if err != nil {
+		return nil, fsError(err)
+	}
+	entries, err := f.withPools([]*entity.UserRepository{ur})
+	if err != nil {
+		return nil, err
+	}
+	return entries[0], nil
+}
+
+// parent resolves the folder that contains name, and the last element of name.
+func (f *FS) parent(name string) (*Entry, string, error) {
+	elems := split(name)
+	if len(elems) == 0 {
+		return nil, "", ErrRoot
+	}
+	parent, err := f.Stat("/" + strings.Join(elems[:len(elems)-1], "/"))
+	if err != nil {
+		return nil, "", err
+	}
+	if !parent.IsDir() {
+		return nil, "", ErrNotDir
+	}
+	return parent, elems[len(elems)-1], nil
+}
+
+// withPools wraps urs into entries, loading the pool records of the files.
+func (f *FS) withPools(urs []*entity.UserRepository) ([]*Entry, error) {
+	var identities []string
+	for _, ur := range urs {
+		if ur.RepositoryIdentity != "" {
+			identities = append(identities, ur.RepositoryIdentity)
+		}
+	}
+	pools := make(map[string]*entity.RepositoryPool, len(identities))
+	if len(identities) > 0 {
+		rps, err := service.Files.Blobs(f.ctx, identities)
+		if err != nil {
+			return nil, err
+		}
+		for _, rp := range rps {
+			pools[rp.Identity] = rp
+		}
+	}
+
+	entries := make([]*Entry, 0, len(urs))
+	for _, ur := range urs {
+		entries = append(entries, &Entry{UserRepository: ur, Pool: pools[ur.RepositoryIdentity]})
+	}
+	return entries, nil
+}
+
+func split(name string) []string {
+	name = strings.Trim(path.Clean("/"+name), "/")
+	if name == "" {
+		return nil
+	}
+	return strings.Split(name, "/")
+}
+
+// fsError reports the errors of the file service the way the fs package
+// does: entries or parents that are missing as fs.ErrNotExist and names
+// already taken in a folder as fs.ErrExist. Running out of quota is
+// ErrQuotaExceeded.
+func fsError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch errno.CodeOf(err) {
+	case errno.FileNotFound, errno.FolderNotFound:
+		return fs.ErrNotExist
+	case errno.NameConflict:
+		return fs.ErrExist
+	case errno.QuotaExceeded:
+		return ErrQuotaExceeded
+	}
+	return err
+}
@@ -0,0 +1,271 @@
+package webdav
+
+import (
+	"cloud-storage/biz/blob"
+	"cloud-storage/biz/vfs"
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/net/webdav"
+)
+
+// fileSystem adapts a user's vfs.FS to webdav.FileSystem.
+type fileSystem struct {
+	fs *vfs.FS
+	// quotaExceeded is set once a write ran out of storage quota, which
+	// webdav.Handler cannot report itself
+	quotaExceeded bool
+}
+
+func (f *fileSystem) Mkdir(ctx context.Context, name string, perm os.FileMode) error {
+	_, err := f.fs.Mkdir(name)
+	return err
+}
+
+func (f *fileSystem) OpenFile(ctx context.Context, name string, flag int, perm os.FileMode) (webdav.File, error) {
+	e, err := f.fs.Stat(name)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if e == nil && flag&os.O_CREATE == 0 {
+		return nil, fs.ErrNotExist
+	}
+	if e == nil {
+		parent, err := f.fs.Stat(path.Dir(name))
+		if err != nil {
+			return nil, err
+		}
+		if !parent.IsDir() {
+			return nil, fs.ErrNotExist
+		}
+	}
+	if e != nil && flag&(os.O_CREATE|os.O_EXCL) == os.O_CREATE|os.O_EXCL {
+		return nil, fs.ErrExist
+	}
+
+	// Content is immutable once pooled, so any write replaces the whole file
+	if e == nil || flag&os.O_TRUNC != 0 {
+		if e != nil && e.IsDir() {
+			return nil, vfs.ErrIsDir
+		}
+		return newWriteFile(f, name)
+	}
+	if e.IsDir() {
+		return &dirFile{fs: f.fs, entry: e, props: props{fs: f.fs, identity: e.Identity}}, nil
+	}
+	file, err := blob.Open(ctx, e.Pool)
+	if err != nil {
+		return nil, err
+	}
+	return &readFile{File: file, entry: e, props: props{fs: f.fs, identity: e.Identity}}, nil
+}
+
+func (f *fileSystem) RemoveAll(ctx context.Context, name string) error {
+	return f.fs.Remove(name)
+}
+
+func (f *fileSystem) Rename(ctx context.Context, oldName, newName string) error {
+	return f.fs.Rename(oldName, newName)
+}
+
+func (f *fileSystem) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	e, err := f.fs.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	return newFileInfo(e), nil
+}
+
+// fileInfo describes an entry. Files use their content hash as ETag.
+type fileInfo struct {
+	fs.FileInfo
+	entry *vfs.Entry
+}
+
+func newFileInfo(e *vfs.Entry) fileInfo {
+	return fileInfo{FileInfo: e.Info(), entry: e}
+}
+
+func (fi fileInfo) ETag(ctx context.Context) (string, error) {
+	if fi.entry.Pool == nil {
+		return "", webdav.ErrNotImplemented
+	}
+	return `"` + fi.entry.Pool.Hash + `"`, nil
+}
+
+func (fi fileInfo) ContentType(ctx context.Context) (string, error) {
+	if ctype := mime.TypeByExtension(filepath.Ext(fi.entry.Name)); ctype != "" {
+		return ctype, nil
+	}
+	return "", webdav.ErrNotImplemented
+}
+
+// readFile serves the content of a pooled blob.
+type readFile struct {
+	*blob.File
+	props
+	entry *vfs.Entry
+}
+
+func (f *readFile) Readdir(count int) ([]fs.FileInfo, error) {
+	return nil, vfs.ErrNotDir
+}
+
+func (f *readFile) Stat() (fs.FileInfo, error) {
+	return newFileInfo(f.entry), nil
+}
+
+func (f *readFile) Write(p []byte) (int, error) {
+	return 0, fs.ErrPermission
+}
+
+// dirFile lists a folder.
+type dirFile struct {
+	props
+	fs      *vfs.FS
+	entry   *vfs.Entry
+	entries []fs.FileInfo
+	read    bool
+}
+
+func (f *dirFile) Close() error {
+	return nil
+}
+
+func (f *dirFile) Read(p []byte) (int, error) {
+	return 0, vfs.ErrIsDir
+}
+
+func (f *dirFile) Seek(offset int64, whence int) (int64, error) {
+	return 0, vfs.ErrIsDir
+}
+
+func (f *dirFile) Write(p []byte) (int, error) {
+	return 0, vfs.ErrIsDir
+}
+
+func (f *dirFile) Stat() (fs.FileInfo, error) {
+	return newFileInfo(f.entry), nil
+}
+
+func (f *dirFile) Readdir(count int) ([]fs.FileInfo, error) {
+	if !f.read {
+		entries, err := f.fs.ReadDir(f.entry)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			f.entries = append(f.entries, newFileInfo(e))
+		}
+		f.read = true
+	}
+	if count <= 0 {
+		entries := f.entries
+		f.entries = nil
+		return entries, nil
+	}
+	if len(f.entries) == 0 {
+		return nil, io.EOF
+	}
+	n := min(count, len(f.entries))
+	entries := f.entries[:n]
+	f.entries = f.entries[n:]
+	return entries, nil
+}
+
+// writeFile buffers written content in a temporary file and stores it
+// through vfs.FS.Put when closed. Property patches received before then are
+// applied once the entry exists.
+type writeFile struct {
+	fs            *vfs.FS
+	quotaExceeded *bool
+	name          string
+	tmp           *os.File
+	size          int64
+	pending       [][]webdav.Proppatch
+}
+
+func newWriteFile(f *fileSystem, name string) (*writeFile, error) {
+	tmp, err := os.CreateTemp("", "cloud-storage-dav-*")
+	if err != nil {
+		return nil, err
+	}
+	return &writeFile{fs: f.fs, quotaExceeded: &f.quotaExceeded, name: name, tmp: tmp}, nil
+}
+
+func (f *writeFile) Write(p []byte) (int, error) {
+	n, err := f.tmp.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *writeFile) Read(p []byte) (int, error) {
+	return 0, fs.ErrPermission
+}
+
+func (f *writeFile) Seek(offset int64, whence int) (int64, error) {
+	return 0, fs.ErrPermission
+}
+
+func (f *writeFile) Readdir(count int) ([]fs.FileInfo, error) {
+	return nil, vfs.ErrNotDir
+}
+
+func (f *writeFile) Stat() (fs.FileInfo, error) {
+	return pendingInfo{name: filepath.Base(f.name), size: f.size}, nil
+}
+
+func (f *writeFile) DeadProps() (map[xml.Name]webdav.Property, error) {
+	return nil, nil
+}
+
+func (f *writeFile) Patch(patches []webdav.Proppatch) ([]webdav.Propstat, error) {
+	f.pending = append(f.pending, patches)
+	return okPropstats(patches), nil
+}
+
+func (f *writeFile) Close() error {
+	defer os.Remove(f.tmp.Name())
+	if _, err := f.tmp.Seek(0, io.SeekStart); err != nil {
+		f.tmp.Close()
+		return err
+	}
+	e, err := f.fs.Put(f.name, f.tmp)
+	if closeErr := f.tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if errors.Is(err, vfs.ErrQuotaExceeded) {
+		*f.quotaExceeded = true
+	}
+	if err != nil {
+		return err
+	}
+	p := props{fs: f.fs, identity: e.Identity}
+	for _, patches := range f.pending {
+		if _, err := p.Patch(patches); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pendingInfo describes a file that is still being written.
+type pendingInfo struct {
+	name string
+	size int64
+}
+
+func (fi pendingInfo) Name() string       { return fi.name }
+func (fi pendingInfo) Size() int64        { return fi.size }
+func (fi pendingInfo) Mode() fs.FileMode  { return 0o644 }
+func (fi pendingInfo) ModTime() time.Time { return time.Now() }
+func (fi pendingInfo) IsDir() bool        { return false }
+func (fi pendingInfo) Sys() interface{}   { return nil }
@@ -0,0 +1,59 @@
+package webdav
+
+import (
+	"cloud-storage/biz/service"
+	"cloud-storage/biz/vfs"
+	"encoding/xml"
+	"net/http"
+
+	"golang.org/x/net/webdav"
+)
+
+// props stores the dead properties of an entry in user_repository_property.
+// They are keyed by the entry identity, so they follow the entry on MOVE.
+type props struct {
+	fs       *vfs.FS
+	identity string
+}
+
+func (p props) DeadProps() (map[xml.Name]webdav.Property, error) {
+	rows, err := service.Files.Properties(p.fs.Context(), p.fs.UserIdentity(), p.identity)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[xml.Name]webdav.Property, len(rows))
+	for _, row := range rows {
+		name := xml.Name{Space: row.Space, Local: row.Name}
+		m[name] = webdav.Property{XMLName: name, Lang: row.Lang, InnerXML: []byte(row.InnerXML)}
+	}
+	return m, nil
+}
+
+func (p props) Patch(patches []webdav.Proppatch) ([]webdav.Propstat, error) {
+	var ps []*service.PropertyPatch
+	for _, patch := range patches {
+		for _, prop := range patch.Props {
+			ps = append(ps, &service.PropertyPatch{
+				Space:    prop.XMLName.Space,
+				Name:     prop.XMLName.Local,
+				Lang:     prop.Lang,
+				InnerXML: string(prop.InnerXML),
+				Remove:   patch.Remove,
+			})
+		}
+	}
+	if err := service.Files.PatchProperties(p.fs.Context(), p.fs.UserIdentity(), p.identity, ps); err != nil {
+		return nil, err
+	}
+	return okPropstats(patches), nil
+}
+
+func okPropstats(patches []webdav.Proppatch) []webdav.Propstat {
+	pstat := webdav.Propstat{Status: http.StatusOK}
+	for _, patch := range patches {
+		for _, prop := range patch.Props {
+			pstat.Props = append(pstat.Props, webdav.Property{XMLName: prop.XMLName})
+		}
+	}
+	return []webdav.Propstat{pstat}
+}
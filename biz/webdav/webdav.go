@@ -0,0 +1,119 @@
+package webdav
+
+import (
+	"cloud-storage/biz/errno"
+	"cloud-storage/biz/logging"
+	"cloud-storage/biz/ratelimit"
+	"cloud-storage/biz/service"
+	"cloud-storage/biz/vfs"
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/cloudwego/hertz/pkg/common/adaptor"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"golang.org/x/net/webdav"
+)
+
+var log = logging.Logger("webdav")
+
+// Prefix is the URL path under which each user's tree is mounted.
+const Prefix = "/dav"
+
+var methods = []string{
+	consts.MethodOptions, consts.MethodGet, consts.MethodHead, consts.MethodPost,
+	consts.MethodPut, consts.MethodDelete,
+	"PROPFIND", "PROPPATCH", "MKCOL", "COPY", "MOVE", "LOCK", "UNLOCK",
+}
+
+// locks holds one lock system per user so that lock tokens and paths of
+// different users never collide.
+var locks sync.Map
+
+// Register mounts the WebDAV server on r.
+func Register(r *server.Hertz) {
+	for _, method := range methods {
+		r.Handle(method, Prefix, Handler)
+		r.Handle(method, Prefix+"/*path", Handler)
+	}
+}
+
+// Handler serves the tree of the user authenticated by HTTP basic auth.
+// Clients send the password with every request, so only failed logins count
+// against the login rate limits.
+func Handler(ctx context.Context, c *app.RequestContext) {
+	name, password, ok := c.Request.BasicAuth()
+	if !ok {
+		unauthorized(c)
+		return
+	}
+	login := ratelimit.Login{IP: c.ClientIP(), Name: name}
+	retryAfter, err := login.Check(ctx)
+	if err != nil {
+		log.WarnContext(ctx, "failed to check rate limit", "error", err)
+	}
+	if retryAfter > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		c.String(consts.StatusTooManyRequests, "too many failed logins")
+		return
+	}
+	userBasic, err := service.Users.Authenticate(ctx, name, password)
+	if errno.CodeOf(err) == errno.InvalidCredentials {
+		if err := login.Fail(ctx); err != nil {
+			log.WarnContext(ctx, "failed to count failed login", "error", err)
+		}
+	}
+	if err != nil {
+		unauthorized(c)
+		return
+	}
+	logging.SetUser(ctx, userBasic.Identity)
+
+	ls, _ := locks.LoadOrStore(userBasic.Identity, webdav.NewMemLS())
+	fsys := &fileSystem{fs: vfs.New(ctx, userBasic.Identity)}
+	h := &webdav.Handler{
+		Prefix:     Prefix,
+		FileSystem: fsys,
+		LockSystem: ls.(webdav.LockSystem),
+	}
+	adaptor.HertzHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(&quotaWriter{ResponseWriter: w, fs: fsys}, r)
+	}))(ctx, c)
+}
+
+// quotaWriter turns the error status webdav.Handler picks for a write that
+// ran out of storage quota, 405 for PUT and 500 for COPY, into 507
+// Insufficient Storage.
+type quotaWriter struct {
+	http.ResponseWriter
+	fs *fileSystem
+	// replaced is set once the status was turned into 507, so that the body
+	// of the original status is dropped
+	replaced bool
+}
+
+func (w *quotaWriter) WriteHeader(status int) {
+	if status >= http.StatusBadRequest && w.fs.quotaExceeded {
+		w.replaced = true
+		w.ResponseWriter.WriteHeader(http.StatusInsufficientStorage)
+		w.ResponseWriter.Write([]byte(http.StatusText(http.StatusInsufficientStorage)))
+		return
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *quotaWriter) Write(p []byte) (int, error) {
+	if w.replaced {
+		return len(p), nil
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+func unauthorized(c *app.RequestContext) {
+	c.Header("WWW-Authenticate", `Basic realm="cloud-storage"`)
+	c.String(consts.StatusUnauthorized, "authentication required")
+}
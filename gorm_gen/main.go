@@ -1,14 +1,25 @@
 package main
 
 import (
-	"gorm.io/driver/mysql"
+	"cloud-storage/biz/config"
+	"cloud-storage/biz/dal/driver"
+	"cloud-storage/biz/dal/migrate"
+	"flag"
 	"gorm.io/gen"
 	"gorm.io/gorm"
 	"os"
 	"path/filepath"
 )
 
+var configPath = flag.String("config", "", "path of the YAML or TOML configuration file (default $"+config.PathEnv+")")
+
 func main() {
+	flag.Parse()
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		panic(err)
+	}
+
 	workdir, err := os.Getwd()
 	if err != nil {
 		panic(err)
@@ -27,15 +38,39 @@ func main() {
 		FieldWithTypeTag:  true,
 	})
 
-	dsn := "root:123456@(127.0.0.1:3306)/cloud_storage?charset=utf8mb4&parseTime=True&loc=Local"
-	db, _ := gorm.Open(mysql.Open(dsn))
+	dialector, err := driver.Dialector(cfg.Database.Driver, cfg.Database.DSN)
+	if err != nil {
+		panic(err)
+	}
+	db, err := gorm.Open(dialector)
+	if err != nil {
+		panic(err)
+	}
+	// Generate from the schema the migrations lead to
+	m, err := migrate.New(db, cfg.Database.Driver)
+	if err != nil {
+		panic(err)
+	}
+	if err := m.Up(); err != nil {
+		panic(err)
+	}
 	g.UseDB(db)
 
 	g.ApplyBasic(
+		g.GenerateModel("audit_log"),
+		g.GenerateModel("job"),
+		g.GenerateModel("job_schedule"),
 		g.GenerateModel("repository_pool"),
 		g.GenerateModel("share_basic"),
+		g.GenerateModel("user_access_key"),
+		g.GenerateModel("user_api_token"),
 		g.GenerateModel("user_basic"),
+		g.GenerateModel("user_password_reset"),
+		g.GenerateModel("user_recovery_code"),
 		g.GenerateModel("user_repository"),
+		g.GenerateModel("user_repository_property"),
+		g.GenerateModel("user_session"),
+		g.GenerateModel("user_ssh_key"),
 	)
 
 	g.Execute()
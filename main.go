@@ -3,19 +3,134 @@
 package main
 
 import (
+	"cloud-storage/biz/blob"
+	"cloud-storage/biz/config"
 	"cloud-storage/biz/dal"
+	"cloud-storage/biz/dal/query"
+	"cloud-storage/biz/dal/repository"
+	"cloud-storage/biz/fulltext"
+	"cloud-storage/biz/health"
+	"cloud-storage/biz/jobs"
+	"cloud-storage/biz/logging"
+	"cloud-storage/biz/mail"
+	"cloud-storage/biz/metrics"
 	"cloud-storage/biz/mw"
+	"cloud-storage/biz/ratelimit"
+	"cloud-storage/biz/rpc"
+	"cloud-storage/biz/s3"
+	"cloud-storage/biz/service"
+	"cloud-storage/biz/sftp"
+	"cloud-storage/biz/tracing"
+	"context"
+	"flag"
+	"os"
+
 	"github.com/cloudwego/hertz/pkg/app/server"
-	"github.com/hertz-contrib/logger/accesslog"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
 )
 
+var configPath = flag.String("config", "", "path of the YAML or TOML configuration file (default $"+config.PathEnv+")")
+
 func main() {
-	h := server.Default()
-	h.Use(accesslog.New())
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "admin" {
+		os.Exit(runAdmin(os.Args[2:]))
+	}
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		hlog.Fatalf("failed to load configuration: %v", err)
+	}
+	if err := logging.Init(&cfg.Log); err != nil {
+		hlog.Fatalf("failed to set up logging: %v", err)
+	}
+	shutdownTracing, err := tracing.Init(context.Background(), &cfg.Tracing)
+	if err != nil {
+		hlog.Fatalf("failed to set up tracing: %v", err)
+	}
+
+	// Stream request bodies so that large WebDAV uploads are not buffered in
+	// memory. On SIGTERM, the requests in progress get the shutdown timeout
+	// to finish.
+	h := server.Default(
+		server.WithHostPorts(cfg.Server.Addr),
+		server.WithStreamBody(true),
+		server.WithMaxRequestBodySize(cfg.Limits.MaxRequestBodySize),
+		server.WithExitWaitTime(cfg.Server.ShutdownTimeout),
+	)
+	proxies, err := cfg.Server.TrustedProxyNets()
+	if err != nil {
+		hlog.Fatalf("invalid trusted proxies: %v", err)
+	}
+	h.SetClientIPFunc(mw.ClientIPFunc(proxies))
+	h.Use(mw.Metrics(), mw.Tracing(), mw.RequestIDHandler(), mw.AuditClient(), mw.AccessLog(), mw.ErrorHandler())
+	h.SetCustomSignalWaiter(health.SignalWaiter(cfg.Server.ShutdownDelay))
+	h.OnShutdown = append(h.OnShutdown, func(ctx context.Context) {
+		if err := shutdownTracing(ctx); err != nil {
+			hlog.Errorf("failed to flush traces: %v", err)
+		}
+	})
+
+	blob.Dir = cfg.Storage.BlobDir()
+	s3.MultipartDir = cfg.Storage.MultipartDir()
+	sftp.HostKeyPath = cfg.Storage.HostKeyPath()
 
-	dal.Init()
-	mw.InitJwt()
+	dal.Init(&cfg.Database)
+	fulltext.Init(cfg.Storage.IndexDir())
+	mw.InitJwt(&cfg.JWT, &cfg.TwoFactor)
+	if err := ratelimit.Init(&cfg.RateLimit, nil); err != nil {
+		hlog.Fatalf("failed to set up rate limits: %v", err)
+	}
+	service.Init(repository.New(query.Q), service.Options{
+		Tokens:     service.Tokens{Access: mw.GenerateToken, Challenge: mw.GenerateChallenge},
+		UserQuota:  cfg.Quota.UserBytes,
+		Lockout:    service.Lockout{Threshold: cfg.Lockout.Threshold, Duration: cfg.Lockout.Duration},
+		TOTPIssuer: cfg.TwoFactor.Issuer,
+		Mailer:     mail.New(&cfg.Mailer),
+		PasswordReset: service.PasswordReset{
+			URL: cfg.PasswordReset.URL,
+			TTL: cfg.PasswordReset.TTL,
+		},
+	})
+	// Jobs running at shutdown are canceled and retried later, here or by
+	// another process
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	jobs.Start(jobsCtx, &cfg.Jobs)
+	h.OnShutdown = append(h.OnShutdown, func(context.Context) {
+		stopJobs()
+	})
+
+	if cfg.Server.MetricsAddr != "" {
+		go func() {
+			if err := metrics.ListenAndServe(cfg.Server.MetricsAddr); err != nil {
+				hlog.Fatalf("metrics server stopped: %v", err)
+			}
+		}()
+	}
+
+	if cfg.Server.SFTPAddr != "" {
+		h.OnShutdown = append(h.OnShutdown, sftp.Shutdown)
+		go func() {
+			if err := sftp.ListenAndServe(cfg.Server.SFTPAddr); err != nil {
+				hlog.Fatalf("SFTP server stopped: %v", err)
+			}
+		}()
+	}
 
 	register(h)
+
+	if cfg.Server.GRPCAddr != "" {
+		grpcServer := rpc.New(h.Engine)
+		h.OnShutdown = append(h.OnShutdown, grpcServer.Shutdown)
+		go func() {
+			if err := grpcServer.ListenAndServe(cfg.Server.GRPCAddr); err != nil {
+				hlog.Fatalf("gRPC server stopped: %v", err)
+			}
+		}()
+	}
+
 	h.Spin()
 }
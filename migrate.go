@@ -0,0 +1,106 @@
+package main
+
+import (
+	"cloud-storage/biz/config"
+	"cloud-storage/biz/dal"
+	"cloud-storage/biz/dal/migrate"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const migrateUsage = `usage: %s migrate [-config path] <command>
+
+commands:
+  status        list the migrations and whether they are applied
+  up            apply every pending migration
+  down          revert the last applied migration
+  to <version>  apply or revert migrations until the schema is at version
+`
+
+// runMigrate runs the migrate subcommand with its arguments and returns the
+// exit code.
+func runMigrate(args []string) int {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	path := fs.String("config", "", "path of the YAML or TOML configuration file (default $"+config.PathEnv+")")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), migrateUsage, os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	command := fs.Arg(0)
+	switch {
+	case command == "status" || command == "up" || command == "down":
+		if fs.NArg() != 1 {
+			fs.Usage()
+			return 2
+		}
+	case command == "to":
+		if fs.NArg() != 2 {
+			fs.Usage()
+			return 2
+		}
+	default:
+		fs.Usage()
+		return 2
+	}
+
+	cfg, err := config.Load(*path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load configuration: %v\n", err)
+		return 1
+	}
+	db, err := dal.Open(&cfg.Database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to open database: %v\n", err)
+		return 1
+	}
+	m, err := migrate.New(db, cfg.Database.Driver)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load migrations: %v\n", err)
+		return 1
+	}
+
+	switch command {
+	case "status":
+		err = printStatus(m)
+	case "up":
+		err = m.Up()
+	case "down":
+		err = m.Down()
+	case "to":
+		var version int64
+		version, err = strconv.ParseInt(fs.Arg(1), 10, 64)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid version %q\n", fs.Arg(1))
+			return 2
+		}
+		err = m.To(version)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+func printStatus(m *migrate.Migrator) error {
+	statuses, err := m.Status()
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, s := range statuses {
+		applied := "pending"
+		if s.AppliedAt != nil {
+			applied = s.AppliedAt.Local().Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, s.Name, applied)
+	}
+	return w.Flush()
+}
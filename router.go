@@ -4,12 +4,20 @@ package main
 
 import (
 	handler "cloud-storage/biz/handler"
+	"cloud-storage/biz/handler/admin"
+	"cloud-storage/biz/health"
+	"cloud-storage/biz/s3"
+	"cloud-storage/biz/webdav"
 	"github.com/cloudwego/hertz/pkg/app/server"
 )
 
 // customizeRegister registers customize routers.
 func customizedRegister(r *server.Hertz) {
 	r.GET("/ping", handler.Ping)
+	health.Register(r)
+	webdav.Register(r)
+	s3.Register(r)
+	admin.Register(r)
 
 	// your code ...
 }